@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/mackee/go-readability/internal/dom"
+	"github.com/mackee/go-readability/internal/util"
 )
 
 // AriaNodeType represents the type of an ARIA node.
@@ -173,8 +174,8 @@ func GetAriaRole(element *dom.VElement) string {
 }
 
 // GetAccessibleName returns the accessible name of an element.
-// It follows the accessible name calculation algorithm, prioritizing aria-label,
-// aria-labelledby, alt, title, and text content. The accessible name is what would
+// It follows the accessible name calculation algorithm, prioritizing aria-labelledby,
+// aria-label, alt, title, and text content. The accessible name is what would
 // be announced by screen readers and other assistive technologies.
 //
 // Parameters:
@@ -183,8 +184,20 @@ func GetAriaRole(element *dom.VElement) string {
 // Returns:
 //   - The accessible name as a string
 func GetAccessibleName(element *dom.VElement) string {
-	// Prioritize aria-label attribute
-	if ariaLabel := dom.GetAttribute(element, "aria-label"); ariaLabel != "" {
+	return getAccessibleName(element, nil)
+}
+
+// getAccessibleName implements GetAccessibleName, resolving aria-labelledby through index.
+//
+// Parameters:
+//   - element: The element to get the accessible name for
+//   - index: The ID index shared by the elements of a document, or nil to build one
+//
+// Returns:
+//   - The accessible name as a string
+func getAccessibleName(element *dom.VElement, index *elementIDIndex) string {
+	// Prioritize aria-labelledby and aria-label attributes
+	if ariaLabel := getAriaLabel(element, index); ariaLabel != "" {
 		return ariaLabel
 	}
 
@@ -235,6 +248,70 @@ func GetAccessibleName(element *dom.VElement) string {
 	return ""
 }
 
+// elementIDIndex maps the IDs of a document to their elements, for resolving the
+// aria-labelledby references of many elements without searching the document each time.
+// The map is built on the first lookup and rebuilt when an element of another tree is
+// looked up.
+type elementIDIndex struct {
+	root *dom.VElement
+	byID map[string]*dom.VElement
+}
+
+// lookup returns the first element with the given ID in the tree of element.
+//
+// Parameters:
+//   - element: An element of the tree to search
+//   - id: The ID to look up
+//
+// Returns:
+//   - The element with the ID, or nil if there is none
+func (index *elementIDIndex) lookup(element *dom.VElement, id string) *dom.VElement {
+	root := element
+	for root.Parent() != nil {
+		root = root.Parent()
+	}
+	if index.byID == nil || index.root != root {
+		index.root = root
+		index.byID = make(map[string]*dom.VElement)
+		for _, candidate := range dom.GetElementsByTagName(root, "*") {
+			if candidateID := candidate.ID(); candidateID != "" && index.byID[candidateID] == nil {
+				index.byID[candidateID] = candidate
+			}
+		}
+	}
+	return index.byID[id]
+}
+
+// getAriaLabel returns the label given to an element by ARIA attributes: the text of the
+// elements referenced by aria-labelledby, in order, or else the aria-label attribute.
+// Referenced elements are searched in the tree the element belongs to.
+//
+// Parameters:
+//   - element: The element to get the label for
+//   - index: The ID index shared by the elements of a document, or nil to build one
+//
+// Returns:
+//   - The label with whitespace normalized, or an empty string if the element has none
+func getAriaLabel(element *dom.VElement, index *elementIDIndex) string {
+	if ids := strings.Fields(element.GetAttribute("aria-labelledby")); len(ids) > 0 {
+		if index == nil {
+			index = &elementIDIndex{}
+		}
+		var texts []string
+		for _, id := range ids {
+			if labelElement := index.lookup(element, id); labelElement != nil && labelElement != element {
+				if text := dom.GetInnerText(labelElement, true); text != "" {
+					texts = append(texts, text)
+				}
+			}
+		}
+		if len(texts) > 0 {
+			return strings.Join(texts, " ")
+		}
+	}
+	return strings.Join(strings.Fields(element.GetAttribute("aria-label")), " ")
+}
+
 // GetAriaNodeType determines the AriaNodeType of an element based on its role.
 // This maps ARIA roles to their corresponding AriaNodeType enum values.
 //
@@ -309,7 +386,8 @@ func GetAriaNodeType(element *dom.VElement) AriaNodeType {
 
 // BuildAriaNode builds an AriaNode from a DOM element.
 // This recursively constructs an accessibility tree node from a DOM element,
-// including its properties and children.
+// including its properties and children. Elements nested more than 1000 levels
+// deep are treated as leaves.
 //
 // Parameters:
 //   - element: The DOM element to build an AriaNode from
@@ -317,8 +395,22 @@ func GetAriaNodeType(element *dom.VElement) AriaNodeType {
 // Returns:
 //   - An AriaNode representing the element and its children
 func BuildAriaNode(element *dom.VElement) *AriaNode {
+	return buildAriaNode(element, util.DefaultMaxDepth, &elementIDIndex{})
+}
+
+// buildAriaNode implements BuildAriaNode, descending at most maxDepth levels.
+// The element at the last level is a leaf: its children are not built.
+//
+// Parameters:
+//   - element: The DOM element to build an AriaNode from
+//   - maxDepth: The number of levels left to build, including this element
+//   - index: The ID index shared by the nodes of the tree, for aria-labelledby
+//
+// Returns:
+//   - An AriaNode representing the element and its children
+func buildAriaNode(element *dom.VElement, maxDepth int, index *elementIDIndex) *AriaNode {
 	nodeType := GetAriaNodeType(element)
-	name := GetAccessibleName(element)
+	name := getAccessibleName(element, index)
 	role := GetAriaRole(element)
 
 	// Create basic AriaNode
@@ -409,10 +501,14 @@ func BuildAriaNode(element *dom.VElement) *AriaNode {
 		node.ValueText = value
 	}
 
-	// Build child nodes recursively
+	// Build child nodes recursively, unless the maximum depth is reached
 	var childNodes []*AriaNode
+	children := element.Children
+	if maxDepth <= 1 {
+		children = nil
+	}
 
-	for _, child := range element.Children {
+	for _, child := range children {
 		childElement, ok := dom.AsVElement(child)
 		if !ok {
 			continue
@@ -423,7 +519,7 @@ func BuildAriaNode(element *dom.VElement) *AriaNode {
 			continue
 		}
 
-		childNode := BuildAriaNode(childElement)
+		childNode := buildAriaNode(childElement, maxDepth-1, index)
 
 		// Only add meaningful child nodes
 		if childNode.Name != "" || childNode.Type != AriaNodeTypeGeneric || len(childNode.Children) > 0 {
@@ -477,6 +573,7 @@ func CountAriaNodes(node *AriaNode) int {
 // CompressAriaTree compresses an AriaTree by removing insignificant nodes,
 // merging similar nodes, and simplifying the structure. This produces a more
 // concise and meaningful representation of the document's accessibility structure.
+// Subtrees more than 1000 levels deep are kept uncompressed.
 //
 // Parameters:
 //   - node: The root node of the tree to compress
@@ -484,9 +581,27 @@ func CountAriaNodes(node *AriaNode) int {
 // Returns:
 //   - The compressed tree's root node
 func CompressAriaTree(node *AriaNode) *AriaNode {
+	return compressAriaTree(node, util.DefaultMaxDepth, 0)
+}
+
+// compressAriaTree implements CompressAriaTree, descending at most maxDepth levels.
+// The subtree of a node at the last level is kept uncompressed.
+//
+// Parameters:
+//   - node: The root node of the tree to compress
+//   - maxDepth: The number of levels left to compress, including this node
+//   - maxGroupSize: The maximum number of similar siblings merged into a group (see
+//     groupAriaNodes), or 0 for no limit
+//
+// Returns:
+//   - The compressed tree's root node
+func compressAriaTree(node *AriaNode, maxDepth, maxGroupSize int) *AriaNode {
 	if node == nil {
 		return nil
 	}
+	if maxDepth <= 1 && len(node.Children) > 0 {
+		return node
+	}
 
 	// If no children, return as is (with possible text content check)
 	if len(node.Children) == 0 {
@@ -504,7 +619,7 @@ func CompressAriaTree(node *AriaNode) *AriaNode {
 	// First, recursively compress all children
 	var processedChildren []*AriaNode
 	for _, child := range node.Children {
-		compressed := CompressAriaTree(child)
+		compressed := compressAriaTree(child, maxDepth-1, maxGroupSize)
 		if compressed != nil && !isInsignificantNode(compressed) {
 			// Filter out empty text nodes
 			if compressed.Type != AriaNodeTypeText || (compressed.Name != "" && strings.TrimSpace(compressed.Name) != "") {
@@ -674,7 +789,7 @@ func CompressAriaTree(node *AriaNode) *AriaNode {
 				Type:            nodeType,
 				Role:            string(nodeType),
 				OriginalElement: node.OriginalElement,
-				Children:        nodes,
+				Children:        groupAriaNodes(nodes, maxGroupSize),
 			}
 			mergedChildren = append(mergedChildren, parentNode)
 		} else if len(nodes) == 1 {
@@ -769,6 +884,28 @@ func CompressAriaTree(node *AriaNode) *AriaNode {
 	return &result
 }
 
+// groupAriaNodes returns the children of a group of similar nodes. With a maxGroupSize,
+// only the first maxGroupSize nodes represent the group, and the others are replaced by
+// a text node counting them (e.g. "47 more listitem nodes"), so that long lists keep a trace
+// of their size without being merged as a whole.
+//
+// Parameters:
+//   - nodes: The similar nodes, all of the same type
+//   - maxGroupSize: The maximum number of nodes kept, or 0 for no limit
+//
+// Returns:
+//   - The children of the group node
+func groupAriaNodes(nodes []*AriaNode, maxGroupSize int) []*AriaNode {
+	if maxGroupSize <= 0 || len(nodes) <= maxGroupSize {
+		return nodes
+	}
+	children := append([]*AriaNode{}, nodes[:maxGroupSize]...)
+	return append(children, &AriaNode{
+		Type: AriaNodeTypeText,
+		Name: strconv.Itoa(len(nodes)-maxGroupSize) + " more " + string(nodes[0].Type) + " nodes",
+	})
+}
+
 // BuildAriaTree builds an AriaTree from a DOM document.
 // This constructs a complete accessibility tree from a document, then compresses
 // it to produce a more concise and meaningful representation.
@@ -777,13 +914,30 @@ func CompressAriaTree(node *AriaNode) *AriaNode {
 //   - doc: The DOM document to build an AriaTree from
 //
 // Returns:
-//   - An AriaTree representing the document's accessibility structure
+//   - An AriaTree representing the document's accessibility structure, or nil if the document has no body
 func BuildAriaTree(doc *dom.VDocument) *AriaTree {
+	return buildAriaTree(doc, util.DefaultMaxDepth, 0)
+}
+
+// buildAriaTree implements BuildAriaTree, descending at most maxDepth levels below the body.
+//
+// Parameters:
+//   - doc: The DOM document to build an AriaTree from
+//   - maxDepth: The maximum depth of the tree
+//   - maxGroupSize: The maximum number of similar siblings merged into a group, or 0 for no limit
+//
+// Returns:
+//   - An AriaTree representing the document's accessibility structure, or nil if the document has no body
+func buildAriaTree(doc *dom.VDocument, maxDepth, maxGroupSize int) *AriaTree {
+	if !hasBody(doc) {
+		return nil
+	}
+
 	// Build tree from document body
-	rootNode := BuildAriaNode(doc.Body)
+	rootNode := buildAriaNode(doc.Body, maxDepth, &elementIDIndex{})
 
 	// Compress the tree
-	compressedRoot := CompressAriaTree(rootNode)
+	compressedRoot := compressAriaTree(rootNode, maxDepth, maxGroupSize)
 
 	// Handle special case for root level nesting
 	if compressedRoot.Type == AriaNodeTypeText && len(compressedRoot.Children) > 0 {
@@ -832,6 +986,62 @@ func BuildAriaTree(doc *dom.VDocument) *AriaTree {
 	}
 }
 
+// Walk visits the nodes of the tree in pre-order (a node before its children, children in
+// order), for building custom outputs such as a list of landmarks.
+//
+// Parameters:
+//   - visit: Called with each node and its depth (0 for the root). Returning false stops the walk.
+func (t *AriaTree) Walk(visit func(node *AriaNode, depth int) bool) {
+	if t == nil || t.Root == nil {
+		return
+	}
+	walkAriaNode(t.Root, 0, visit)
+}
+
+// walkAriaNode implements AriaTree.Walk for a subtree.
+//
+// Parameters:
+//   - node: The root of the subtree
+//   - depth: The depth of node in the tree
+//   - visit: The visitor function
+//
+// Returns:
+//   - false if the visitor stopped the walk
+func walkAriaNode(node *AriaNode, depth int, visit func(node *AriaNode, depth int) bool) bool {
+	if node == nil {
+		return true
+	}
+	if !visit(node, depth) {
+		return false
+	}
+	for _, child := range node.Children {
+		if !walkAriaNode(child, depth+1, visit) {
+			return false
+		}
+	}
+	return true
+}
+
+// FindByRole finds the nodes of the tree with the given role, such as "heading" or
+// "navigation", in pre-order. A node matches by its type or its explicit role attribute.
+//
+// Parameters:
+//   - role: The role to find (case-insensitive)
+//
+// Returns:
+//   - The matching nodes, or nil if there are none
+func (t *AriaTree) FindByRole(role string) []*AriaNode {
+	role = strings.ToLower(role)
+	var nodes []*AriaNode
+	t.Walk(func(node *AriaNode, _ int) bool {
+		if string(node.Type) == role || strings.ToLower(node.Role) == role {
+			nodes = append(nodes, node)
+		}
+		return true
+	})
+	return nodes
+}
+
 // AriaTreeToString converts an AriaTree to a string representation.
 // This is useful for debugging and visualizing the accessibility structure of a document.
 //
@@ -955,3 +1165,63 @@ func nodeToString(node *AriaNode, indent int, sb *strings.Builder) {
 		}
 	}
 }
+
+// maxDOTNameLength is the maximum number of characters of a node name in a DOT label.
+// Longer names, such as the text of paragraphs, are cut and end with an ellipsis.
+const maxDOTNameLength = 60
+
+// AriaTreeToDOT converts an AriaTree to a Graphviz digraph, for rendering the structure of
+// a page (e.g. with `dot -Tsvg`). Each node is labeled with its type, its accessible name,
+// and its heading level, and has an edge from its parent.
+//
+// Parameters:
+//   - tree: The AriaTree to convert
+//
+// Returns:
+//   - The DOT source of the graph, or an empty string for an empty tree
+func AriaTreeToDOT(tree *AriaTree) string {
+	if tree == nil || tree.Root == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("digraph aria {\n")
+	sb.WriteString("  node [shape=box];\n")
+
+	// parents[depth] is the ID of the last node visited at that depth
+	var parents []int
+	id := 0
+	tree.Walk(func(node *AriaNode, depth int) bool {
+		parents = append(parents[:depth], id)
+		sb.WriteString("  n" + strconv.Itoa(id) + " [label=\"" + escapeDOTLabel(ariaDOTLabel(node)) + "\"];\n")
+		if depth > 0 {
+			sb.WriteString("  n" + strconv.Itoa(parents[depth-1]) + " -> n" + strconv.Itoa(id) + ";\n")
+		}
+		id++
+		return true
+	})
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// ariaDOTLabel returns the label of a node in AriaTreeToDOT: "type: name", followed by the
+// heading level, with whitespace in the name collapsed and long names cut.
+func ariaDOTLabel(node *AriaNode) string {
+	label := string(node.Type)
+	if name := strings.Join(strings.Fields(node.Name), " "); name != "" {
+		if runes := []rune(name); len(runes) > maxDOTNameLength {
+			name = string(runes[:maxDOTNameLength]) + "…"
+		}
+		label += ": " + name
+	}
+	if node.Level > 0 {
+		label += " (level " + strconv.Itoa(node.Level) + ")"
+	}
+	return label
+}
+
+// escapeDOTLabel escapes the backslashes and double quotes of a label for a quoted DOT string.
+func escapeDOTLabel(label string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(label)
+}
@@ -144,6 +144,33 @@ func TestGetAccessibleName(t *testing.T) {
 	}
 }
 
+func TestGetAriaLabelSharedIndex(t *testing.T) {
+	first, err := ParseHTML(`<html><body><h2 id="title">First</h2><div id="panel" aria-labelledby="title"></div></body></html>`, "")
+	if err != nil {
+		t.Fatalf("ParseHTML failed: %v", err)
+	}
+	second, err := ParseHTML(`<html><body><h2 id="title">Second</h2><div id="panel" aria-labelledby="title missing"></div></body></html>`, "")
+	if err != nil {
+		t.Fatalf("ParseHTML failed: %v", err)
+	}
+
+	// One index serves the elements of a document, and is rebuilt for another document
+	index := &elementIDIndex{}
+	for _, tc := range []struct {
+		doc      *dom.VDocument
+		expected string
+	}{
+		{first, "First"},
+		{first, "First"},
+		{second, "Second"},
+	} {
+		panel := GetElementsByTagName(tc.doc.Body, "div")[0]
+		if label := getAriaLabel(panel, index); label != tc.expected {
+			t.Errorf("getAriaLabel() = %q, want %q", label, tc.expected)
+		}
+	}
+}
+
 func TestBuildAriaNode(t *testing.T) {
 	// Create a test element
 	element := &dom.VElement{
@@ -249,7 +276,181 @@ func TestAriaTreeToString(t *testing.T) {
 	}
 }
 
+func TestAriaTreeToDOT(t *testing.T) {
+	tree := outlineAriaTree()
+	tree.Root.Children[1].Children[1].Name = `Say "hi" to C:\path`
+
+	expected := `digraph aria {
+  node [shape=box];
+  n0 [label="generic"];
+  n1 [label="banner"];
+  n0 -> n1;
+  n2 [label="heading: Site (level 1)"];
+  n1 -> n2;
+  n3 [label="main"];
+  n0 -> n3;
+  n4 [label="heading: Article (level 2)"];
+  n3 -> n4;
+  n5 [label="text: Say \"hi\" to C:\\path"];
+  n3 -> n5;
+  n6 [label="generic: Pages"];
+  n0 -> n6;
+}
+`
+	if result := AriaTreeToDOT(tree); result != expected {
+		t.Errorf("AriaTreeToDOT() =\n%s\nwant\n%s", result, expected)
+	}
+	if result := AriaTreeToDOT(nil); result != "" {
+		t.Errorf("Expected an empty string for a nil tree, got %q", result)
+	}
+}
+
+// outlineAriaTree returns a small tree with a banner, two headings, and a navigation.
+func outlineAriaTree() *AriaTree {
+	return &AriaTree{
+		Root: &AriaNode{
+			Type: AriaNodeTypeGeneric,
+			Children: []*AriaNode{
+				{Type: AriaNodeTypeBanner, Children: []*AriaNode{
+					{Type: AriaNodeTypeHeading, Name: "Site", Level: 1},
+				}},
+				{Type: AriaNodeTypeMain, Children: []*AriaNode{
+					{Type: AriaNodeTypeHeading, Name: "Article", Level: 2},
+					{Type: AriaNodeTypeText, Name: "Body text"},
+				}},
+				{Type: AriaNodeTypeGeneric, Role: "navigation", Name: "Pages"},
+			},
+		},
+		NodeCount: 7,
+	}
+}
+
+func TestAriaTreeWalk(t *testing.T) {
+	tree := outlineAriaTree()
+
+	var visited []string
+	tree.Walk(func(node *AriaNode, depth int) bool {
+		visited = append(visited, strings.Repeat("  ", depth)+string(node.Type)+":"+node.Name)
+		return true
+	})
+	expected := []string{
+		"generic:",
+		"  banner:",
+		"    heading:Site",
+		"  main:",
+		"    heading:Article",
+		"    text:Body text",
+		"  generic:Pages",
+	}
+	if strings.Join(visited, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected walk order:\n%s\nwant:\n%s", strings.Join(visited, "\n"), strings.Join(expected, "\n"))
+	}
+
+	// Returning false stops the walk
+	count := 0
+	tree.Walk(func(node *AriaNode, _ int) bool {
+		count++
+		return node.Type != AriaNodeTypeMain
+	})
+	if count != 4 {
+		t.Errorf("Expected the walk to stop at the 4th node, visited %d", count)
+	}
+
+	// A nil tree has nothing to walk
+	var nilTree *AriaTree
+	nilTree.Walk(func(*AriaNode, int) bool {
+		t.Error("Expected no node in a nil tree")
+		return true
+	})
+}
+
+func TestAriaTreeFindByRole(t *testing.T) {
+	tree := outlineAriaTree()
+
+	headings := tree.FindByRole("heading")
+	if len(headings) != 2 || headings[0].Name != "Site" || headings[1].Name != "Article" {
+		t.Errorf("Expected the headings Site and Article, got %+v", headings)
+	}
+	if navigation := tree.FindByRole("Navigation"); len(navigation) != 1 || navigation[0].Name != "Pages" {
+		t.Errorf("Expected the navigation by explicit role, got %+v", navigation)
+	}
+	if found := tree.FindByRole("dialog"); found != nil {
+		t.Errorf("Expected no dialog, got %+v", found)
+	}
+}
+
+// deeplyNestedHTML returns a page with text at the bottom of depth nested divs.
+func deeplyNestedHTML(depth int) string {
+	return "<html><body>" + strings.Repeat("<div>", depth) + "<p>Deep text</p>" +
+		strings.Repeat("</div>", depth) + "</body></html>"
+}
+
+func TestBuildAriaTreeDeepNesting(t *testing.T) {
+	doc, err := ParseHTML(deeplyNestedHTML(5000), "")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	tree := BuildAriaTree(doc)
+	if tree == nil || tree.Root == nil {
+		t.Fatal("Expected an ARIA tree")
+	}
+	if count := CountAriaNodes(BuildAriaNode(doc.Body)); count > 1000 {
+		t.Errorf("Expected the tree to stop at 1000 levels, got %d nodes", count)
+	}
+
+	options := DefaultOptions()
+	options.MaxDepth = 10
+	document, err := Parse(deeplyNestedHTML(50), options)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if tree := document.AriaTree(); tree == nil || tree.NodeCount > 10 {
+		t.Errorf("Expected the tree to stop at MaxDepth, got %v", tree)
+	}
+}
+
 // Helper function to check if a string contains a substring
 func containsSubstring(s, substr string) bool {
 	return strings.Contains(s, substr)
 }
+
+func TestAriaMaxGroupSize(t *testing.T) {
+	html := "<html><body><main><h1>Items</h1><ul>" + strings.Repeat("<li>Item</li>", 50) + "</ul></main></body></html>"
+
+	document, err := Parse(html, DefaultOptions())
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if tree := AriaTreeToString(document.AriaTree()); strings.Contains(tree, "more listitem") {
+		t.Errorf("Expected no count summary without a limit, got:\n%s", tree)
+	}
+
+	options := DefaultOptions()
+	options.AriaMaxGroupSize = 3
+	document, err = Parse(html, options)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if tree := AriaTreeToString(document.AriaTree()); !strings.Contains(tree, "text: 47 more listitem nodes") {
+		t.Errorf("Expected a count summary of the items beyond the limit, got:\n%s", tree)
+	}
+}
+
+func TestGroupAriaNodes(t *testing.T) {
+	nodes := []*AriaNode{
+		{Type: AriaNodeTypeImg, Name: "a"},
+		{Type: AriaNodeTypeImg, Name: "b"},
+		{Type: AriaNodeTypeImg, Name: "c"},
+	}
+	if grouped := groupAriaNodes(nodes, 0); len(grouped) != 3 {
+		t.Errorf("Expected all nodes without a limit, got %d", len(grouped))
+	}
+	if grouped := groupAriaNodes(nodes, 3); len(grouped) != 3 {
+		t.Errorf("Expected all nodes within the limit, got %d", len(grouped))
+	}
+	grouped := groupAriaNodes(nodes, 1)
+	if len(grouped) != 2 || grouped[0] != nodes[0] || grouped[1].Name != "2 more img nodes" {
+		t.Errorf("Expected the first node and a count summary, got %+v", grouped)
+	}
+}
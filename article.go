@@ -12,10 +12,18 @@ import (
 type ReadabilityArticle struct {
 	Title     string        // Extracted title
 	Byline    string        // Extracted byline/author information
+	Excerpt   string        // Description from JSON-LD or the description meta tags
+	SiteName  string        // Site name from JSON-LD or og:site_name
 	Root      *dom.VElement // Main content root element (if score threshold is met)
 	NodeCount int           // Total number of nodes
 	PageType  PageType      // Classification of page type
 
+	// Heuristic that decided PageType: ClassificationReasonContentExtracted when Root was
+	// found, ClassificationReasonForced for ReadabilityOptions.ForcedPageType (which Extract
+	// sets to ARTICLE by default) when it was not, or a ClassificationReason constant
+	// returned by ClassifyPageTypeWithReason
+	ClassificationReason string
+
 	// Structural elements (set when PageType is ARTICLE but Root is nil)
 	Header                *dom.VElement   // Page header element, if identified
 	Footer                *dom.VElement   // Page footer element, if identified
@@ -23,6 +31,59 @@ type ReadabilityArticle struct {
 
 	// Fallback when article extraction fails
 	AriaTree *AriaTree // ARIA tree representation
+
+	Outline   []OutlineItem // Headings found in Root, in document order
+	Truncated bool          // Whether Root was cut to fit ReadabilityOptions.MaxOutputChars or MaxParagraphs
+	Paywalled bool          // Whether the page looks like a teaser behind a paywall (Root is left as extracted)
+	Script    Script        // Dominant script of the top candidate's text
+	Lang      string        // Language declared by <html lang>, or ReadabilityOptions.Lang
+	Dir       string        // Text direction of Root ("ltr" or "rtl") from the dir attributes, empty if not declared
+	Keywords  []string      // Keywords/tags from meta keywords, article:tag, and rel="tag" links
+
+	StructuredData []map[string]interface{} // All JSON-LD objects found in the document
+	Images         []ImageInfo              // Images in Root, in document order, each URL listed once
+	FaviconURL     string                   // URL of the site's icon, falling back to /favicon.ico
+	RawHTML        string                   // Original markup of Root, set with ReadabilityOptions.IncludeRawHTML
+	PublishedTime  string                   // Publication date from metadata, or resolved from a relative date near the byline
+	Section        string                   // Section or category, from article:section, JSON-LD, or breadcrumbs
+	Breadcrumbs    []string                 // Category path from a JSON-LD BreadcrumbList, from the top level down
+	PrevURL        string                   // Previous article of the series or blog, from rel="prev" or post navigation links
+	NextURL        string                   // Next article of the series or blog, from rel="next" or post navigation links
+	MediaTracks    []TrackInfo              // Caption and other text tracks of the <video> and <audio> elements in Root
+	Score          float64                  // Content quality from 0 to 1, from the candidate score, text length, link density, and paragraph count
+	Candidates     []CandidateSummary       // Top candidates by score, set with ReadabilityOptions.Debug
+}
+
+// CandidateSummary describes a content candidate considered by the extraction, for
+// debugging why an element was or was not selected.
+type CandidateSummary struct {
+	TagName string  `json:"tagName"` // Tag name of the candidate element
+	ID      string  `json:"id"`      // ID of the element, empty if missing
+	Class   string  `json:"class"`   // Class attribute of the element, empty if missing
+	Score   float64 `json:"score"`   // Content score after the link and text density adjustments
+}
+
+// OutlineItem represents a single heading in the outline of the extracted content.
+type OutlineItem struct {
+	Level int    `json:"level"` // Heading level (1 for h1, 2 for h2, ...)
+	Text  string `json:"text"`  // Normalized heading text
+}
+
+// ImageInfo describes an image found in the extracted content.
+type ImageInfo struct {
+	Src    string `json:"src"`    // Image URL, resolved against the base URL
+	Alt    string `json:"alt"`    // Alternative text, empty if missing
+	Title  string `json:"title"`  // Title attribute, empty if missing
+	Width  int    `json:"width"`  // Width attribute in pixels, 0 if missing or not a number
+	Height int    `json:"height"` // Height attribute in pixels, 0 if missing or not a number
+}
+
+// TrackInfo describes a text track (<track>) of a video or audio element in the extracted content.
+type TrackInfo struct {
+	Kind    string `json:"kind"`    // Track kind: captions, subtitles, descriptions, chapters, or metadata
+	Src     string `json:"src"`     // Track URL, resolved against the base URL
+	SrcLang string `json:"srclang"` // Language of the track, empty if missing
+	Label   string `json:"label"`   // User-readable title of the track, empty if missing
 }
 
 // ArticleContent represents the content of an article page.
@@ -0,0 +1,166 @@
+// Package readability provides functionality to extract readable content from HTML documents.
+// It implements an algorithm similar to Mozilla's Readability.js to identify and extract
+// the main content from web pages, removing clutter, navigation, ads, and other non-content elements.
+package readability
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/mackee/go-readability/internal/dom"
+)
+
+// Text directions of the dir attribute
+const (
+	dirLTR = "ltr"
+	dirRTL = "rtl"
+)
+
+// bidiURLRegex matches URLs written in ASCII, such as https://example.com/path or
+// www.example.com, in right-to-left text.
+var bidiURLRegex = regexp.MustCompile(`(?i)(?:https?://|www\.)[\x21-\x7e]+`)
+
+// urlTrailingPunctuation is the punctuation that closes a sentence rather than belonging
+// to a URL.
+const urlTrailingPunctuation = ".,;:!?)]}'\""
+
+// ltrIsolatedTags are the elements whose content is left-to-right code or sample text even
+// in right-to-left documents.
+var ltrIsolatedTags = map[string]bool{"code": true, "pre": true, "kbd": true, "samp": true}
+
+// GetTextDirection returns the text direction of an element: the dir attribute of the element
+// or its closest ancestor that has one, falling back to the dir of <body> and <html>.
+// dir="auto" is skipped, as the direction then depends on the text.
+//
+// Parameters:
+//   - doc: The parsed HTML document
+//   - element: The element to check, or nil for the direction of the document
+//
+// Returns:
+//   - "ltr", "rtl", or an empty string if no direction is declared
+func GetTextDirection(doc *dom.VDocument, element *dom.VElement) string {
+	for ancestor := element; ancestor != nil; ancestor = ancestor.Parent() {
+		if dir := declaredDirection(ancestor); dir != "" {
+			return dir
+		}
+	}
+	if doc == nil {
+		return ""
+	}
+	for _, ancestor := range []*dom.VElement{doc.Body, doc.DocumentElement} {
+		if ancestor == nil {
+			continue
+		}
+		if dir := declaredDirection(ancestor); dir != "" {
+			return dir
+		}
+	}
+	return ""
+}
+
+// declaredDirection returns the dir attribute of an element if it is "ltr" or "rtl", and an
+// empty string otherwise.
+func declaredDirection(element *dom.VElement) string {
+	switch dir := strings.ToLower(strings.TrimSpace(element.GetAttribute("dir"))); dir {
+	case dirLTR, dirRTL:
+		return dir
+	}
+	return ""
+}
+
+// isolateLTRRuns keeps right-to-left content readable once it leaves the page. It sets
+// dir="rtl" on root, so the direction inherited from <html> or <body> is not lost, and
+// isolates the left-to-right runs embedded in the text: code elements and links whose text
+// is a URL get dir="ltr", and bare URLs in text are wrapped in <bdi dir="ltr">. Without
+// the isolation, the punctuation of a URL is reordered by the surrounding text.
+//
+// Parameters:
+//   - root: The extracted content of a right-to-left document, modified in place
+//
+// Returns:
+//   - The number of runs isolated
+func isolateLTRRuns(root *dom.VElement) int {
+	if !root.HasAttribute("dir") {
+		root.SetAttribute("dir", dirRTL)
+	}
+
+	isolated := 0
+	for _, element := range GetElementsByTagName(root, "*") {
+		if element.HasAttribute("dir") {
+			continue
+		}
+		if ltrIsolatedTags[element.TagName] ||
+			(element.TagName == "a" && isURLText(GetInnerText(element, true))) {
+			element.SetAttribute("dir", dirLTR)
+			isolated++
+		}
+	}
+	return isolated + isolateBareURLs(root)
+}
+
+// isURLText checks whether a text consists of a single URL.
+func isURLText(text string) bool {
+	match := bidiURLRegex.FindStringIndex(text)
+	return match != nil && match[0] == 0 && match[1] == len(text)
+}
+
+// isolateBareURLs wraps the URLs in the text nodes of an element in <bdi dir="ltr">.
+// Elements with a dir attribute, and <bdi> and <bdo>, already isolate their content and
+// are skipped.
+//
+// Parameters:
+//   - element: The element to process, in place
+//
+// Returns:
+//   - The number of URLs wrapped
+func isolateBareURLs(element *dom.VElement) int {
+	wrapped := 0
+	children := make([]dom.VNode, 0, len(element.Children))
+	for _, child := range element.Children {
+		if childElement, ok := dom.AsVElement(child); ok {
+			if !childElement.HasAttribute("dir") && childElement.TagName != "bdi" && childElement.TagName != "bdo" {
+				wrapped += isolateBareURLs(childElement)
+			}
+			children = append(children, child)
+			continue
+		}
+		text, ok := dom.AsVText(child)
+		if !ok {
+			children = append(children, child)
+			continue
+		}
+
+		content := text.TextContent
+		matches := bidiURLRegex.FindAllStringIndex(content, -1)
+		if len(matches) == 0 {
+			children = append(children, child)
+			continue
+		}
+		last := 0
+		for _, match := range matches {
+			end := match[0] + len(strings.TrimRight(content[match[0]:match[1]], urlTrailingPunctuation))
+			if end <= match[0] {
+				continue
+			}
+			if match[0] > last {
+				children = append(children, dom.NewVText(content[last:match[0]]))
+			}
+			bdi := dom.NewVElement("bdi")
+			bdi.SetAttribute("dir", dirLTR)
+			bdi.AppendChild(dom.NewVText(content[match[0]:end]))
+			children = append(children, bdi)
+			last = end
+			wrapped++
+		}
+		if last < len(content) {
+			children = append(children, dom.NewVText(content[last:]))
+		}
+		text.SetParent(nil)
+	}
+
+	element.Children = children
+	for _, child := range children {
+		child.SetParent(element)
+	}
+	return wrapped
+}
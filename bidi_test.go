@@ -0,0 +1,60 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractIsolatesLTRRunsInRTLContent(t *testing.T) {
+	html := `<html lang="ar" dir="rtl"><head><title>اختبار</title></head><body><article>` +
+		strings.Repeat(`<p>تستخرج هذه المكتبة المحتوى الرئيسي من صفحات الويب وتزيل القوائم والإعلانات منها.</p>`, 5) +
+		`<p>اقرأ المزيد على https://example.com/docs. واستخدم الأمر <code>go test</code> للتحقق.</p>` +
+		`<p>الرابط: <a href="https://example.org/">https://example.org/</a></p>` +
+		`</article></body></html>`
+
+	options := DefaultOptions()
+	options.CharThreshold = 100
+	article, err := Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if article.Root == nil {
+		t.Fatal("Expected content to be extracted")
+	}
+	if article.Dir != "rtl" {
+		t.Errorf("Expected Dir %q, got %q", "rtl", article.Dir)
+	}
+	if dir := article.Root.GetAttribute("dir"); dir != "rtl" {
+		t.Errorf("Expected the root to keep dir=\"rtl\", got %q", dir)
+	}
+
+	output := ToHTML(article.Root)
+	for _, expected := range []string{
+		`<bdi dir="ltr">https://example.com/docs</bdi>. واستخدم`,
+		`<code dir="ltr">go test</code>`,
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected HTML to contain %q, got %q", expected, output)
+		}
+	}
+
+	markdown := ToMarkdown(article.Root)
+	for _, expected := range []string{
+		"\u2066https://example.com/docs\u2069.",
+		"\u2066`go test`\u2069",
+		"\u2066[https://example.org/](https://example.org/)\u2069",
+	} {
+		if !strings.Contains(markdown, expected) {
+			t.Errorf("Expected Markdown to contain %q, got %q", expected, markdown)
+		}
+	}
+
+	// Left-to-right documents are left as is
+	ltr, err := Extract(strings.ReplaceAll(html, `dir="rtl"`, ""), options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if ltr.Dir != "" || strings.Contains(ToHTML(ltr.Root), "bdi") {
+		t.Errorf("Expected no isolation without a direction, got dir %q and %q", ltr.Dir, ToHTML(ltr.Root))
+	}
+}
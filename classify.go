@@ -11,6 +11,42 @@ import (
 	"github.com/mackee/go-readability/internal/util"
 )
 
+// Reasons reported in ReadabilityArticle.ClassificationReason. Extract reports
+// ClassificationReasonContentExtracted or ClassificationReasonForced, or one of the
+// reasons returned by ClassifyPageTypeWithReason.
+const (
+	// ClassificationReasonContentExtracted means the content was found, so the page is an article
+	ClassificationReasonContentExtracted = "content extracted"
+	// ClassificationReasonForced means the page type was set by ReadabilityOptions.ForcedPageType
+	ClassificationReasonForced = "forced"
+	// ClassificationReasonArticleURL means the URL contains /articles/ or ends with an article ID
+	ClassificationReasonArticleURL = "article URL"
+	// ClassificationReasonTopLevelURL means the URL is the top page of a site or a user page
+	ClassificationReasonTopLevelURL = "top-level or user page URL"
+	// ClassificationReasonNoCandidates means no content candidate was found
+	ClassificationReasonNoCandidates = "no content candidates"
+	// ClassificationReasonManyListElements means the page is a list of cards or entries
+	ClassificationReasonManyListElements = "many list elements"
+	// ClassificationReasonManyLinksAndImages means the page is mostly linked images
+	ClassificationReasonManyLinksAndImages = "many links and images"
+	// ClassificationReasonManyHeadings means the page has too many headings for an article
+	ClassificationReasonManyHeadings = "many headings"
+	// ClassificationReasonNoHeadings means the page has no heading
+	ClassificationReasonNoHeadings = "no headings"
+	// ClassificationReasonSemanticTag means an <article> or <main> holds enough text
+	ClassificationReasonSemanticTag = "semantic tag with enough text"
+	// ClassificationReasonEnoughText means the best candidate has enough text and few links
+	ClassificationReasonEnoughText = "enough text with low link density"
+	// ClassificationReasonLongText means a top-level or user page has a long text with few links
+	ClassificationReasonLongText = "long text with low link density"
+	// ClassificationReasonHighLinkDensity means the candidates are mostly links
+	ClassificationReasonHighLinkDensity = "high link density"
+	// ClassificationReasonManyLinks means the page has many links and little text
+	ClassificationReasonManyLinks = "many links with little text"
+	// ClassificationReasonShortText means the page has too little text for an article
+	ClassificationReasonShortText = "short text"
+)
+
 // ClassifyPageType classifies a document as an article or other type of page.
 // It uses various heuristics including URL pattern, semantic tags, text length,
 // link density, and more to determine the page type. This classification helps
@@ -30,6 +66,30 @@ func ClassifyPageType(
 	charThreshold int,
 	url string,
 ) PageType {
+	pageType, _ := ClassifyPageTypeWithReason(doc, candidates, charThreshold, url)
+	return pageType
+}
+
+// ClassifyPageTypeWithReason classifies a document like ClassifyPageType, and also returns
+// the heuristic that decided the page type, such as "many list elements", "high link
+// density", or "short text". The reason tells a confident decision (an index page with
+// many list elements) from a borderline one (a page whose text is just short).
+//
+// Parameters:
+//   - doc: The parsed HTML document
+//   - candidates: The list of content candidates found by the scoring algorithm
+//   - charThreshold: The minimum character threshold for article content
+//   - url: The URL of the page (optional, used for URL pattern analysis)
+//
+// Returns:
+//   - PageType: Either PageTypeArticle or PageTypeOther
+//   - The reason for the classification
+func ClassifyPageTypeWithReason(
+	doc *dom.VDocument,
+	candidates []*dom.VElement,
+	charThreshold int,
+	url string,
+) (PageType, string) {
 	// If charThreshold is not provided, use the default
 	if charThreshold <= 0 {
 		charThreshold = util.DefaultCharThreshold
@@ -41,9 +101,9 @@ func ClassifyPageType(
 		if strings.Contains(url, "/articles/") {
 			// 候補がある場合のみ ARTICLE として扱う
 			if len(candidates) > 0 {
-				return PageTypeArticle
+				return PageTypeArticle, ClassificationReasonArticleURL
 			}
-			return PageTypeOther
+			return PageTypeOther, ClassificationReasonNoCandidates
 		}
 
 		// 追加: 末尾に英単語ではなさそうなハッシュ・連番・UUIDのような文字列を含む場合
@@ -64,9 +124,9 @@ func ClassifyPageType(
 				len(lastPartWithoutExt) >= 5) { // 5文字以上
 			// 候補がある場合のみ ARTICLE として扱う
 			if len(candidates) > 0 {
-				return PageTypeArticle
+				return PageTypeArticle, ClassificationReasonArticleURL
 			}
-			return PageTypeOther
+			return PageTypeOther, ClassificationReasonNoCandidates
 		}
 
 		// トップレベルドメインやユーザーページは OTHER の可能性が高い
@@ -79,16 +139,16 @@ func ClassifyPageType(
 				textLength := GetInnerText(candidates[0], false)
 				// 非常に長いテキストがあり、リンク密度が低い場合のみ ARTICLE
 				if len(textLength) > charThreshold*2 && GetLinkDensity(candidates[0]) < 0.3 {
-					return PageTypeArticle
+					return PageTypeArticle, ClassificationReasonLongText
 				}
 			}
-			return PageTypeOther
+			return PageTypeOther, ClassificationReasonTopLevelURL
 		}
 	}
 
-	// 候補がない場合は OTHER
-	if len(candidates) == 0 {
-		return PageTypeOther
+	// 候補がない場合や body がない場合は OTHER
+	if len(candidates) == 0 || !hasBody(doc) {
+		return PageTypeOther, ClassificationReasonNoCandidates
 	}
 
 	topCandidate := candidates[0]
@@ -132,15 +192,16 @@ func ClassifyPageType(
 	// - 多数のリンク
 	// - 多数の画像
 	// - 見出しが少ない、または多すぎる
-	hasIndexPageCharacteristics :=
-		listElementCount > 10 || // 多数のリスト要素
-			(linkCount > 50 && imageCount > 20) || // 多数のリンクと画像
-			headingCount > 10 ||
-			headingCount == 0 // 見出しが多すぎるか、まったくない
-
-	if hasIndexPageCharacteristics {
-		// トップページの特徴が強い場合は OTHER
-		return PageTypeOther
+	// トップページの特徴が強い場合は OTHER
+	switch {
+	case listElementCount > 10: // 多数のリスト要素
+		return PageTypeOther, ClassificationReasonManyListElements
+	case linkCount > 50 && imageCount > 20: // 多数のリンクと画像
+		return PageTypeOther, ClassificationReasonManyLinksAndImages
+	case headingCount > 10: // 見出しが多すぎる
+		return PageTypeOther, ClassificationReasonManyHeadings
+	case headingCount == 0: // 見出しがまったくない
+		return PageTypeOther, ClassificationReasonNoHeadings
 	}
 
 	// 3. セマンティックタグの確認 + テキスト長チェック
@@ -154,14 +215,14 @@ func ClassifyPageType(
 		if len(textLength) >= charThreshold/2 && linkDensity <= 0.5 {
 			// 記事リスト要素が多い場合は OTHER
 			if listElementCount > 10 {
-				return PageTypeOther
+				return PageTypeOther, ClassificationReasonManyListElements
 			}
-			return PageTypeArticle
+			return PageTypeArticle, ClassificationReasonSemanticTag
 		}
 
 		// テキスト長が非常に短い場合は OTHER
 		if len(textLength) < 100 {
-			return PageTypeOther
+			return PageTypeOther, ClassificationReasonShortText
 		}
 	}
 
@@ -174,7 +235,7 @@ func ClassifyPageType(
 		linkDensity <= 0.5 &&
 		headingCount >= 1 &&
 		headingCount <= 10 {
-		return PageTypeArticle
+		return PageTypeArticle, ClassificationReasonEnoughText
 	}
 
 	// 5. 候補のスコア差を確認（平衡性）
@@ -204,7 +265,7 @@ func ClassifyPageType(
 
 			// リンク密度が高い場合は OTHER（リスト/インデックスページの可能性）
 			if bodyLinkDensity > 0.25 || linkDensity > 0.3 {
-				return PageTypeOther
+				return PageTypeOther, ClassificationReasonHighLinkDensity
 			}
 		}
 	}
@@ -214,7 +275,7 @@ func ClassifyPageType(
 
 	// リンクが多く、本文が少ない場合は OTHER
 	if linkCount > 30 && bodyTextLength < int(float64(charThreshold)*1.5) {
-		return PageTypeOther
+		return PageTypeOther, ClassificationReasonManyLinks
 	}
 
 	// 7. 最終判定
@@ -222,13 +283,13 @@ func ClassifyPageType(
 	if len(textLength) >= 140 && linkDensity <= 0.5 {
 		// 記事リスト要素が多い場合は OTHER
 		if listElementCount > 10 {
-			return PageTypeOther
+			return PageTypeOther, ClassificationReasonManyListElements
 		}
-		return PageTypeArticle
+		return PageTypeArticle, ClassificationReasonEnoughText
 	}
 
 	// それ以外の場合は OTHER
-	return PageTypeOther
+	return PageTypeOther, ClassificationReasonShortText
 }
 
 // IsSignificantNode determines if a node is semantically significant.
@@ -242,6 +303,18 @@ func ClassifyPageType(
 // Returns:
 //   - true if the node is semantically significant, false otherwise
 func IsSignificantNode(node *dom.VElement) bool {
+	return isSignificantNode(node, nil)
+}
+
+// isSignificantNode implements IsSignificantNode, resolving aria-labelledby through index.
+//
+// Parameters:
+//   - node: The element to check
+//   - index: The ID index shared by the elements of a document, or nil to build one
+//
+// Returns:
+//   - true if the node is semantically significant, false otherwise
+func isSignificantNode(node *dom.VElement, index *elementIDIndex) bool {
 	// Check tag name
 	tagName := strings.ToLower(node.TagName)
 	if tagName == "header" || tagName == "footer" || tagName == "main" ||
@@ -249,14 +322,15 @@ func IsSignificantNode(node *dom.VElement) bool {
 		return true
 	}
 
-	// Check role attribute
+	// Check role attribute. A region is a landmark only when it has a label
 	role := strings.ToLower(GetAttribute(node, "role"))
+	label := strings.ToLower(getAriaLabel(node, index))
 	if role == "banner" || role == "contentinfo" || role == "main" ||
-		role == "navigation" || role == "complementary" {
+		role == "navigation" || role == "complementary" || (role == "region" && label != "") {
 		return true
 	}
 
-	// Check class and ID
+	// Check class, ID, and the ARIA label
 	className := strings.ToLower(node.ClassName())
 	id := strings.ToLower(node.ID())
 
@@ -267,7 +341,7 @@ func IsSignificantNode(node *dom.VElement) bool {
 	}
 
 	for _, pattern := range significantPatterns {
-		if strings.Contains(className, pattern) || strings.Contains(id, pattern) {
+		if strings.Contains(className, pattern) || strings.Contains(id, pattern) || strings.Contains(label, pattern) {
 			return true
 		}
 	}
@@ -388,3 +388,131 @@ func TestClassifyPageType(t *testing.T) {
 		})
 	}
 }
+
+func TestClassifyPageTypeWithReason(t *testing.T) {
+	longText := "<p>" + strings.Repeat("これは記事の本文です。", 100) + "</p>"
+	tests := []struct {
+		name          string
+		html          string
+		url           string
+		noCandidates  bool
+		expected      PageType
+		expectedCause string
+	}{
+		{
+			name:          "記事URL",
+			html:          `<html><body><div><h1>記事</h1>` + longText + `</div></body></html>`,
+			url:           "https://example.com/articles/about",
+			expected:      PageTypeArticle,
+			expectedCause: ClassificationReasonArticleURL,
+		},
+		{
+			name:          "記事IDで終わるURL",
+			html:          `<html><body><div><h1>記事</h1><p>短い本文です。</p></div></body></html>`,
+			url:           "https://example.com/blog/entry-20240115.html",
+			expected:      PageTypeArticle,
+			expectedCause: ClassificationReasonArticleURL,
+		},
+		{
+			name:          "候補なし",
+			html:          `<html><body></body></html>`,
+			noCandidates:  true,
+			expected:      PageTypeOther,
+			expectedCause: ClassificationReasonNoCandidates,
+		},
+		{
+			name:          "トップページのURL",
+			html:          `<html><body><div><h1>トップ</h1><p>ようこそ。</p></div></body></html>`,
+			url:           "https://example.com/",
+			expected:      PageTypeOther,
+			expectedCause: ClassificationReasonTopLevelURL,
+		},
+		{
+			name:          "ユーザーページのURL",
+			html:          `<html><body><div><h1>ユーザー</h1><p>プロフィールです。</p></div></body></html>`,
+			url:           "https://example.com/jane/",
+			expected:      PageTypeOther,
+			expectedCause: ClassificationReasonTopLevelURL,
+		},
+		{
+			name:          "トップページのURLでも長い本文",
+			html:          `<html><body><div><h1>トップ</h1>` + longText + `</div></body></html>`,
+			url:           "https://example.com/",
+			expected:      PageTypeArticle,
+			expectedCause: ClassificationReasonLongText,
+		},
+		{
+			name:          "リスト要素が多い",
+			html:          `<html><body><div><h1>記事一覧</h1>` + strings.Repeat(`<article class="card"><h2>記事タイトル</h2><p>概要</p></article>`, 15) + `</div></body></html>`,
+			expected:      PageTypeOther,
+			expectedCause: ClassificationReasonManyListElements,
+		},
+		{
+			name:          "リンクと画像が多い",
+			html:          `<html><body><div><h1>ギャラリー</h1>` + longText + strings.Repeat(`<a href="/p"><img src="p.png"></a>`, 60) + `</div></body></html>`,
+			expected:      PageTypeOther,
+			expectedCause: ClassificationReasonManyLinksAndImages,
+		},
+		{
+			name:          "見出しが多すぎる",
+			html:          `<html><body><div>` + strings.Repeat(`<h2>見出し</h2><p>本文です。</p>`, 11) + `</div></body></html>`,
+			expected:      PageTypeOther,
+			expectedCause: ClassificationReasonManyHeadings,
+		},
+		{
+			name:          "見出しがない",
+			html:          `<html><body><div>` + longText + `</div></body></html>`,
+			expected:      PageTypeOther,
+			expectedCause: ClassificationReasonNoHeadings,
+		},
+		{
+			name:          "セマンティックタグと十分なテキスト",
+			html:          `<html><body><article><h1>記事</h1>` + longText + `</article></body></html>`,
+			expected:      PageTypeArticle,
+			expectedCause: ClassificationReasonSemanticTag,
+		},
+		{
+			name:          "十分なテキストと低いリンク密度",
+			html:          `<html><body><div><h1>記事</h1>` + longText + `</div></body></html>`,
+			expected:      PageTypeArticle,
+			expectedCause: ClassificationReasonEnoughText,
+		},
+		{
+			name:          "候補が拮抗しリンク密度が高い",
+			html:          `<html><body><h1>ニュース</h1>` + strings.Repeat(`<div><p>Summary of the news of today, in brief. <a href="/a">Read the full story about the news</a></p></div>`, 2) + `</body></html>`,
+			expected:      PageTypeOther,
+			expectedCause: ClassificationReasonHighLinkDensity,
+		},
+		{
+			name:          "リンクが多く本文が少ない",
+			html:          `<html><body><div><h1>リンク集</h1><p>いくつかのリンク</p>` + strings.Repeat(`<a href="#">リンク</a>`, 40) + `</div></body></html>`,
+			expected:      PageTypeOther,
+			expectedCause: ClassificationReasonManyLinks,
+		},
+		{
+			name:          "テキストが少ない",
+			html:          `<html><body><div><h1>短いページ</h1><p>これは短いテキストです。</p></div></body></html>`,
+			expected:      PageTypeOther,
+			expectedCause: ClassificationReasonShortText,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.ParseHTML(tt.html, "https://example.com")
+			if err != nil {
+				t.Fatalf("HTML解析エラー: %v", err)
+			}
+
+			var candidates []*dom.VElement
+			if !tt.noCandidates {
+				candidates = FindMainCandidates(doc, 5)
+			}
+
+			pageType, reason := ClassifyPageTypeWithReason(doc, candidates, 500, tt.url)
+			if pageType != tt.expected || reason != tt.expectedCause {
+				t.Errorf("ClassifyPageTypeWithReason() = %v, %q, want %v, %q", pageType, reason, tt.expected, tt.expectedCause)
+			}
+		})
+	}
+}
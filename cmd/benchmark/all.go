@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mackee/go-readability"
+)
+
+// fixtureResult は、1つのフィクスチャの計測結果です。
+type fixtureResult struct {
+	name       string
+	average    time.Duration // 1回あたりの平均処理時間
+	allocBytes uint64        // 1回あたりの割り当てバイト数
+	allocs     uint64        // 1回あたりの割り当て回数
+	err        error         // 読み込みまたは抽出のエラー
+	nodeCount  int
+	pageType   readability.PageType
+}
+
+// benchmarkFixture は、1つのフィクスチャに対して抽出を繰り返し実行して計測します。
+func benchmarkFixture(dir string, iterations int) fixtureResult {
+	result := fixtureResult{name: filepath.Base(dir)}
+
+	htmlBytes, err := os.ReadFile(filepath.Join(dir, "source.html"))
+	if err != nil {
+		result.err = fmt.Errorf("source.htmlの読み込みに失敗しました: %w", err)
+		return result
+	}
+	html := string(htmlBytes)
+	options := readability.ReadabilityOptions{}
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	startTime := time.Now()
+
+	var article readability.ReadabilityArticle
+	for i := 0; i < iterations; i++ {
+		article, err = readability.Extract(html, options)
+		if err != nil {
+			result.err = fmt.Errorf("抽出に失敗しました: %w", err)
+			return result
+		}
+	}
+
+	elapsedTime := time.Since(startTime)
+	runtime.ReadMemStats(&after)
+
+	result.average = elapsedTime / time.Duration(iterations)
+	result.allocBytes = (after.TotalAlloc - before.TotalAlloc) / uint64(iterations)
+	result.allocs = (after.Mallocs - before.Mallocs) / uint64(iterations)
+	result.nodeCount = article.NodeCount
+	result.pageType = article.PageType
+	return result
+}
+
+// runAll は、fixturesDir 以下のすべてのフィクスチャを計測し、処理時間の降順で表を出力します。
+// source.html がないフィクスチャはエラーとして表に記録し、計測を続けます。
+func runAll(fixturesDir string, iterations int, w io.Writer) error {
+	if iterations <= 0 {
+		return fmt.Errorf("繰り返し回数は1以上を指定してください: %d", iterations)
+	}
+
+	entries, err := os.ReadDir(fixturesDir)
+	if err != nil {
+		return fmt.Errorf("フィクスチャディレクトリの読み込みに失敗しました: %w", err)
+	}
+
+	var results []fixtureResult
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		results = append(results, benchmarkFixture(filepath.Join(fixturesDir, entry.Name()), iterations))
+	}
+	if len(results) == 0 {
+		return fmt.Errorf("フィクスチャが見つかりません: %s", fixturesDir)
+	}
+	return writeReport(results, iterations, w)
+}
+
+// writeReport は、計測結果を処理時間の降順で表にし、集計とともに出力します。
+// 処理時間が同じ場合はフィクスチャ名の順に並べ、失敗したものは末尾に置きます。
+func writeReport(results []fixtureResult, iterations int, w io.Writer) error {
+	sort.SliceStable(results, func(i, j int) bool {
+		if (results[i].err == nil) != (results[j].err == nil) {
+			return results[i].err == nil
+		}
+		if results[i].average != results[j].average {
+			return results[i].average > results[j].average
+		}
+		return results[i].name < results[j].name
+	})
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "フィクスチャ\t平均処理時間\t割り当てバイト数\t割り当て回数\tノード数\tページタイプ")
+	var durations []time.Duration
+	var totalAllocBytes, totalAllocs uint64
+	failed := 0
+	for _, result := range results {
+		if result.err != nil {
+			fmt.Fprintf(tw, "%s\tエラー: %v\t\t\t\t\n", result.name, result.err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%v\t%d\t%d\t%d\t%s\n",
+			result.name, result.average, result.allocBytes, result.allocs, result.nodeCount, result.pageType)
+		durations = append(durations, result.average)
+		totalAllocBytes += result.allocBytes
+		totalAllocs += result.allocs
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\nフィクスチャ数: %d (成功: %d, 失敗: %d, %d回の繰り返し)\n",
+		len(results), len(durations), failed, iterations)
+	if len(durations) > 0 {
+		sorted := append([]time.Duration(nil), durations...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		fmt.Fprintf(w, "最小: %v\t中央値: %v\t最大: %v\n", sorted[0], median(sorted), sorted[len(sorted)-1])
+		fmt.Fprintf(w, "合計割り当てバイト数: %d\t合計割り当て回数: %d (1回あたり)\n", totalAllocBytes, totalAllocs)
+	}
+	return nil
+}
+
+// median は、昇順に並んだ処理時間の中央値を返します。
+func median(sorted []time.Duration) time.Duration {
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunAll(t *testing.T) {
+	dir := t.TempDir()
+	article := `<html><head><title>Test</title></head><body><article>` +
+		strings.Repeat("<p>This paragraph has enough text, and commas, to be scored as content.</p>", 10) +
+		`</article></body></html>`
+
+	for _, name := range []string{"small", "large"} {
+		if err := os.Mkdir(filepath.Join(dir, name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "small", "source.html"), []byte(article), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "large", "source.html"), []byte(strings.Repeat(article, 20)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// source.html のないフィクスチャ
+	if err := os.Mkdir(filepath.Join(dir, "missing"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := runAll(dir, 2, &out); err != nil {
+		t.Fatalf("runAll failed: %v", err)
+	}
+
+	output := out.String()
+	for _, want := range []string{"small", "large", "missing", "source.html", "成功: 2, 失敗: 1", "中央値"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	results := []fixtureResult{
+		{name: "missing", err: errors.New("source.htmlの読み込みに失敗しました")},
+		{name: "fast", average: 1 * time.Millisecond},
+		{name: "tie-b", average: 5 * time.Millisecond},
+		{name: "slow", average: 9 * time.Millisecond},
+		{name: "tie-a", average: 5 * time.Millisecond},
+	}
+
+	var out bytes.Buffer
+	if err := writeReport(results, 1, &out); err != nil {
+		t.Fatalf("writeReport failed: %v", err)
+	}
+
+	output := out.String()
+	last := -1
+	for _, name := range []string{"slow", "tie-a", "tie-b", "fast", "missing"} {
+		index := strings.Index(output, name)
+		if index <= last {
+			t.Fatalf("Expected %q after the previous fixture, got:\n%s", name, output)
+		}
+		last = index
+	}
+	for _, want := range []string{"成功: 4, 失敗: 1", "最小: 1ms", "中央値: 5ms", "最大: 9ms"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestRunAllWithoutFixtures(t *testing.T) {
+	if err := runAll(t.TempDir(), 1, &bytes.Buffer{}); err == nil {
+		t.Error("Expected an error for a directory without fixtures")
+	}
+	if err := runAll(filepath.Join(t.TempDir(), "nonexistent"), 1, &bytes.Buffer{}); err == nil {
+		t.Error("Expected an error for a nonexistent directory")
+	}
+}
+
+func TestMedian(t *testing.T) {
+	if got := median([]time.Duration{1, 2, 9}); got != 2 {
+		t.Errorf("median of odd count = %v, want 2", got)
+	}
+	if got := median([]time.Duration{1, 3, 5, 9}); got != 4 {
+		t.Errorf("median of even count = %v, want 4", got)
+	}
+}
@@ -20,6 +20,8 @@ func main() {
 		iterations  = flag.Int("iterations", 100, "繰り返し回数")
 		htmlFile    = flag.String("html", "", "HTMLファイルのパス（指定しない場合はテストケースを使用）")
 		testCaseDir = flag.String("testcase", "../../testdata/fixtures/001", "テストケースのディレクトリ（htmlが指定されていない場合に使用）")
+		all         = flag.Bool("all", false, "fixturesディレクトリ以下のすべてのテストケースを計測する")
+		fixturesDir = flag.String("fixtures", "../../testdata/fixtures", "テストケースのディレクトリの親ディレクトリ（allが指定された場合に使用）")
 	)
 	flag.Parse()
 
@@ -42,24 +44,54 @@ func main() {
 		defer pprof.StopCPUProfile()
 	}
 
+	if *all {
+		// すべてのフィクスチャを計測
+		if err := runAll(*fixturesDir, *iterations, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+	} else if err := runSingle(*htmlFile, *testCaseDir, *iterations); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	// メモリプロファイリングの設定
+	if *memprofile != "" {
+		f, err := os.Create(*memprofile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "メモリプロファイルの作成に失敗しました: %v\n", err)
+			os.Exit(1)
+		}
+		defer func() {
+			if err := f.Close(); err != nil {
+				fmt.Fprintf(os.Stderr, "メモリプロファイルのクローズに失敗しました: %v\n", err)
+			}
+		}()
+		runtime.GC() // メモリプロファイリング前にGCを実行
+		if err := pprof.WriteHeapProfile(f); err != nil {
+			fmt.Fprintf(os.Stderr, "メモリプロファイルの書き込みに失敗しました: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// runSingle は、1つのHTMLファイルまたはテストケースに対して抽出を繰り返し実行し、結果を表示します。
+func runSingle(htmlFile, testCaseDir string, iterations int) error {
 	// HTMLの読み込み
 	var html string
-	var err error
-	if *htmlFile != "" {
+	if htmlFile != "" {
 		// 指定されたHTMLファイルを読み込む
-		htmlBytes, err := os.ReadFile(*htmlFile)
+		htmlBytes, err := os.ReadFile(htmlFile)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "HTMLファイルの読み込みに失敗しました: %v\n", err)
-			os.Exit(1)
+			return fmt.Errorf("HTMLファイルの読み込みに失敗しました: %w", err)
 		}
 		html = string(htmlBytes)
 	} else {
 		// テストケースのHTMLを読み込む
-		sourcePath := filepath.Join(*testCaseDir, "source.html")
+		sourcePath := filepath.Join(testCaseDir, "source.html")
 		htmlBytes, err := os.ReadFile(sourcePath)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "テストケースのHTMLファイルの読み込みに失敗しました: %v\n", err)
-			os.Exit(1)
+			return fmt.Errorf("テストケースのHTMLファイルの読み込みに失敗しました: %w", err)
 		}
 		html = string(htmlBytes)
 	}
@@ -72,18 +104,18 @@ func main() {
 	var result readability.ReadabilityArticle
 
 	// 指定された回数だけ抽出を実行
-	for i := 0; i < *iterations; i++ {
+	for i := 0; i < iterations; i++ {
+		var err error
 		result, err = readability.Extract(html, options)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "抽出に失敗しました: %v\n", err)
-			os.Exit(1)
+			return fmt.Errorf("抽出に失敗しました: %w", err)
 		}
 	}
 
 	// 処理時間の表示
 	elapsedTime := time.Since(startTime)
-	fmt.Printf("処理時間: %v (%d回の繰り返し)\n", elapsedTime, *iterations)
-	fmt.Printf("1回あたりの平均処理時間: %v\n", elapsedTime/time.Duration(*iterations))
+	fmt.Printf("処理時間: %v (%d回の繰り返し)\n", elapsedTime, iterations)
+	fmt.Printf("1回あたりの平均処理時間: %v\n", elapsedTime/time.Duration(iterations))
 
 	// 結果の表示
 	fmt.Printf("タイトル: %s\n", result.Title)
@@ -99,24 +131,7 @@ func main() {
 	fmt.Printf("\tSys = %v MiB", bToMb(m.Sys))
 	fmt.Printf("\tNumGC = %v\n", m.NumGC)
 
-	// メモリプロファイリングの設定
-	if *memprofile != "" {
-		f, err := os.Create(*memprofile)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "メモリプロファイルの作成に失敗しました: %v\n", err)
-			os.Exit(1)
-		}
-		defer func() {
-			if err := f.Close(); err != nil {
-				fmt.Fprintf(os.Stderr, "メモリプロファイルのクローズに失敗しました: %v\n", err)
-			}
-		}()
-		runtime.GC() // メモリプロファイリング前にGCを実行
-		if err := pprof.WriteHeapProfile(f); err != nil {
-			fmt.Fprintf(os.Stderr, "メモリプロファイルの書き込みに失敗しました: %v\n", err)
-			os.Exit(1)
-		}
-	}
+	return nil
 }
 
 // バイトをメガバイトに変換する関数
@@ -1,104 +1,364 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
+	"mime"
 	"net/http"
+	"net/textproto"
 	"net/url"
 	"os"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/mackee/go-readability"
 )
 
+// Exit codes returned by the command
+const (
+	exitOK         = 0 // Content or metadata was written
+	exitError      = 1 // Unexpected error (e.g. JSON encoding)
+	exitInputError = 2 // Invalid arguments, or the input could not be read or fetched
+	exitParseError = 3 // The input could not be parsed
+	exitNoContent  = 4 // No content was extracted (see --allow-empty)
+)
+
 func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command with the given arguments (without the program name)
+// and returns its exit code. Content is written to stdout, errors to stderr.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
 	// Define command-line flags
-	formatFlag := flag.String("format", "html", "Output format: html or markdown")
-	metadataFlag := flag.Bool("metadata", false, "Output metadata as JSON instead of content")
-	helpFlag := flag.Bool("help", false, "Show help")
-	flag.Parse()
+	flags := flag.NewFlagSet("readability", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() { printUsage(stderr) }
+	formatFlag := flags.String("format", "html", "Output format: html, markdown, sentences, readability-json, or aria-dot")
+	langFlag := flags.String("lang", "", "Language of pages that do not declare one (e.g. ja)")
+	lineEndingFlag := flags.String("line-ending", "lf", "Line ending of the content: lf or crlf")
+	metadataFlag := flags.Bool("metadata", false, "Output metadata as JSON instead of content")
+	allowEmptyFlag := flags.Bool("allow-empty", false, "Exit with 0 even when no content is extracted")
+	fallbackFlag := flags.Bool("fallback", false, "Output the page structure when no content is extracted")
+	warcFlag := flags.String("warc", "", "Extract every HTML response in a WARC file and output JSON lines")
+	diffFlag := flags.Bool("diff", false, "Compare the extractions of two pages (URLs or files) instead of outputting content")
+	explainFlag := flags.Bool("explain-preprocess", false, "List the elements removed by preprocessing instead of content")
+	quietFlag := flags.Bool("quiet", false, "Suppress warnings")
+	verboseFlag := flags.Bool("verbose", false, "Print extraction details (timing, chosen candidate, page type) to stderr")
+	helpFlag := flags.Bool("help", false, "Show help")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitInputError
+	}
 
 	// Show help if requested
 	if *helpFlag {
-		printUsage()
-		os.Exit(0)
+		printUsage(stdout)
+		return exitOK
+	}
+
+	if *quietFlag && *verboseFlag {
+		fmt.Fprintln(stderr, "Error: --quiet and --verbose cannot be used together")
+		return exitInputError
+	}
+	logger := newLogger(stderr, *quietFlag, *verboseFlag)
+
+	format := strings.ToLower(*formatFlag)
+	if format != "html" && format != "markdown" && format != "sentences" && format != "readability-json" && format != "aria-dot" {
+		fmt.Fprintf(stderr, "Error: unknown format: %s\n", *formatFlag)
+		return exitInputError
+	}
+
+	var lineEnding string
+	switch strings.ToLower(*lineEndingFlag) {
+	case "lf":
+		lineEnding = "\n"
+	case "crlf":
+		lineEnding = "\r\n"
+	default:
+		fmt.Fprintf(stderr, "Error: unknown line ending: %s\n", *lineEndingFlag)
+		return exitInputError
 	}
 
+	if *warcFlag != "" {
+		// The JSON lines hold the content as text; a whole-page format does not fit in them
+		if format != "html" && format != "markdown" && format != "sentences" {
+			fmt.Fprintf(stderr, "Error: --warc supports only the html, markdown, and sentences formats, not %s\n", format)
+			return exitInputError
+		}
+		return runWARC(*warcFlag, format, *langFlag, stdout, stderr)
+	}
+
+	if *diffFlag {
+		if flags.NArg() != 2 {
+			fmt.Fprintln(stderr, "Error: --diff requires two URLs or files")
+			return exitInputError
+		}
+		return runDiff(flags.Arg(0), flags.Arg(1), *langFlag, logger, stdout, stderr)
+	}
+
+	baseURL := ""
+	if flags.NArg() > 0 && isRequestURL(flags.Arg(0)) {
+		baseURL = flags.Arg(0)
+	}
 	body, err := func() ([]byte, error) {
-		if flag.NArg() == 0 {
-			return readStdin()
+		if flags.NArg() == 0 {
+			return readStdin(stdin)
 		}
 		// Get the URL or file path from command-line arguments
-		src := flag.Arg(0)
-		if isRequestURL(src) {
-			return fetchContent(src)
-		}
-		return readFile(src)
+		return readSource(flags.Arg(0), logger)
 	}()
 	if err != nil {
-		log.Fatalf("Error: %v", err)
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitInputError
+	}
+
+	if *explainFlag {
+		doc, err := readability.ParseHTML(string(body), baseURL)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return exitParseError
+		}
+		report := readability.PreprocessReport(doc)
+		for _, removed := range report.Removed {
+			fmt.Fprintf(stdout, "%s\t%s\n", removed.Reason, describeElement(removed))
+		}
+		fmt.Fprintf(stdout, "%d elements removed by preprocessing\n", len(report.Removed))
+		return exitOK
+	}
+
+	// The accessibility tree of the whole page, as a Graphviz graph
+	if format == "aria-dot" {
+		doc, err := readability.ParseHTML(string(body), baseURL)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return exitParseError
+		}
+		dot := readability.AriaTreeToDOT(readability.BuildAriaTree(doc))
+		if dot == "" {
+			fmt.Fprintln(stderr, "No content was extracted (empty accessibility tree)")
+			return exitNoContent
+		}
+		fmt.Fprint(stdout, dot)
+		return exitOK
 	}
 
 	// Parse the content
-	article, err := parseContent(body)
+	article, err := parseContent(body, baseURL, *langFlag, logger)
 	if err != nil {
-		log.Fatalf("Error: %v", err)
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitParseError
 	}
 
 	// Output based on flags
 	if *metadataFlag {
 		// Output metadata as JSON
-		metadata := map[string]string{
-			"title":     article.Title,
-			"byline":    article.Byline,
-			"nodeCount": fmt.Sprintf("%d", article.NodeCount),
-			"pageType":  string(article.PageType),
+		metadata := map[string]interface{}{
+			"title":                article.Title,
+			"byline":               article.Byline,
+			"publishedTime":        article.PublishedTime,
+			"nodeCount":            fmt.Sprintf("%d", article.NodeCount),
+			"pageType":             string(article.PageType),
+			"classificationReason": article.ClassificationReason,
+			"score":                article.Score,
+			"outline":              article.Outline,
+			"script":               string(article.Script),
+			"lang":                 article.Lang,
+			"dir":                  article.Dir,
+			"keywords":             article.Keywords,
+			"favicon":              article.FaviconURL,
+			"images":               article.Images,
+			"mediaTracks":          article.MediaTracks,
+			"paywalled":            article.Paywalled,
+			"section":              article.Section,
+			"breadcrumbs":          article.Breadcrumbs,
+			"prevURL":              article.PrevURL,
+			"nextURL":              article.NextURL,
 		}
 		jsonData, err := json.MarshalIndent(metadata, "", "  ")
 		if err != nil {
-			log.Fatalf("Error marshaling JSON: %v", err)
+			fmt.Fprintf(stderr, "Error marshaling JSON: %v\n", err)
+			return exitError
 		}
-		fmt.Println(string(jsonData))
-	} else {
-		// Output content in the specified format
-		switch strings.ToLower(*formatFlag) {
+		fmt.Fprintln(stdout, string(jsonData))
+		return exitOK
+	}
+
+	// Output content in the specified format. With --fallback, a page without content
+	// is output as its header, footer, and other significant nodes
+	nodes := readability.FallbackNodes(*article)
+	if article.Root == nil && !*fallbackFlag {
+		nodes = nil
+	}
+	if len(nodes) == 0 {
+		if *allowEmptyFlag {
+			return exitOK
+		}
+		fmt.Fprintf(stderr, "No content was extracted (page type: %s)\n", article.PageType)
+		return exitNoContent
+	}
+	if format == "readability-json" {
+		jsonData, err := json.MarshalIndent(readabilityJSON(*article), "", "  ")
+		if err != nil {
+			fmt.Fprintf(stderr, "Error marshaling JSON: %v\n", err)
+			return exitError
+		}
+		fmt.Fprintln(stdout, string(jsonData))
+		return exitOK
+	}
+	parts := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		switch format {
 		case "html":
-			if article.Root != nil {
-				fmt.Println(readability.ToHTML(article.Root))
-			} else {
-				log.Fatalf("No content was extracted from the URL")
-			}
+			options := readability.DefaultHTMLOptions()
+			options.LineEnding = lineEnding
+			parts = append(parts, readability.ToHTMLWithOptions(node, options))
 		case "markdown":
-			if article.Root != nil {
-				fmt.Println(readability.ToMarkdown(article.Root))
-			} else {
-				log.Fatalf("No content was extracted from the URL")
-			}
-		default:
-			log.Fatalf("Unknown format: %s", *formatFlag)
+			options := readability.DefaultMarkdownOptions()
+			options.LineEnding = lineEnding
+			parts = append(parts, readability.ToMarkdownWithOptions(node, options))
+		case "sentences":
+			parts = append(parts, strings.Join(readability.ToSentences(node, article.Lang), "\n"))
+		}
+	}
+	separator := "\n"
+	if format == "markdown" {
+		separator = "\n\n"
+	}
+	content := readability.ConvertLineEndings(strings.Join(parts, separator), lineEnding)
+	fmt.Fprint(stdout, content+lineEnding)
+	return exitOK
+}
+
+// readabilityResult is the article object returned by Mozilla's Readability.parse(),
+// output by --format readability-json for consumers of the JavaScript library.
+type readabilityResult struct {
+	Title         string `json:"title"`
+	Byline        string `json:"byline"`
+	Content       string `json:"content"`     // HTML of the content
+	TextContent   string `json:"textContent"` // Text of the content, without markup
+	Length        int    `json:"length"`      // Number of characters in textContent
+	Excerpt       string `json:"excerpt"`
+	SiteName      string `json:"siteName"`
+	Lang          string `json:"lang"`
+	Dir           string `json:"dir"` // Text direction, "ltr" or "rtl"
+	PublishedTime string `json:"publishedTime"`
+}
+
+// readabilityJSON builds the readability-json output of an article. The content is the
+// root, or the fallback nodes when there is no root (see readability.FallbackNodes).
+func readabilityJSON(article readability.ReadabilityArticle) readabilityResult {
+	nodes := readability.FallbackNodes(article)
+	contents := make([]string, 0, len(nodes))
+	texts := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		contents = append(contents, readability.ToHTML(node))
+		texts = append(texts, readability.ExtractTextContent(node))
+	}
+	textContent := strings.Join(texts, "\n")
+	return readabilityResult{
+		Title:         article.Title,
+		Byline:        article.Byline,
+		Content:       strings.Join(contents, "\n"),
+		TextContent:   textContent,
+		Length:        utf8.RuneCountInString(textContent),
+		Excerpt:       article.Excerpt,
+		SiteName:      article.SiteName,
+		Lang:          article.Lang,
+		Dir:           article.Dir,
+		PublishedTime: article.PublishedTime,
+	}
+}
+
+// runDiff extracts two pages and writes how the second extraction differs from the first:
+// changed metadata as `field: "before" -> "after"`, then removed text lines prefixed
+// with "- " and added ones prefixed with "+ ".
+func runDiff(src1, src2, lang string, logger *slog.Logger, stdout, stderr io.Writer) int {
+	var articles [2]*readability.ReadabilityArticle
+	for i, src := range []string{src1, src2} {
+		body, err := readSource(src, logger)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return exitInputError
 		}
+		baseURL := ""
+		if isRequestURL(src) {
+			baseURL = src
+		}
+		article, err := parseContent(body, baseURL, lang, logger)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %s: %v\n", src, err)
+			return exitParseError
+		}
+		articles[i] = article
+	}
+
+	diff := readability.CompareArticles(*articles[0], *articles[1])
+	if diff.Equal() {
+		fmt.Fprintln(stdout, "No differences")
+		return exitOK
+	}
+	for _, change := range diff.Changes {
+		fmt.Fprintf(stdout, "%s: %q -> %q\n", change.Field, change.Before, change.After)
+	}
+	for _, line := range diff.Removed {
+		fmt.Fprintln(stdout, "- "+line)
+	}
+	for _, line := range diff.Added {
+		fmt.Fprintln(stdout, "+ "+line)
 	}
+	return exitOK
 }
 
-func readStdin() ([]byte, error) {
+// newLogger creates the logger for diagnostics written to stderr. Warnings are shown by
+// default; quiet shows errors only, and verbose adds the extraction details.
+func newLogger(stderr io.Writer, quiet, verbose bool) *slog.Logger {
+	level := slog.LevelWarn
+	switch {
+	case quiet:
+		level = slog.LevelError
+	case verbose:
+		level = slog.LevelDebug
+	}
+	return slog.New(slog.NewTextHandler(stderr, &slog.HandlerOptions{
+		Level: level,
+		// Timestamps only clutter the output of a short-lived command
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if len(groups) == 0 && attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	}))
+}
+
+func readStdin(stdin io.Reader) ([]byte, error) {
 	// limit to 1GiB to avoid blocking of command execution
-	r := io.LimitReader(os.Stdin, 1024*1024*1024)
+	r := io.LimitReader(stdin, 1024*1024*1024)
 	body, err := io.ReadAll(r)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read stdin: %w", err)
 	}
-	return body, nil
+	return gunzipIfCompressed(body)
 }
 
 func isRequestURL(s string) bool {
-	_, err := url.ParseRequestURI(s)
-	return err == nil
+	// Absolute file paths are valid request URIs too, so require an HTTP(S) URL
+	u, err := url.ParseRequestURI(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
 }
 
-func fetchContent(src string) ([]byte, error) {
+func fetchContent(src string, logger *slog.Logger) ([]byte, error) {
 	// Fetch the content
 	resp, err := http.Get(src)
 	if err != nil {
@@ -106,7 +366,7 @@ func fetchContent(src string) ([]byte, error) {
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
-			log.Printf("Warning: failed to close response body: %v", err)
+			logger.Warn("failed to close response body", slog.Any("error", err))
 		}
 	}()
 
@@ -122,38 +382,141 @@ func fetchContent(src string) ([]byte, error) {
 	return body, nil
 }
 
+// readSource reads the page at src: fetched if it is an HTTP(S) URL, read from disk otherwise.
+func readSource(src string, logger *slog.Logger) ([]byte, error) {
+	if isRequestURL(src) {
+		return fetchContent(src, logger)
+	}
+	return readFile(src)
+}
+
 func readFile(src string) ([]byte, error) {
 	// Read the file
 	body, err := os.ReadFile(src)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
-	return body, nil
+	return gunzipIfCompressed(body)
+}
+
+// gunzipIfCompressed decompresses gzip data (e.g. a saved .html.gz page),
+// detected by its magic bytes, and returns any other data unchanged.
+func gunzipIfCompressed(body []byte) ([]byte, error) {
+	if len(body) < 2 || body[0] != 0x1f || body[1] != 0x8b {
+		return body, nil
+	}
+	r, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress gzip data: %w", err)
+	}
+	defer r.Close()
+
+	// limit to 1GiB like stdin, to guard against decompression bombs
+	decompressed, err := io.ReadAll(io.LimitReader(r, 1024*1024*1024))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress gzip data: %w", err)
+	}
+	return decompressed, nil
 }
 
-func parseContent(body []byte) (*readability.ReadabilityArticle, error) {
+func parseContent(body []byte, baseURL, lang string, logger *slog.Logger) (*readability.ReadabilityArticle, error) {
 	// Parse the content
 	options := readability.DefaultOptions()
-	article, err := readability.Extract(string(body), options)
+	options.BaseURL = baseURL
+	options.Lang = lang
+	options.Logger = logger
+	start := time.Now()
+	var article readability.ReadabilityArticle
+	var err error
+	if isMHTML(body) {
+		// Saved pages reference their embedded images by cid: URLs
+		options.ResolveMHTMLImages = true
+		article, err = readability.ExtractMHTML(bytes.NewReader(body), options)
+	} else {
+		article, err = readability.Extract(string(body), options)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse content: %w", err)
 	}
+	logger.Info("extracted content",
+		slog.Duration("elapsed", time.Since(start)),
+		slog.String("pageType", string(article.PageType)),
+		slog.String("classificationReason", article.ClassificationReason),
+		slog.Bool("found", article.Root != nil),
+		slog.Int("nodeCount", article.NodeCount),
+	)
 	return &article, nil
 }
 
-// printUsage prints the usage information
-func printUsage() {
-	fmt.Println("Usage: readability [options] <url|file_path>")
-	fmt.Println("\nreadability is a command-line tool that extracts the main content from a web page.")
-	fmt.Println("The web page to be processed can be specified as a URL, a file path, or stdin.")
-	fmt.Println("\nOptions:")
-	fmt.Println("  --format <format>  Output format: html or markdown (default: html)")
-	fmt.Println("  --metadata         Output metadata as JSON instead of content")
-	fmt.Println("  --help             Show this help message")
-	fmt.Println("\nExamples:")
-	fmt.Println("  readability https://example.com/article")
-	fmt.Println("  readability ./article.html")
-	fmt.Println("  readability --format markdown https://example.com/article")
-	fmt.Println("  readability --metadata https://example.com/article")
-	fmt.Println("  cat ./article.html | readability --format markdown")
+// isMHTML checks whether the input is a web page saved as MHTML (.mhtml or .mht):
+// a MIME message whose header declares a multipart/related content type.
+func isMHTML(body []byte) bool {
+	header, err := textproto.NewReader(bufio.NewReader(bytes.NewReader(body))).ReadMIMEHeader()
+	if err != nil {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	return err == nil && mediaType == "multipart/related"
+}
+
+// describeElement formats a removed element as a CSS selector, e.g. div.ad-container#top
+func describeElement(removed readability.RemovedElement) string {
+	var b strings.Builder
+	b.WriteString(removed.TagName)
+	for _, class := range strings.Fields(removed.ClassName) {
+		b.WriteString("." + class)
+	}
+	if removed.ID != "" {
+		b.WriteString("#" + removed.ID)
+	}
+	return b.String()
+}
+
+// printUsage prints the usage information to w
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: readability [options] <url|file_path>")
+	fmt.Fprintln(w, "\nreadability is a command-line tool that extracts the main content from a web page.")
+	fmt.Fprintln(w, "The web page to be processed can be specified as a URL, a file path, or stdin.")
+	fmt.Fprintln(w, "Pages saved as MHTML (.mhtml or .mht) are detected and read from their HTML part.")
+	fmt.Fprintln(w, "\nOptions:")
+	fmt.Fprintln(w, "  --format <format>  Output format: html, markdown, sentences, readability-json, or aria-dot")
+	fmt.Fprintln(w, "                     (default: html). readability-json outputs the object returned by")
+	fmt.Fprintln(w, "                     Mozilla Readability (title, byline, content, textContent, length, ...)")
+	fmt.Fprintln(w, "                     aria-dot outputs the accessibility tree of the page as a Graphviz graph")
+	fmt.Fprintln(w, "  --line-ending <lf|crlf>")
+	fmt.Fprintln(w, "                     Line ending of the content (default: lf)")
+	fmt.Fprintln(w, "  --lang <lang>      Language of pages that do not declare one with <html lang>, e.g. ja;")
+	fmt.Fprintln(w, "                     a declared language wins. The character threshold is lowered for")
+	fmt.Fprintln(w, "                     pages in Chinese, Japanese, or Korean, declared or assumed,")
+	fmt.Fprintln(w, "                     and selects the abbreviations used by --format sentences")
+	fmt.Fprintln(w, "  --metadata         Output metadata as JSON instead of content")
+	fmt.Fprintln(w, "  --allow-empty      Exit with 0 even when no content is extracted")
+	fmt.Fprintln(w, "  --fallback         Output the header, footer, and other significant parts of the page")
+	fmt.Fprintln(w, "                     when no content is extracted")
+	fmt.Fprintln(w, "  --diff <file1> <file2>")
+	fmt.Fprintln(w, "                     Compare the extractions of two pages (URLs or files): changed title,")
+	fmt.Fprintln(w, "                     byline, and page type, and removed (-) and added (+) lines of text")
+	fmt.Fprintln(w, "  --warc <file>      Extract every HTML response in a WARC file (.warc or .warc.gz)")
+	fmt.Fprintln(w, "                     and output one JSON object per page (html, markdown, or sentences)")
+	fmt.Fprintln(w, "  --explain-preprocess")
+	fmt.Fprintln(w, "                     List the elements removed by preprocessing instead of content")
+	fmt.Fprintln(w, "  --quiet            Suppress warnings (errors are still reported)")
+	fmt.Fprintln(w, "  --verbose          Print extraction details (timing, chosen candidate, page type)")
+	fmt.Fprintln(w, "                     to stderr")
+	fmt.Fprintln(w, "  --help             Show this help message")
+	fmt.Fprintln(w, "\nExit codes:")
+	fmt.Fprintln(w, "  0  Content (or metadata) was written")
+	fmt.Fprintln(w, "  1  Unexpected error")
+	fmt.Fprintln(w, "  2  Invalid arguments, or the input could not be read or fetched")
+	fmt.Fprintln(w, "  3  The input could not be parsed")
+	fmt.Fprintln(w, "  4  No content was extracted (unless --allow-empty is given)")
+	fmt.Fprintln(w, "\nExamples:")
+	fmt.Fprintln(w, "  readability https://example.com/article")
+	fmt.Fprintln(w, "  readability ./article.html")
+	fmt.Fprintln(w, "  readability ./saved-page.mhtml")
+	fmt.Fprintln(w, "  readability --format markdown https://example.com/article")
+	fmt.Fprintln(w, "  readability --metadata https://example.com/article")
+	fmt.Fprintln(w, "  cat ./article.html | readability --format markdown")
+	fmt.Fprintln(w, "  readability --warc crawl.warc.gz --format markdown > articles.jsonl")
+	fmt.Fprintln(w, "  readability --diff before.html after.html")
 }
@@ -0,0 +1,409 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testArticle = `<html><head><title>Article</title></head><body><article>
+	<h1>Extraction</h1>` +
+	`<p>The extraction algorithm scores paragraphs, and picks the article with the <em>highest</em> score.</p>
+	<p>The extraction algorithm scores paragraphs, and picks the article with the highest score.</p>
+	<p>The extraction algorithm scores paragraphs, and picks the article with the highest score.</p>
+	<p>The extraction algorithm scores paragraphs, and picks the article with the highest score.</p>
+	<p>The extraction algorithm scores paragraphs, and picks the article with the highest score.</p>
+	<p>The extraction algorithm scores paragraphs, and picks the article with the highest score.</p>
+</article></body></html>`
+
+const testEmpty = `<html><head><title>Empty</title></head><body><p>Too short.</p></body></html>`
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	articleFile := filepath.Join(dir, "article.html")
+	if err := os.WriteFile(articleFile, []byte(testArticle), 0o644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	if _, err := gz.Write([]byte(testArticle)); err != nil {
+		t.Fatalf("Failed to compress test file: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("Failed to compress test file: %v", err)
+	}
+	gzipFile := filepath.Join(dir, "article.html.gz")
+	if err := os.WriteFile(gzipFile, compressed.Bytes(), 0o644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+	corruptFile := filepath.Join(dir, "corrupt.html.gz")
+	if err := os.WriteFile(corruptFile, compressed.Bytes()[:20], 0o644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		args           []string
+		stdin          string
+		expectedCode   int
+		expectedStdout string // Substring expected in stdout
+		expectedStderr string // Substring expected in stderr
+	}{
+		{
+			name:           "html from stdin by default",
+			stdin:          testArticle,
+			expectedCode:   exitOK,
+			expectedStdout: "<em>highest</em>",
+		},
+		{
+			name:           "markdown format",
+			args:           []string{"--format", "markdown"},
+			stdin:          testArticle,
+			expectedCode:   exitOK,
+			expectedStdout: "# Extraction\n\nThe extraction algorithm scores paragraphs, and picks the article with the *highest* score.",
+		},
+		{
+			name:           "sentences format",
+			args:           []string{"--format", "sentences"},
+			stdin:          testArticle,
+			expectedCode:   exitOK,
+			expectedStdout: "Extraction\nThe extraction algorithm scores paragraphs, and picks the article with the highest score.\n",
+		},
+		{
+			name:           "crlf line endings",
+			args:           []string{"--format", "markdown", "--line-ending", "crlf"},
+			stdin:          testArticle,
+			expectedCode:   exitOK,
+			expectedStdout: "# Extraction\r\n\r\nThe extraction algorithm",
+		},
+		{
+			name:           "unknown line ending",
+			args:           []string{"--line-ending", "cr"},
+			stdin:          testArticle,
+			expectedCode:   exitInputError,
+			expectedStderr: "unknown line ending: cr",
+		},
+		{
+			name:           "markdown format from a file",
+			args:           []string{"--format", "markdown", articleFile},
+			expectedCode:   exitOK,
+			expectedStdout: "# Extraction",
+		},
+		{
+			name:           "gzipped file",
+			args:           []string{"--format", "markdown", gzipFile},
+			expectedCode:   exitOK,
+			expectedStdout: "# Extraction",
+		},
+		{
+			name:           "gzipped stdin",
+			args:           []string{"--format", "markdown"},
+			stdin:          compressed.String(),
+			expectedCode:   exitOK,
+			expectedStdout: "# Extraction",
+		},
+		{
+			name:           "corrupt gzipped file",
+			args:           []string{corruptFile},
+			expectedCode:   exitInputError,
+			expectedStderr: "failed to decompress gzip data",
+		},
+		{
+			name:           "metadata",
+			args:           []string{"--metadata"},
+			stdin:          testArticle,
+			expectedCode:   exitOK,
+			expectedStdout: `"title": "Article"`,
+		},
+		{
+			name:           "unknown format",
+			args:           []string{"--format", "pdf"},
+			stdin:          testArticle,
+			expectedCode:   exitInputError,
+			expectedStderr: "unknown format: pdf",
+		},
+		{
+			name:           "unknown flag",
+			args:           []string{"--unknown"},
+			stdin:          testArticle,
+			expectedCode:   exitInputError,
+			expectedStderr: "Usage: readability",
+		},
+		{
+			name:           "missing file",
+			args:           []string{filepath.Join(dir, "missing.html")},
+			expectedCode:   exitInputError,
+			expectedStderr: "failed to read file",
+		},
+		{
+			name:           "no content",
+			stdin:          testEmpty,
+			expectedCode:   exitNoContent,
+			expectedStderr: "No content was extracted",
+		},
+		{
+			name:         "no content allowed",
+			args:         []string{"--allow-empty"},
+			stdin:        testEmpty,
+			expectedCode: exitOK,
+		},
+		{
+			name:           "fallback for a page without content",
+			args:           []string{"--fallback", "--format", "markdown"},
+			stdin:          `<html><head><title>Thin</title></head><body><main><h2>Welcome</h2><p>Too short.</p></main></body></html>`,
+			expectedCode:   exitOK,
+			expectedStdout: "## Welcome\n\nToo short.\n",
+		},
+		{
+			name:           "metadata without content",
+			args:           []string{"--metadata"},
+			stdin:          testEmpty,
+			expectedCode:   exitOK,
+			expectedStdout: `"title": "Empty"`,
+		},
+		{
+			name:           "explain preprocessing",
+			args:           []string{"--explain-preprocess"},
+			stdin:          `<html><body><nav class="site nav">Home</nav><div class="ad-container" id="top">Ad</div><p>Text</p></body></html>`,
+			expectedCode:   exitOK,
+			expectedStdout: "tag\tnav.site.nav\nad\tdiv.ad-container#top\n2 elements removed by preprocessing",
+		},
+		{
+			name:           "verbose",
+			args:           []string{"--verbose"},
+			stdin:          testArticle,
+			expectedCode:   exitOK,
+			expectedStdout: "<em>highest</em>",
+			expectedStderr: "msg=\"selected top candidate\" tag=article",
+		},
+		{
+			name:           "quiet and verbose",
+			args:           []string{"--quiet", "--verbose"},
+			stdin:          testArticle,
+			expectedCode:   exitInputError,
+			expectedStderr: "cannot be used together",
+		},
+		{
+			name:           "help",
+			args:           []string{"--help"},
+			expectedCode:   exitOK,
+			expectedStdout: "Exit codes:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			if code != tt.expectedCode {
+				t.Errorf("Expected exit code %d, got %d (stderr: %q)", tt.expectedCode, code, stderr.String())
+			}
+			if !strings.Contains(stdout.String(), tt.expectedStdout) {
+				t.Errorf("Expected stdout to contain %q, got %q", tt.expectedStdout, stdout.String())
+			}
+			if !strings.Contains(stderr.String(), tt.expectedStderr) {
+				t.Errorf("Expected stderr to contain %q, got %q", tt.expectedStderr, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRunLang(t *testing.T) {
+	// About 400 bytes of Japanese text: below the default threshold, above the CJK one
+	body := `<head><title>記事</title></head><body><article>` +
+		strings.Repeat("<p>本文抽出のアルゴリズムは段落を評価して記事を選びます。</p>", 5) +
+		`</article></body></html>`
+
+	tests := []struct {
+		name         string
+		page         string
+		args         []string
+		expectedCode int
+	}{
+		{name: "without lang", page: "<html>" + body, args: nil, expectedCode: exitNoContent},
+		{name: "with lang ja", page: "<html>" + body, args: []string{"--lang", "ja"}, expectedCode: exitOK},
+		{name: "with lang en", page: "<html>" + body, args: []string{"--lang", "en"}, expectedCode: exitNoContent},
+		{name: "declared ja wins over lang en", page: `<html lang="ja">` + body, args: []string{"--lang", "en"}, expectedCode: exitOK},
+		{name: "declared en wins over lang ja", page: `<html lang="en">` + body, args: []string{"--lang", "ja"}, expectedCode: exitNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, strings.NewReader(tt.page), &stdout, &stderr)
+			if code != tt.expectedCode {
+				t.Errorf("Expected exit code %d, got %d (stderr: %q)", tt.expectedCode, code, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRunMHTML(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"--format", "markdown", "../../testdata/mhtml/source.mhtml"}, strings.NewReader(""), &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("Expected exit code %d, got %d (stderr: %q)", exitOK, code, stderr.String())
+	}
+	output := stdout.String()
+	if !strings.Contains(output, "sent to the laboratory for analysis.") {
+		t.Errorf("Expected the HTML part to be extracted, got %q", output)
+	}
+	if !strings.Contains(output, "![Depth chart](data:image/png;base64,") {
+		t.Errorf("Expected the cid: image to be embedded, got %q", output)
+	}
+}
+
+func TestRunLogging(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string // Substring expected in stderr, or "" for no output
+	}{
+		{name: "default", args: nil, want: ""},
+		{name: "quiet", args: []string{"--quiet"}, want: ""},
+		{name: "verbose", args: []string{"--verbose"}, want: "msg=\"extracted content\""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(tt.args, strings.NewReader(testArticle), &stdout, &stderr); code != exitOK {
+				t.Fatalf("Expected exit code %d, got %d (stderr: %q)", exitOK, code, stderr.String())
+			}
+			if tt.want == "" && stderr.Len() > 0 {
+				t.Errorf("Expected no stderr output, got %q", stderr.String())
+			}
+			if tt.want != "" && !strings.Contains(stderr.String(), tt.want) {
+				t.Errorf("Expected stderr to contain %q, got %q", tt.want, stderr.String())
+			}
+			if strings.Contains(stderr.String(), "time=") {
+				t.Errorf("Expected no timestamps, got %q", stderr.String())
+			}
+		})
+	}
+}
+
+func TestNewLogger(t *testing.T) {
+	var stderr bytes.Buffer
+	newLogger(&stderr, false, false).Warn("failed to close response body")
+	if !strings.Contains(stderr.String(), "level=WARN") {
+		t.Errorf("Expected warnings by default, got %q", stderr.String())
+	}
+
+	stderr.Reset()
+	newLogger(&stderr, true, false).Warn("failed to close response body")
+	if stderr.Len() > 0 {
+		t.Errorf("Expected no warnings with quiet, got %q", stderr.String())
+	}
+}
+
+func TestRunMetadataJSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"--metadata"}, strings.NewReader(testArticle), &stdout, &stderr); code != exitOK {
+		t.Fatalf("Expected exit code %d, got %d (stderr: %q)", exitOK, code, stderr.String())
+	}
+
+	var metadata map[string]interface{}
+	if err := json.Unmarshal(stdout.Bytes(), &metadata); err != nil {
+		t.Fatalf("Expected valid JSON, got %v: %q", err, stdout.String())
+	}
+	if metadata["pageType"] != "article" {
+		t.Errorf("Expected pageType article, got %v", metadata["pageType"])
+	}
+	if metadata["classificationReason"] != "content extracted" {
+		t.Errorf("Expected classificationReason %q, got %v", "content extracted", metadata["classificationReason"])
+	}
+	if metadata["paywalled"] != false {
+		t.Errorf("Expected paywalled false, got %v", metadata["paywalled"])
+	}
+	if _, ok := metadata["section"]; !ok {
+		t.Error("Expected a section key")
+	}
+	for _, key := range []string{"prevURL", "nextURL"} {
+		if _, ok := metadata[key]; !ok {
+			t.Errorf("Expected a %s key", key)
+		}
+	}
+}
+
+func TestRunAriaDOT(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"--format", "aria-dot"}, strings.NewReader(testArticle), &stdout, &stderr); code != exitOK {
+		t.Fatalf("Expected exit code %d, got %d (stderr: %q)", exitOK, code, stderr.String())
+	}
+
+	output := stdout.String()
+	if !strings.HasPrefix(output, "digraph aria {\n") || !strings.HasSuffix(output, "}\n") {
+		t.Errorf("Expected a Graphviz digraph, got %q", output)
+	}
+	if !strings.Contains(output, " -> ") {
+		t.Errorf("Expected edges between the nodes, got %q", output)
+	}
+}
+
+func TestRunReadabilityJSON(t *testing.T) {
+	page := strings.Replace(testArticle, `<title>Article</title>`,
+		`<title>Article</title><meta name="description" content="How extraction works"><meta property="og:site_name" content="Example">`, 1)
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"--format", "readability-json"}, strings.NewReader(page), &stdout, &stderr); code != exitOK {
+		t.Fatalf("Expected exit code %d, got %d (stderr: %q)", exitOK, code, stderr.String())
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		t.Fatalf("Expected valid JSON, got %v: %q", err, stdout.String())
+	}
+	for _, key := range []string{"title", "byline", "content", "textContent", "length", "excerpt", "siteName", "dir"} {
+		if _, ok := result[key]; !ok {
+			t.Errorf("Expected a %s key", key)
+		}
+	}
+	if length, _ := result["length"].(float64); length <= 0 {
+		t.Errorf("Expected a positive length, got %v", result["length"])
+	}
+	if content, _ := result["content"].(string); !strings.Contains(content, "<em>highest</em>") {
+		t.Errorf("Expected the content as HTML, got %q", content)
+	}
+	if text, _ := result["textContent"].(string); strings.Contains(text, "<") || !strings.Contains(text, "highest score") {
+		t.Errorf("Expected the content as text, got %q", text)
+	}
+	if result["excerpt"] != "How extraction works" || result["siteName"] != "Example" {
+		t.Errorf("Expected the excerpt and site name from the meta tags, got %v and %v", result["excerpt"], result["siteName"])
+	}
+}
+
+func TestRunDiff(t *testing.T) {
+	dir := t.TempDir()
+	before := filepath.Join(dir, "before.html")
+	after := filepath.Join(dir, "after.html")
+	if err := os.WriteFile(before, []byte(testArticle), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	changed := strings.Replace(testArticle, "<h1>Extraction</h1>", "<h1>Extraction</h1><p>An added paragraph about the extraction algorithm and its scores.</p>", 1)
+	if err := os.WriteFile(after, []byte(changed), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"--diff", before, before}, strings.NewReader(""), &stdout, &stderr); code != exitOK {
+		t.Fatalf("Expected exit code %d, got %d (stderr: %q)", exitOK, code, stderr.String())
+	}
+	if output := strings.TrimSpace(stdout.String()); output != "No differences" {
+		t.Errorf("Expected no differences, got %q", output)
+	}
+
+	stdout.Reset()
+	if code := run([]string{"--diff", before, after}, strings.NewReader(""), &stdout, &stderr); code != exitOK {
+		t.Fatalf("Expected exit code %d, got %d (stderr: %q)", exitOK, code, stderr.String())
+	}
+	if output := stdout.String(); output != "+ An added paragraph about the extraction algorithm and its scores.\n" {
+		t.Errorf("Expected the added line, got %q", output)
+	}
+
+	if code := run([]string{"--diff", before}, strings.NewReader(""), &stdout, &stderr); code != exitInputError {
+		t.Errorf("Expected exit code %d for a single file, got %d", exitInputError, code)
+	}
+}
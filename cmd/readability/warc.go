@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/textproto"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mackee/go-readability"
+)
+
+// maxWARCRecordSize is the largest content block read from a WARC file. Larger records are
+// skipped without being read into memory, so that a bad Content-Length cannot exhaust it.
+var maxWARCRecordSize int64 = 100 * 1024 * 1024
+
+// warcRecord is a single record of a WARC file: its named fields and content block.
+type warcRecord struct {
+	header    textproto.MIMEHeader
+	block     []byte
+	oversized bool // The block was larger than maxWARCRecordSize and skipped; block is nil
+}
+
+// warcReader reads the records of a WARC file one by one.
+// It implements only what is needed to read response records, not the full WARC specification.
+type warcReader struct {
+	r  *bufio.Reader
+	tp *textproto.Reader
+}
+
+// newWARCReader creates a reader for WARC data. Gzipped WARC files (.warc.gz)
+// are decompressed transparently.
+func newWARCReader(r io.Reader) (*warcReader, error) {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		// Each record is usually its own gzip member; the gzip reader reads them all
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress WARC file: %w", err)
+		}
+		br = bufio.NewReader(gz)
+	}
+	return &warcReader{r: br, tp: textproto.NewReader(br)}, nil
+}
+
+// next returns the next record, or io.EOF when there are no more records.
+func (w *warcReader) next() (*warcRecord, error) {
+	// Skip the blank lines that end the previous record
+	var version string
+	for {
+		line, err := w.tp.ReadLine()
+		if err != nil {
+			return nil, err
+		}
+		if line != "" {
+			version = line
+			break
+		}
+	}
+	if !strings.HasPrefix(version, "WARC/") {
+		return nil, fmt.Errorf("invalid WARC record: unexpected line %q", version)
+	}
+
+	header, err := w.tp.ReadMIMEHeader()
+	if err != nil {
+		return nil, fmt.Errorf("invalid WARC record header: %w", err)
+	}
+	length, err := strconv.ParseInt(header.Get("Content-Length"), 10, 64)
+	if err != nil || length < 0 {
+		return nil, fmt.Errorf("invalid WARC record: bad Content-Length %q", header.Get("Content-Length"))
+	}
+	if length > maxWARCRecordSize {
+		if _, err := io.CopyN(io.Discard, w.r, length); err != nil {
+			return nil, fmt.Errorf("invalid WARC record: truncated content block: %w", err)
+		}
+		return &warcRecord{header: header, oversized: true}, nil
+	}
+	// Read through a limit instead of allocating the declared length up front, so that a
+	// truncated file only costs the data it holds
+	block, err := io.ReadAll(io.LimitReader(w.r, length))
+	if err != nil {
+		return nil, fmt.Errorf("invalid WARC record: failed to read content block: %w", err)
+	}
+	if int64(len(block)) < length {
+		return nil, fmt.Errorf("invalid WARC record: truncated content block: %w", io.ErrUnexpectedEOF)
+	}
+	return &warcRecord{header: header, block: block}, nil
+}
+
+// warcResult is one line of the JSONL output of --warc.
+type warcResult struct {
+	URL      string `json:"url"`
+	Title    string `json:"title,omitempty"`
+	Byline   string `json:"byline,omitempty"`
+	PageType string `json:"pageType,omitempty"`
+	Content  string `json:"content,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// htmlPayload returns the HTML body of a WARC response record, or false if the
+// record is not an HTTP response with an HTML content type.
+func htmlPayload(record *warcRecord) ([]byte, bool, error) {
+	if record.header.Get("WARC-Type") != "response" ||
+		!strings.HasPrefix(record.header.Get("Content-Type"), "application/http") {
+		return nil, false, nil
+	}
+	if record.oversized {
+		return nil, false, fmt.Errorf("record larger than %d bytes skipped", maxWARCRecordSize)
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(record.block)), nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to parse HTTP response: %w", err)
+	}
+	defer resp.Body.Close()
+
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || (mediaType != "text/html" && mediaType != "application/xhtml+xml") {
+		return nil, false, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read HTTP response body: %w", err)
+	}
+	body, err = gunzipIfCompressed(body)
+	if err != nil {
+		return nil, false, err
+	}
+	return body, true, nil
+}
+
+// runWARC extracts every HTML response in a WARC file and writes one JSON object per
+// page to stdout. Records that fail to extract are reported in the "error" field.
+// lang is the language assumed for pages that do not declare one.
+func runWARC(path, format, lang string, stdout, stderr io.Writer) int {
+	file, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: failed to open WARC file: %v\n", err)
+		return exitInputError
+	}
+	defer file.Close()
+
+	reader, err := newWARCReader(file)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitParseError
+	}
+
+	encoder := json.NewEncoder(stdout)
+	for {
+		record, err := reader.next()
+		if err == io.EOF {
+			return exitOK
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return exitParseError
+		}
+
+		body, ok, err := htmlPayload(record)
+		result := warcResult{URL: record.header.Get("WARC-Target-URI")}
+		switch {
+		case err != nil:
+			result.Error = err.Error()
+		case !ok:
+			continue
+		default:
+			options := readability.DefaultOptions()
+			options.BaseURL = result.URL
+			options.Lang = lang
+			article, err := readability.Extract(string(body), options)
+			if err != nil {
+				result.Error = err.Error()
+				break
+			}
+			result.Title = article.Title
+			result.Byline = article.Byline
+			result.PageType = string(article.PageType)
+			if article.Root != nil {
+				switch format {
+				case "markdown":
+					result.Content = readability.ToMarkdown(article.Root)
+				case "sentences":
+					result.Content = strings.Join(readability.ToSentences(article.Root, article.Lang), "\n")
+				default:
+					result.Content = readability.ToHTML(article.Root)
+				}
+			}
+		}
+
+		if err := encoder.Encode(result); err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return exitError
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// warcRecordString builds a WARC record with the given type, target URI, content type, and block.
+func warcRecordString(warcType, targetURI, contentType, block string) string {
+	return fmt.Sprintf("WARC/1.0\r\nWARC-Type: %s\r\nWARC-Target-URI: %s\r\nContent-Type: %s\r\nContent-Length: %d\r\n\r\n%s\r\n\r\n",
+		warcType, targetURI, contentType, len(block), block)
+}
+
+// httpResponseString builds a raw HTTP response with the given content type and body.
+func httpResponseString(contentType, body string) string {
+	return fmt.Sprintf("HTTP/1.1 200 OK\r\nContent-Type: %s\r\nContent-Length: %d\r\n\r\n%s", contentType, len(body), body)
+}
+
+func TestRunWARC(t *testing.T) {
+	warc := warcRecordString("warcinfo", "", "application/warc-fields", "software: test\r\n") +
+		warcRecordString("request", "https://example.com/article", "application/http; msgtype=request", "GET /article HTTP/1.1\r\nHost: example.com\r\n\r\n") +
+		warcRecordString("response", "https://example.com/article", "application/http; msgtype=response", httpResponseString("text/html; charset=utf-8", testArticle)) +
+		warcRecordString("response", "https://example.com/logo.png", "application/http; msgtype=response", httpResponseString("image/png", "\x89PNG")) +
+		warcRecordString("response", "https://example.com/short", "application/http; msgtype=response", httpResponseString("text/html", testEmpty))
+
+	dir := t.TempDir()
+	plainFile := filepath.Join(dir, "crawl.warc")
+	if err := os.WriteFile(plainFile, []byte(warc), 0o644); err != nil {
+		t.Fatalf("Failed to write WARC file: %v", err)
+	}
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	if _, err := gz.Write([]byte(warc)); err != nil {
+		t.Fatalf("Failed to compress WARC file: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("Failed to compress WARC file: %v", err)
+	}
+	gzipFile := filepath.Join(dir, "crawl.warc.gz")
+	if err := os.WriteFile(gzipFile, compressed.Bytes(), 0o644); err != nil {
+		t.Fatalf("Failed to write WARC file: %v", err)
+	}
+
+	for _, path := range []string{plainFile, gzipFile} {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run([]string{"--warc", path, "--format", "markdown"}, strings.NewReader(""), &stdout, &stderr); code != exitOK {
+				t.Fatalf("Expected exit code %d, got %d (stderr: %q)", exitOK, code, stderr.String())
+			}
+
+			lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+			if len(lines) != 2 {
+				t.Fatalf("Expected 2 JSON lines (HTML responses only), got %d: %q", len(lines), stdout.String())
+			}
+			var results []warcResult
+			for _, line := range lines {
+				var result warcResult
+				if err := json.Unmarshal([]byte(line), &result); err != nil {
+					t.Fatalf("Expected valid JSON, got %v: %q", err, line)
+				}
+				results = append(results, result)
+			}
+
+			if results[0].URL != "https://example.com/article" || results[0].Title != "Article" ||
+				!strings.HasPrefix(results[0].Content, "# Extraction") {
+				t.Errorf("Unexpected result for the article: %+v", results[0])
+			}
+			if results[1].URL != "https://example.com/short" || results[1].Content != "" {
+				t.Errorf("Expected no content for the short page, got %+v", results[1])
+			}
+		})
+	}
+
+	t.Run("malformed WARC", func(t *testing.T) {
+		path := filepath.Join(dir, "broken.warc")
+		if err := os.WriteFile(path, []byte("not a warc file\r\n"), 0o644); err != nil {
+			t.Fatalf("Failed to write WARC file: %v", err)
+		}
+		var stdout, stderr bytes.Buffer
+		if code := run([]string{"--warc", path}, strings.NewReader(""), &stdout, &stderr); code != exitParseError {
+			t.Errorf("Expected exit code %d, got %d", exitParseError, code)
+		}
+	})
+
+	t.Run("oversized record", func(t *testing.T) {
+		article := httpResponseString("text/html", testArticle)
+		short := httpResponseString("text/html", testEmpty)
+		path := filepath.Join(dir, "large.warc")
+		large := warcRecordString("response", "https://example.com/article", "application/http; msgtype=response", article) +
+			warcRecordString("response", "https://example.com/short", "application/http; msgtype=response", short)
+		if err := os.WriteFile(path, []byte(large), 0o644); err != nil {
+			t.Fatalf("Failed to write WARC file: %v", err)
+		}
+
+		defer func(size int64) { maxWARCRecordSize = size }(maxWARCRecordSize)
+		maxWARCRecordSize = int64(len(short))
+
+		var stdout, stderr bytes.Buffer
+		if code := run([]string{"--warc", path}, strings.NewReader(""), &stdout, &stderr); code != exitOK {
+			t.Fatalf("Expected exit code %d, got %d (stderr: %q)", exitOK, code, stderr.String())
+		}
+		lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("Expected 2 JSON lines, got %d: %q", len(lines), stdout.String())
+		}
+		var first, second warcResult
+		if err := json.Unmarshal([]byte(lines[0]), &first); err != nil || !strings.Contains(first.Error, "larger than") {
+			t.Errorf("Expected an error for the oversized record, got %+v (%v)", first, err)
+		}
+		if err := json.Unmarshal([]byte(lines[1]), &second); err != nil || second.URL != "https://example.com/short" || second.Error != "" {
+			t.Errorf("Expected the record after the oversized one to be read, got %+v (%v)", second, err)
+		}
+	})
+
+	t.Run("Content-Length beyond the file", func(t *testing.T) {
+		path := filepath.Join(dir, "truncated.warc")
+		truncated := "WARC/1.0\r\nWARC-Type: response\r\nContent-Length: 1099511627776\r\n\r\nshort"
+		if err := os.WriteFile(path, []byte(truncated), 0o644); err != nil {
+			t.Fatalf("Failed to write WARC file: %v", err)
+		}
+		var stdout, stderr bytes.Buffer
+		if code := run([]string{"--warc", path}, strings.NewReader(""), &stdout, &stderr); code != exitParseError {
+			t.Errorf("Expected exit code %d, got %d", exitParseError, code)
+		}
+	})
+
+	for _, format := range []string{"aria-dot", "readability-json"} {
+		t.Run("unsupported format "+format, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run([]string{"--warc", plainFile, "--format", format}, strings.NewReader(""), &stdout, &stderr); code != exitInputError {
+				t.Errorf("Expected exit code %d, got %d", exitInputError, code)
+			}
+			if stdout.Len() != 0 {
+				t.Errorf("Expected no output, got %q", stdout.String())
+			}
+		})
+	}
+
+	t.Run("missing WARC", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		if code := run([]string{"--warc", filepath.Join(dir, "missing.warc")}, strings.NewReader(""), &stdout, &stderr); code != exitInputError {
+			t.Errorf("Expected exit code %d, got %d", exitInputError, code)
+		}
+	})
+}
@@ -4,9 +4,17 @@
 package readability
 
 import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
+	"unicode/utf8"
 
 	"github.com/mackee/go-readability/internal/dom"
+	"github.com/mackee/go-readability/internal/parser"
 	"github.com/mackee/go-readability/internal/util"
 )
 
@@ -21,33 +29,23 @@ import (
 //
 // Returns:
 //   - A ReadabilityArticle containing the extracted content and metadata
-//   - An error if the HTML parsing fails
-func Extract(html string, options ReadabilityOptions) (ReadabilityArticle, error) {
-	// Parse HTML to create virtual DOM
-	doc, err := ParseHTML(html, "")
+//   - An error wrapping ErrParseFailed if the HTML parsing fails, or ErrNoBody if the document has no body.
+//     An error wrapping ErrInvalidOptions is returned if options.TagsToScore, an extra pattern,
+//     or an IncludeOnlySelectors entry is invalid, or if options.AncestorDepth is negative.
+//     With options.StrictErrors, ErrEmptyDocument or ErrNoContent is returned when nothing can be extracted.
+//     Extract does not panic on any input: an unexpected failure is returned as ErrExtractionFailed.
+func Extract(html string, options ReadabilityOptions) (article ReadabilityArticle, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			article, err = ReadabilityArticle{}, fmt.Errorf("%w: %v", ErrExtractionFailed, r)
+		}
+	}()
+
+	document, err := Parse(html, options)
 	if err != nil {
 		return ReadabilityArticle{}, err
 	}
-
-	// Execute preprocessing
-	PreprocessDocument(doc)
-
-	// Set default values if not provided
-	if options.CharThreshold <= 0 {
-		options.CharThreshold = util.DefaultCharThreshold
-	}
-
-	if options.NbTopCandidates <= 0 {
-		options.NbTopCandidates = util.DefaultNTopCandidates
-	}
-
-	// Set default page type if not specified
-	if options.ForcedPageType == "" {
-		options.ForcedPageType = PageTypeArticle
-	}
-
-	// Extract content
-	return ExtractContent(doc, options), nil
+	return document.Extract()
 }
 
 // ExtractContent extracts the main content from a document.
@@ -59,8 +57,29 @@ func Extract(html string, options ReadabilityOptions) (ReadabilityArticle, error
 //   - options: Configuration options for the extraction process
 //
 // Returns:
-//   - A ReadabilityArticle containing the extracted content and metadata
+//   - A ReadabilityArticle containing the extracted content and metadata,
+//     or an empty ReadabilityArticle if the document has no body
 func ExtractContent(doc *dom.VDocument, options ReadabilityOptions) ReadabilityArticle {
+	article, _ := extractContent(doc, options)
+	return article
+}
+
+// extractContent implements ExtractContent, and also reports the character threshold the
+// top candidate was measured against, after the adjustments for the language or script.
+//
+// Parameters:
+//   - doc: The parsed HTML document as a VDocument
+//   - options: Configuration options for the extraction process
+//
+// Returns:
+//   - A ReadabilityArticle containing the extracted content and metadata,
+//     or an empty ReadabilityArticle if the document has no body
+//   - The character threshold applied
+func extractContent(doc *dom.VDocument, options ReadabilityOptions) (ReadabilityArticle, int) {
+	if !hasBody(doc) {
+		return ReadabilityArticle{}, 0
+	}
+
 	// Set default values if not provided
 	charThreshold := options.CharThreshold
 	if charThreshold <= 0 {
@@ -72,41 +91,133 @@ func ExtractContent(doc *dom.VDocument, options ReadabilityOptions) ReadabilityA
 		nbTopCandidates = util.DefaultNTopCandidates
 	}
 
+	ancestorDepth := options.AncestorDepth
+	if ancestorDepth == 0 {
+		ancestorDepth = util.DefaultAncestorDepth
+	}
+
+	// Invalid tag names are skipped here; Extract reports them as errors
+	tagsToScore := util.DefaultTagsToScore
+	if len(options.TagsToScore) > 0 {
+		tagsToScore, _ = normalizeTagsToScore(options.TagsToScore)
+	}
+
 	generateAriaTree := options.GenerateAriaTree
 
 	// Find content candidates
-	candidates := FindMainCandidates(doc, nbTopCandidates)
+	// Invalid patterns fall back to the defaults here; Extract reports them as errors
+	patterns, err := newClassPatterns(options)
+	if err != nil {
+		patterns = defaultClassPatterns
+	}
+
+	var candidates []*dom.VElement
+	var candidateSummaries []CandidateSummary
 	var topCandidate *dom.VElement
 	var articleContent *dom.VElement
+	script := ScriptUnknown
+
+	// The language declared by the document wins over the configured one
+	lang := GetDocumentLanguage(doc)
+	if lang == "" {
+		lang = options.Lang
+	}
+
+	// Invalid selectors select nothing here; Extract reports them as errors
+	included, err := parseSelectors(options.IncludeOnlySelectors)
+	if err != nil {
+		included = nil
+	}
+	if len(options.IncludeOnlySelectors) > 0 {
+		// The allowlisted subtrees are the content; no scoring
+		if elements := selectOutermost(doc.Body, included); len(elements) > 0 {
+			articleContent = dom.NewVElement("div")
+			for _, element := range elements {
+				element.Parent().RemoveChild(element)
+				articleContent.AppendChild(element)
+			}
+			script = DetectScript(GetInnerText(articleContent, false))
+		}
+	} else {
+		scoredCandidates := findMainCandidates(doc, nbTopCandidates, ancestorDepth, tagsToScore, patterns)
+		candidates = candidateElements(scoredCandidates)
+		// Summarize before postprocessing changes the attributes of the content
+		if options.Debug {
+			candidateSummaries = summarizeCandidates(scoredCandidates)
+		}
+	}
 
 	// Select the best candidate if any exist
 	if len(candidates) > 0 {
 		topCandidate = candidates[0] // Highest scoring candidate
 
 		// Check if the candidate contains meaningful content
-		textLength := len(GetInnerText(topCandidate, false))
+		innerText := GetInnerText(topCandidate, false)
+		textLength := len(innerText)
+		script = DetectScript(innerText)
+		// The declared language, or the configured one if none is declared, decides the threshold
+		if isCJKLanguage(lang) {
+			charThreshold = effectiveCharThreshold(charThreshold, ScriptCJK)
+		} else if options.AutoThreshold {
+			charThreshold = effectiveCharThreshold(charThreshold, script)
+		}
 		linkDensity := GetLinkDensity(topCandidate)
 
 		// If the candidate has enough text and low link density, it's probably content
 		if textLength >= charThreshold && linkDensity <= 0.5 {
 			articleContent = topCandidate
+		} else if options.AllowShortContent && isClearlyShortContent(topCandidate, textLength, linkDensity, patterns) {
+			articleContent = topCandidate
+		}
+
+		if logger := options.Logger; logger != nil {
+			score := 0.0
+			if data := topCandidate.GetReadabilityData(); data != nil {
+				score = data.ContentScore
+			}
+			logger.Debug("selected top candidate",
+				slog.String("tag", topCandidate.TagName),
+				slog.Float64("score", score),
+				slog.Int("textLength", textLength),
+				slog.Float64("linkDensity", linkDensity),
+				slog.Int("charThreshold", charThreshold),
+				slog.Bool("accepted", articleContent != nil),
+			)
 		}
 	}
 
 	// Determine page type (forced or auto-detected)
 	pageType := options.ForcedPageType
-	if pageType == "" {
+	classificationReason := ClassificationReasonForced
+	switch {
+	case articleContent != nil && (pageType == "" || pageType == PageTypeArticle):
 		// If we found content, it's probably an article
-		if articleContent != nil {
-			pageType = PageTypeArticle
-		} else {
-			pageType = ClassifyPageType(doc, candidates, charThreshold, "")
-		}
+		pageType = PageTypeArticle
+		classificationReason = ClassificationReasonContentExtracted
+	case pageType == "":
+		pageType, classificationReason = ClassifyPageTypeWithReason(doc, candidates, charThreshold, "")
+	}
+	if logger := options.Logger; logger != nil {
+		logger.Debug("decided page type",
+			slog.String("pageType", string(pageType)),
+			slog.String("reason", classificationReason),
+			slog.Bool("forced", options.ForcedPageType != ""),
+			slog.Int("candidates", len(candidates)),
+		)
 	}
 
 	// Get metadata
-	title := GetArticleTitle(doc)
+	titleMinLength, titleMaxLength := options.titleBounds()
+	title := getArticleTitle(doc, titleMinLength, titleMaxLength, options.TitlePrecedence)
 	byline := GetArticleByline(doc)
+	keywords := GetArticleKeywords(doc)
+	structuredData := GetStructuredData(doc)
+	faviconURL := GetFaviconURL(doc)
+	publishedTime := GetPublishedTime(doc, options.now())
+	section := GetArticleSection(doc)
+	breadcrumbs := breadcrumbNames(structuredData)
+	excerpt, siteName := getExcerptAndSiteName(doc, GetJSONLD(doc))
+	prevURL, nextURL := GetAdjacentArticleURLs(doc)
 
 	// Detect structural elements if needed (for ARTICLE type but no content found)
 	var header *dom.VElement
@@ -125,18 +236,255 @@ func ExtractContent(doc *dom.VDocument, options ReadabilityOptions) ReadabilityA
 		ariaTree = nil
 	}
 
+	// Flag paywalled pages before postprocessing trims trailing links
+	paywalled := isPaywalledDocument(doc, structuredData) || endsWithContinueReading(articleContent)
+
+	// Rate the selected content before postprocessing options trim it
+	score := contentQuality(articleContent)
+
+	// Keep the original markup of the selected region before postprocessing changes it
+	rawHTML := ""
+	if articleContent != nil && options.IncludeRawHTML {
+		rawHTML = parser.SerializeToHTML(articleContent)
+	}
+
+	// Cut the content at the end-of-content marker
+	if articleContent != nil && options.StopSelector != "" {
+		// An invalid selector selects nothing here; Extract reports it as an error
+		if stop, err := parseSelectors([]string{options.StopSelector}); err == nil {
+			trimAtStopElement(articleContent, stop)
+		}
+	}
+
+	// Drop empty headings and tidy the whitespace of the others before the outline is built
+	if articleContent != nil {
+		normalizeHeadings(articleContent)
+	}
+
+	// Drop the byline line that repeats the extracted byline
+	if articleContent != nil && options.DedupeBylineInContent && !options.IncludeByline {
+		removeLeadingByline(articleContent, byline)
+	}
+
+	// Pull the byline above the content into it
+	if articleContent != nil && options.IncludeByline {
+		if bylineElement := FindBylineElement(articleContent); bylineElement != nil {
+			if parent := bylineElement.Parent(); parent != nil {
+				parent.RemoveChild(bylineElement)
+			}
+			articleContent.PrependChild(bylineElement)
+		}
+	}
+
+	// Join fragmented inline markup
+	if articleContent != nil && options.MergeInlineElements {
+		mergeInlineElements(articleContent)
+	}
+
+	// Drop menus embedded in the content
+	if articleContent != nil && options.RemoveNavLike {
+		removeNavLike(articleContent)
+	}
+
+	// Unwrap placeholder links such as <a href="#">
+	if articleContent != nil && options.RemoveEmptyLinks {
+		removeEmptyLinks(articleContent)
+	}
+
+	// Drop legal boilerplate that slipped into the end of the content
+	if articleContent != nil && options.TrimBoilerplate {
+		trimBoilerplate(articleContent)
+	}
+
+	// Keep only the opening paragraphs
+	truncated := false
+	if articleContent != nil && options.MaxParagraphs > 0 {
+		truncated = limitParagraphs(articleContent, options.MaxParagraphs)
+	}
+
+	// Keep the direction of right-to-left content and isolate the URLs and code in it
+	dir := GetTextDirection(doc, articleContent)
+	if articleContent != nil && dir == dirRTL {
+		isolateLTRRuns(articleContent)
+	}
+
+	// Trim the content to the requested length, once the markup it is rendered with is final
+	if articleContent != nil && options.MaxOutputChars > 0 {
+		truncated = fitRenderedContent(articleContent, options.MaxOutputChars, options.OutputFormat) || truncated
+	}
+
 	// Create and return the article
 	return ReadabilityArticle{
 		Title:                 title,
 		Byline:                byline,
+		Excerpt:               excerpt,
+		SiteName:              siteName,
+		PublishedTime:         publishedTime,
+		Section:               section,
+		Breadcrumbs:           breadcrumbs,
+		PrevURL:               prevURL,
+		NextURL:               nextURL,
 		Root:                  articleContent,
 		NodeCount:             CountNodes(articleContent),
 		PageType:              pageType,
+		ClassificationReason:  classificationReason,
 		Header:                header,
 		Footer:                footer,
 		OtherSignificantNodes: otherSignificantNodes,
 		AriaTree:              ariaTree,
+		Outline:               GetOutline(articleContent),
+		Truncated:             truncated,
+		Paywalled:             paywalled,
+		RawHTML:               rawHTML,
+		Script:                script,
+		Lang:                  lang,
+		Dir:                   dir,
+		Keywords:              keywords,
+		StructuredData:        structuredData,
+		FaviconURL:            faviconURL,
+		Images:                GetImages(articleContent, doc.BaseURI),
+		MediaTracks:           GetMediaTracks(articleContent, doc.BaseURI),
+		Score:                 score,
+		Candidates:            candidateSummaries,
+	}, charThreshold
+}
+
+// tagNameRegex matches lowercase HTML tag names.
+var tagNameRegex = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// isValidTagName checks whether a string is a lowercase HTML tag name such as "p" or "h2".
+//
+// Parameters:
+//   - tag: The string to check
+//
+// Returns:
+//   - true if the string is a valid lowercase tag name
+func isValidTagName(tag string) bool {
+	return tagNameRegex.MatchString(tag)
+}
+
+// normalizeTagsToScore lowercases the entries of ReadabilityOptions.TagsToScore and drops
+// duplicates, so that no element is scored twice.
+//
+// Parameters:
+//   - tags: The configured tag names
+//
+// Returns:
+//   - The valid tag names, lowercased and without duplicates, in their original order
+//   - An error wrapping ErrInvalidOptions for the first entry that is not a tag name
+func normalizeTagsToScore(tags []string) ([]string, error) {
+	var err error
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		lower := strings.ToLower(strings.TrimSpace(tag))
+		if !isValidTagName(lower) {
+			if err == nil {
+				err = fmt.Errorf("%w: TagsToScore entry %q is not a tag name", ErrInvalidOptions, tag)
+			}
+			continue
+		}
+		if !seen[lower] {
+			seen[lower] = true
+			normalized = append(normalized, lower)
+		}
 	}
+	return normalized, err
+}
+
+// DefaultTagsToScore returns the tag names whose text is scored by default.
+// Append to the result to extend the default set through ReadabilityOptions.TagsToScore.
+//
+// Returns:
+//   - A copy of the default tag names
+func DefaultTagsToScore() []string {
+	return append([]string(nil), util.DefaultTagsToScore...)
+}
+
+// isClearlyShortContent checks whether a candidate below the character threshold is still
+// unmistakably the main content: a semantic container (article/main) or one with a strongly
+// positive class weight, with some text and few links.
+//
+// Parameters:
+//   - candidate: The top candidate element
+//   - textLength: The length of the candidate's inner text
+//   - linkDensity: The link density of the candidate
+//   - patterns: The class/ID patterns used to weigh the candidate
+//
+// Returns:
+//   - true if the candidate should be accepted regardless of the character threshold
+func isClearlyShortContent(candidate *dom.VElement, textLength int, linkDensity float64, patterns classPatterns) bool {
+	if textLength == 0 || linkDensity > 0.25 {
+		return false
+	}
+	tagName := strings.ToLower(candidate.TagName)
+	return tagName == "article" || tagName == "main" || patterns.classWeight(candidate) >= 25
+}
+
+// Saturation points of the components of contentQuality: a component reaches 1 at these values.
+const (
+	qualityFullContentScore = 50.0   // Summed base scores of the paragraphs
+	qualityFullTextLength   = 2000.0 // Characters of text
+	qualityFullParagraphs   = 5.0    // Paragraphs with text
+	qualityZeroLinkDensity  = 0.5    // Link density at which the link component drops to 0
+)
+
+// contentQuality rates the extracted content from 0 (no or poor content) to 1 (rich article).
+// It is the weighted sum of four components, each clamped to [0, 1]:
+//
+//	0.3 × contentScore / 50          (summed base scores of its paragraphs)
+//	0.3 × textLength / 2000          (characters of text)
+//	0.2 × (1 - linkDensity / 0.5)    (share of text outside links)
+//	0.2 × paragraphs / 5             (<p> elements with text)
+//
+// The content score is recomputed from the paragraphs rather than read from the element,
+// since content found by a semantic tag or by IncludeOnlySelectors is never scored.
+//
+// Parameters:
+//   - content: The selected content element, or nil
+//
+// Returns:
+//   - The quality score in [0, 1]
+func contentQuality(content *dom.VElement) float64 {
+	if content == nil {
+		return 0
+	}
+
+	contentScore := 0.0
+	for _, tag := range util.DefaultTagsToScore {
+		for _, element := range GetElementsByTagName(content, tag) {
+			if measurement := measureElement(element, defaultClassPatterns); measurement.ok {
+				contentScore += measurement.score
+			}
+		}
+	}
+	textLength := float64(utf8.RuneCountInString(GetInnerText(content, true)))
+	paragraphs := 0
+	for _, p := range GetElementsByTagName(content, "p") {
+		if GetInnerText(p, true) != "" {
+			paragraphs++
+		}
+	}
+
+	clamp := func(value float64) float64 {
+		return max(0, minFloat(value, 1))
+	}
+	return 0.3*clamp(contentScore/qualityFullContentScore) +
+		0.3*clamp(textLength/qualityFullTextLength) +
+		0.2*clamp(1-GetLinkDensity(content)/qualityZeroLinkDensity) +
+		0.2*clamp(float64(paragraphs)/qualityFullParagraphs)
+}
+
+// hasBody checks whether a document has both a document element and a body.
+// Documents built by hand (rather than by ParseHTML) may lack either.
+//
+// Parameters:
+//   - doc: The document to check
+//
+// Returns:
+//   - true if the document can be used for extraction
+func hasBody(doc *dom.VDocument) bool {
+	return doc != nil && doc.DocumentElement != nil && doc.Body != nil
 }
 
 // FindStructuralElements detects header, footer, and other significant structural elements in a document.
@@ -156,6 +504,9 @@ func FindStructuralElements(doc *dom.VDocument) (
 	footer *dom.VElement,
 	otherSignificantNodes []*dom.VElement,
 ) {
+	if !hasBody(doc) {
+		return nil, nil, nil
+	}
 	body := doc.Body
 
 	// 1. Look for header candidates
@@ -236,8 +587,10 @@ func FindStructuralElements(doc *dom.VDocument) (
 	potentialNodes = append(potentialNodes, asideTags...)
 	potentialNodes = append(potentialNodes, navTags...)
 
-	// Add elements with significant class names or IDs
-	AddSignificantElementsByClassOrId(body, &potentialNodes)
+	// Add elements with significant class names or IDs. The ARIA labels of all the
+	// elements are resolved through a single ID index
+	index := &elementIDIndex{}
+	addSignificantElementsByClassOrId(body, &potentialNodes, index)
 
 	// Filter out nodes inside header or footer
 	for _, node := range potentialNodes {
@@ -263,23 +616,56 @@ func FindStructuralElements(doc *dom.VDocument) (
 
 		if !isInsideHeaderOrFooter && !alreadyIncluded {
 			// Check if node is visible and has significant content
-			if IsProbablyVisible(node) && (IsSignificantNode(node) || IsSemanticTag(node)) {
+			if IsProbablyVisible(node) && (isSignificantNode(node, index) || IsSemanticTag(node)) {
 				otherSignificantNodes = append(otherSignificantNodes, node)
 			}
 		}
 	}
 
+	// The nodes were collected by tag and by class/ID; return them in document order
+	sortInDocumentOrder(body, otherSignificantNodes)
+
 	return header, footer, otherSignificantNodes
 }
 
-// AddSignificantElementsByClassOrId detects elements with meaningful class names or IDs
-// and adds them to the potentialNodes slice. This helps identify content containers
-// that might not use semantic HTML tags but follow common naming conventions.
+// sortInDocumentOrder sorts elements in place by their position in the document.
+//
+// Parameters:
+//   - root: The element containing all the elements to sort
+//   - elements: The elements to sort
+func sortInDocumentOrder(root *dom.VElement, elements []*dom.VElement) {
+	if len(elements) < 2 {
+		return
+	}
+	positions := make(map[*dom.VElement]int)
+	for i, element := range GetElementsByTagName(root, "*") {
+		positions[element] = i
+	}
+	sort.SliceStable(elements, func(i, j int) bool {
+		return positions[elements[i]] < positions[elements[j]]
+	})
+}
+
+// AddSignificantElementsByClassOrId detects elements with meaningful class names, IDs,
+// or ARIA labels (aria-label, or the text referenced by aria-labelledby) and adds them to
+// the potentialNodes slice. This helps identify content containers that might not use
+// semantic HTML tags but follow common naming conventions.
 //
 // Parameters:
 //   - body: The body element to search within
 //   - potentialNodes: A pointer to a slice where identified elements will be added
 func AddSignificantElementsByClassOrId(body *dom.VElement, potentialNodes *[]*dom.VElement) {
+	addSignificantElementsByClassOrId(body, potentialNodes, &elementIDIndex{})
+}
+
+// addSignificantElementsByClassOrId implements AddSignificantElementsByClassOrId, resolving
+// aria-labelledby through index.
+//
+// Parameters:
+//   - body: The body element to search within
+//   - potentialNodes: A pointer to a slice where identified elements will be added
+//   - index: The ID index shared by the elements of the document
+func addSignificantElementsByClassOrId(body *dom.VElement, potentialNodes *[]*dom.VElement, index *elementIDIndex) {
 	allElements := GetElementsByTagName(body, "*")
 
 	// Patterns for significant class names or IDs
@@ -302,7 +688,7 @@ func AddSignificantElementsByClassOrId(body *dom.VElement, potentialNodes *[]*do
 	for _, el := range allElements {
 		className := strings.ToLower(el.ClassName())
 		id := strings.ToLower(el.ID())
-		combinedString := className + " " + id
+		combinedString := className + " " + id + " " + strings.ToLower(getAriaLabel(el, index))
 
 		// Check if element has a significant class name or ID
 		for _, pattern := range significantPatterns {
@@ -355,10 +741,84 @@ func minFloat(x, y float64) float64 {
 // Returns:
 //   - A slice of the top N candidate elements, sorted by score in descending order
 func FindMainCandidates(doc *dom.VDocument, nbTopCandidates int) []*dom.VElement {
+	return FindMainCandidatesWithDepth(doc, nbTopCandidates, util.DefaultAncestorDepth)
+}
+
+// FindMainCandidatesWithDepth works like FindMainCandidates, but propagates each element's
+// score to the given number of ancestor levels instead of the default of 3.
+// Deeply nested layouts need a larger depth for the score to reach the content container.
+//
+// Parameters:
+//   - doc: The parsed HTML document
+//   - nbTopCandidates: The number of top candidates to return
+//   - ancestorDepth: The number of ancestor levels that receive score (at least 1)
+//
+// Returns:
+//   - A slice of the top N candidate elements, sorted by score in descending order
+func FindMainCandidatesWithDepth(doc *dom.VDocument, nbTopCandidates int, ancestorDepth int) []*dom.VElement {
+	return candidateElements(findMainCandidates(doc, nbTopCandidates, ancestorDepth, util.DefaultTagsToScore, defaultClassPatterns))
+}
+
+// scoredCandidate is a content candidate with its final score.
+type scoredCandidate struct {
+	element *dom.VElement
+	score   float64
+}
+
+// candidateElements returns the elements of scored candidates, in the same order.
+func candidateElements(scoredCandidates []scoredCandidate) []*dom.VElement {
+	elements := make([]*dom.VElement, 0, len(scoredCandidates))
+	for _, candidate := range scoredCandidates {
+		elements = append(elements, candidate.element)
+	}
+	return elements
+}
+
+// summarizeCandidates describes scored candidates for ReadabilityArticle.Candidates.
+//
+// Parameters:
+//   - scoredCandidates: The candidates, sorted by score in descending order
+//
+// Returns:
+//   - The summaries in the same order, or nil if there are no candidates
+func summarizeCandidates(scoredCandidates []scoredCandidate) []CandidateSummary {
+	var summaries []CandidateSummary
+	for _, candidate := range scoredCandidates {
+		summaries = append(summaries, CandidateSummary{
+			TagName: candidate.element.TagName,
+			ID:      candidate.element.ID(),
+			Class:   candidate.element.ClassName(),
+			Score:   candidate.score,
+		})
+	}
+	return summaries
+}
+
+// findMainCandidates implements FindMainCandidatesWithDepth for a given set of tags to score.
+//
+// Parameters:
+//   - doc: The parsed HTML document
+//   - nbTopCandidates: The number of top candidates to return
+//   - ancestorDepth: The number of ancestor levels that receive score (at least 1)
+//   - tagsToScore: The lowercase tag names of the elements whose text is scored
+//   - patterns: The class/ID patterns used to weigh and demote elements
+//
+// Returns:
+//   - The top N candidates with their scores, sorted by score in descending order.
+//     A single <article> or <main> element, or the body when nothing was scored, is
+//     returned alone with a score of 0.
+func findMainCandidates(doc *dom.VDocument, nbTopCandidates int, ancestorDepth int, tagsToScore []string, patterns classPatterns) []scoredCandidate {
+	if !hasBody(doc) {
+		return nil
+	}
+
 	// Use default value if nbTopCandidates is not provided
 	if nbTopCandidates <= 0 {
 		nbTopCandidates = util.DefaultNTopCandidates
 	}
+	if ancestorDepth < 1 {
+		ancestorDepth = util.DefaultAncestorDepth
+	}
 
 	// 1. First, look for semantic tags (simple method)
 	semanticTags := []string{"article", "main"}
@@ -366,7 +826,7 @@ func FindMainCandidates(doc *dom.VDocument, nbTopCandidates int) []*dom.VElement
 		elements := GetElementsByTagName(doc.DocumentElement, tag)
 		if len(elements) == 1 {
 			// If a single semantic tag is found, return it as the only candidate
-			return []*dom.VElement{elements[0]}
+			return []scoredCandidate{{element: elements[0]}}
 		}
 	}
 
@@ -376,56 +836,40 @@ func FindMainCandidates(doc *dom.VDocument, nbTopCandidates int) []*dom.VElement
 	elementsToScore := []*dom.VElement{}
 
 	// Collect elements to score
-	for _, tag := range util.DefaultTagsToScore {
+	for _, tag := range tagsToScore {
 		elements := GetElementsByTagName(body, tag)
 		elementsToScore = append(elementsToScore, elements...)
 	}
 
-	// Score each element
-	for _, elementToScore := range elementsToScore {
-		// Ignore elements with less than 25 characters
-		innerText := GetInnerText(elementToScore, false)
-		if len(innerText) < 25 {
+	// Score each element: measure the texts (in parallel on large pages), then add the
+	// scores to the ancestors sequentially, as they share ReadabilityData
+	measurements := measureElements(elementsToScore, patterns)
+	for i, elementToScore := range elementsToScore {
+		contentScore, ok := measurements[i].score, measurements[i].ok
+		if !ok {
 			continue
 		}
 
-		// Get ancestor elements (up to 3 levels)
-		ancestors := GetNodeAncestors(elementToScore, 3)
+		// Get ancestor elements (up to ancestorDepth levels)
+		ancestors := GetNodeAncestors(elementToScore, ancestorDepth)
 		if len(ancestors) == 0 {
 			continue
 		}
 
-		// Calculate base score
-		contentScore := 1.0                                                            // Base points
-		contentScore += float64(len(util.Regexps.Commas.FindAllString(innerText, -1))) // Number of commas
-		contentScore += float64(min(len(innerText)/100, 3))                            // Text length (max 3 points)
-
 		// Add score to ancestor elements
 		for level, ancestor := range ancestors {
 			if ancestor.GetReadabilityData() == nil {
-				InitializeNode(ancestor)
+				initializeNode(ancestor, patterns)
 				candidates = append(candidates, ancestor)
 			}
 
-			// Decrease score for deeper levels
-			scoreDivider := 1
-			if level == 1 {
-				scoreDivider = 2
-			} else if level >= 2 {
-				scoreDivider = level * 3
-			}
-
 			if ancestor.GetReadabilityData() != nil {
-				ancestor.GetReadabilityData().ContentScore += contentScore / float64(scoreDivider)
+				ancestor.GetReadabilityData().ContentScore += contentScore / scoreDivider(level, ancestorDepth)
 			}
 		}
 	}
 
 	// Score and select candidates
-	type scoredCandidate struct {
-		element *dom.VElement
-		score   float64
-	}
 	scoredCandidates := []scoredCandidate{}
 
 	for _, candidate := range candidates {
@@ -484,19 +928,46 @@ func FindMainCandidates(doc *dom.VDocument, nbTopCandidates int) []*dom.VElement
 	}
 
 	// Return top N candidates
-	topCandidates := []*dom.VElement{}
-	for i := 0; i < min(len(scoredCandidates), nbTopCandidates); i++ {
-		topCandidates = append(topCandidates, scoredCandidates[i].element)
-	}
+	topCandidates := scoredCandidates[:min(len(scoredCandidates), nbTopCandidates)]
 
 	// Return body if no candidate is found and body exists
 	if len(topCandidates) == 0 && doc.Body != nil {
-		return []*dom.VElement{doc.Body}
+		return []scoredCandidate{{element: doc.Body}}
 	}
 
 	return topCandidates
 }
 
+// defaultScoreDividers is the divider schedule of the default ancestor depth: the parent
+// gets the full score, the grandparent half, and the great-grandparent a sixth.
+var defaultScoreDividers = []float64{1, 2, 6}
+
+// scoreDivider returns the divider applied to an element's score when it is added
+// to the ancestor at the given level, so that deeper ancestors receive less.
+// The default schedule (1, 2, 6) is stretched or compressed over the configured depth:
+// the parent always gets the full score, the deepest ancestor a sixth, and the levels
+// in between are interpolated. With the default depth of 3 the schedule is unchanged.
+//
+// Parameters:
+//   - level: The ancestor level, starting at 0 for the parent
+//   - ancestorDepth: The number of ancestor levels that receive score
+//
+// Returns:
+//   - The divider for the score
+func scoreDivider(level int, ancestorDepth int) float64 {
+	if level <= 0 || ancestorDepth <= 1 {
+		return defaultScoreDividers[0]
+	}
+	last := len(defaultScoreDividers) - 1
+	position := float64(level*last) / float64(ancestorDepth-1)
+	index := int(position)
+	if index >= last {
+		return defaultScoreDividers[last]
+	}
+	fraction := position - float64(index)
+	return defaultScoreDividers[index] + fraction*(defaultScoreDividers[index+1]-defaultScoreDividers[index])
+}
+
 // IsProbablyContent determines content probability (simplified version similar to isProbablyReaderable).
 // It checks various properties of an element to determine if it's likely to contain
 // meaningful content, including visibility, class/ID patterns, text length, and link density.
@@ -507,18 +978,36 @@ func FindMainCandidates(doc *dom.VDocument, nbTopCandidates int) []*dom.VElement
 // Returns:
 //   - true if the element is likely to contain meaningful content, false otherwise
 func IsProbablyContent(element *dom.VElement) bool {
+	return isProbablyContent(element, defaultClassPatterns)
+}
+
+// IsProbablyContentWithOptions determines content probability like IsProbablyContent, with
+// the extra unlikely and likely candidate patterns of options merged into the defaults.
+//
+// Parameters:
+//   - element: The element to evaluate
+//   - options: The options holding the extra patterns
+//
+// Returns:
+//   - true if the element is likely to contain meaningful content, false otherwise
+//   - An error wrapping ErrInvalidOptions if an extra pattern is not a valid regular expression
+func IsProbablyContentWithOptions(element *dom.VElement, options ReadabilityOptions) (bool, error) {
+	patterns, err := newClassPatterns(options)
+	if err != nil {
+		return false, err
+	}
+	return isProbablyContent(element, patterns), nil
+}
+
+// isProbablyContent implements IsProbablyContent with the given class/ID patterns.
+func isProbablyContent(element *dom.VElement, patterns classPatterns) bool {
 	// Visibility check
 	if !IsProbablyVisible(element) {
 		return false
 	}
 
 	// Check class name and ID
-	className := element.ClassName()
-	id := element.ID()
-	matchString := className + " " + id
-
-	if util.Regexps.UnlikelyCandidates.MatchString(matchString) &&
-		!util.Regexps.OkMaybeItsACandidate.MatchString(matchString) {
+	if patterns.isUnlikely(element) {
 		return false
 	}
 
@@ -540,6 +1029,83 @@ func IsProbablyContent(element *dom.VElement) bool {
 	return textDensity >= 0.1
 }
 
+// parallelScoringThreshold is the number of elements to score from which their texts
+// are measured in parallel. Smaller pages are measured on a single goroutine.
+const parallelScoringThreshold = 512
+
+// elementMeasurement is the base content score of an element to score.
+type elementMeasurement struct {
+	score float64 // Base score from the element's text
+	ok    bool    // false if the element is not scored (too short or demoted)
+}
+
+// measureElement computes the base content score of an element from its text:
+// one point, plus one per comma, plus one per 100 characters (at most 3).
+// It only reads the document, so it can run concurrently.
+//
+// Parameters:
+//   - element: The element to measure
+//   - patterns: The class/ID patterns used to skip demoted content
+//
+// Returns:
+//   - The measurement of the element
+func measureElement(element *dom.VElement, patterns classPatterns) elementMeasurement {
+	// Ignore elements with less than 25 characters
+	innerText := GetInnerText(element, false)
+	if len(innerText) < 25 {
+		return elementMeasurement{}
+	}
+
+	// Ignore content demoted by the extra unlikely patterns
+	if patterns.inExtraUnlikely(element) {
+		return elementMeasurement{}
+	}
+
+	// Calculate base score
+	contentScore := 1.0                                                            // Base points
+	contentScore += float64(len(util.Regexps.Commas.FindAllString(innerText, -1))) // Number of commas
+	contentScore += float64(min(len(innerText)/100, 3))                            // Text length (max 3 points)
+	return elementMeasurement{score: contentScore, ok: true}
+}
+
+// measureElements measures the elements to score. From parallelScoringThreshold elements on,
+// the work is spread over a pool of GOMAXPROCS goroutines.
+//
+// Parameters:
+//   - elements: The elements to measure
+//   - patterns: The class/ID patterns used to skip demoted content
+//
+// Returns:
+//   - The measurements, in the order of elements
+func measureElements(elements []*dom.VElement, patterns classPatterns) []elementMeasurement {
+	measurements := make([]elementMeasurement, len(elements))
+	workers := runtime.GOMAXPROCS(0)
+	if len(elements) < parallelScoringThreshold || workers < 2 {
+		for i, element := range elements {
+			measurements[i] = measureElement(element, patterns)
+		}
+		return measurements
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				measurements[i] = measureElement(elements[i], patterns)
+			}
+		}()
+	}
+	for i := range elements {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return measurements
+}
+
 // InitializeNode initializes a node with a readability score.
 // It sets an initial score based on the tag name and adjusts it based on class name and ID.
 // This is a key part of the content scoring algorithm, establishing baseline scores
@@ -548,25 +1114,25 @@ func IsProbablyContent(element *dom.VElement) bool {
 // Parameters:
 //   - node: The element to initialize with a readability score
 func InitializeNode(node *dom.VElement) {
+	initializeNode(node, defaultClassPatterns)
+}
+
+// initializeNode implements InitializeNode with the given class/ID patterns and tag scores.
+//
+// Parameters:
+//   - node: The element to initialize with a readability score
+//   - patterns: The tag scores and the patterns used for the class/ID score adjustment
+func initializeNode(node *dom.VElement, patterns classPatterns) {
 	// Create a new ReadabilityData with initial score of 0
 	node.SetReadabilityData(&dom.ReadabilityData{
 		ContentScore: 0,
 	})
 
 	// Initial score based on tag name (case-insensitive)
-	switch strings.ToLower(node.TagName) {
-	case "div":
-		node.GetReadabilityData().ContentScore += 5
-	case "pre", "td", "blockquote":
-		node.GetReadabilityData().ContentScore += 3
-	case "address", "ol", "ul", "dl", "dd", "dt", "li", "form":
-		node.GetReadabilityData().ContentScore -= 3
-	case "h1", "h2", "h3", "h4", "h5", "h6", "th":
-		node.GetReadabilityData().ContentScore -= 5
-	}
+	node.GetReadabilityData().ContentScore += patterns.tagScores[strings.ToLower(node.TagName)]
 
 	// Score adjustment based on class name and ID
-	node.GetReadabilityData().ContentScore += GetClassWeight(node)
+	node.GetReadabilityData().ContentScore += patterns.classWeight(node)
 }
 
 // CreateExtractor creates a custom extractor function with specific options.
@@ -596,29 +1162,24 @@ func CreateExtractor(options ReadabilityOptions) func(string) (ReadabilityArticl
 // Returns:
 //   - A float64 score adjustment (positive for likely content, negative for likely noise)
 func GetClassWeight(node *dom.VElement) float64 {
-	var weight float64 = 0
-
-	// Check class name
-	className := node.ClassName()
-	if className != "" {
-		if util.Regexps.Negative.MatchString(className) {
-			weight -= 25
-		}
-		if util.Regexps.Positive.MatchString(className) {
-			weight += 25
-		}
-	}
+	return defaultClassPatterns.classWeight(node)
+}
 
-	// Check ID
-	id := node.ID()
-	if id != "" {
-		if util.Regexps.Negative.MatchString(id) {
-			weight -= 25
-		}
-		if util.Regexps.Positive.MatchString(id) {
-			weight += 25
-		}
+// GetClassWeightWithOptions calculates the class/ID score adjustment of an element like
+// GetClassWeight, with the extra positive and negative patterns of options merged into
+// the defaults, as used by Extract.
+//
+// Parameters:
+//   - node: The element to calculate a class weight for
+//   - options: The options holding the extra patterns
+//
+// Returns:
+//   - A float64 score adjustment (positive for likely content, negative for likely noise)
+//   - An error wrapping ErrInvalidOptions if an extra pattern is not a valid regular expression
+func GetClassWeightWithOptions(node *dom.VElement, options ReadabilityOptions) (float64, error) {
+	patterns, err := newClassPatterns(options)
+	if err != nil {
+		return 0, err
 	}
-
-	return weight
+	return patterns.classWeight(node), nil
 }
@@ -1,7 +1,15 @@
 package readability
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"slices"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/mackee/go-readability/internal/dom"
@@ -153,6 +161,85 @@ func TestFindMainCandidates(t *testing.T) {
 	}
 }
 
+func TestFindMainCandidatesWithDepth(t *testing.T) {
+	// Content split into many groups, each paragraph nested three levels below the container
+	setupDoc := func() *dom.VDocument {
+		html := dom.NewVElement("html")
+		body := dom.NewVElement("body")
+		html.AppendChild(body)
+
+		container := dom.NewVElement("div")
+		container.SetAttribute("id", "content")
+		body.AppendChild(container)
+
+		for i := 0; i < 12; i++ {
+			parent := container
+			for level := 0; level < 3; level++ {
+				div := dom.NewVElement("div")
+				parent.AppendChild(div)
+				parent = div
+			}
+			p := dom.NewVElement("p")
+			p.AppendChild(dom.NewVText("This is a paragraph with enough text to be considered. It has commas, and more text."))
+			parent.AppendChild(p)
+		}
+
+		return dom.NewVDocument(html, body)
+	}
+
+	testCases := []struct {
+		name          string
+		ancestorDepth int
+		expectContent bool
+	}{
+		{name: "default depth stops below the container", ancestorDepth: 3, expectContent: false},
+		{name: "invalid depth falls back to the default", ancestorDepth: 0, expectContent: false},
+		{name: "increased depth reaches the container", ancestorDepth: 4, expectContent: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			candidates := FindMainCandidatesWithDepth(setupDoc(), 5, tc.ancestorDepth)
+			if len(candidates) == 0 {
+				t.Fatal("Expected at least one candidate")
+			}
+			if isContent := candidates[0].ID() == "content"; isContent != tc.expectContent {
+				t.Errorf("Expected content container selected = %v, got <%s id=%q>",
+					tc.expectContent, candidates[0].TagName, candidates[0].ID())
+			}
+		})
+	}
+}
+
+func TestScoreDivider(t *testing.T) {
+	testCases := []struct {
+		ancestorDepth int
+		expected      []float64
+	}{
+		{ancestorDepth: 1, expected: []float64{1}},
+		{ancestorDepth: 2, expected: []float64{1, 6}},
+		{ancestorDepth: 3, expected: []float64{1, 2, 6}},
+		{ancestorDepth: 5, expected: []float64{1, 1.5, 2, 4, 6}},
+		{ancestorDepth: 7, expected: []float64{1, 4.0 / 3, 5.0 / 3, 2, 10.0 / 3, 14.0 / 3, 6}},
+	}
+
+	for _, tc := range testCases {
+		for level, want := range tc.expected {
+			if got := scoreDivider(level, tc.ancestorDepth); math.Abs(got-want) > 1e-9 {
+				t.Errorf("scoreDivider(%d, %d) = %v, want %v", level, tc.ancestorDepth, got, want)
+			}
+		}
+	}
+}
+
+func TestExtractNegativeAncestorDepth(t *testing.T) {
+	options := DefaultOptions()
+	options.AncestorDepth = -1
+	if _, err := Extract("<html><body><p>Text</p></body></html>", options); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("Expected ErrInvalidOptions, got %v", err)
+	}
+}
+
 func TestIsProbablyContent(t *testing.T) {
 	// Test cases for IsProbablyContent
 	testCases := []struct {
@@ -339,6 +426,45 @@ func TestInitializeNode(t *testing.T) {
 	}
 }
 
+func TestInitializeNodeTagScores(t *testing.T) {
+	options := DefaultOptions()
+	options.TagScores = map[string]float64{"h1": 0, "blockquote": 10}
+	patterns, err := newClassPatterns(options)
+	if err != nil {
+		t.Fatalf("newClassPatterns failed: %v", err)
+	}
+
+	tests := []struct {
+		tagName       string
+		expectedScore float64
+	}{
+		{"h1", 0},          // overridden
+		{"blockquote", 10}, // overridden
+		{"h2", -5},         // default
+		{"div", 5},         // default
+		{"span", 0},        // not listed
+	}
+	for _, tt := range tests {
+		element := dom.NewVElement(tt.tagName)
+		initializeNode(element, patterns)
+		if score := element.GetReadabilityData().ContentScore; score != tt.expectedScore {
+			t.Errorf("<%s>: expected score %v, got %v", tt.tagName, tt.expectedScore, score)
+		}
+	}
+
+	// The defaults are not modified
+	element := dom.NewVElement("h1")
+	InitializeNode(element)
+	if score := element.GetReadabilityData().ContentScore; score != -5 {
+		t.Errorf("Expected default <h1> score -5, got %v", score)
+	}
+
+	options.TagScores = map[string]float64{"H1": 0}
+	if _, err := Extract("<html><body><p>Text</p></body></html>", options); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("Expected ErrInvalidOptions for an uppercase key, got %v", err)
+	}
+}
+
 func TestGetClassWeight(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -412,6 +538,53 @@ func TestGetClassWeight(t *testing.T) {
 	}
 }
 
+func TestClassWeightWithOptions(t *testing.T) {
+	kiji := dom.NewVElement("div")
+	kiji.SetAttribute("class", "kiji")
+	ad := dom.NewVElement("div")
+	ad.SetAttribute("class", "pr-box")
+
+	options := DefaultOptions()
+	options.ExtraPositivePatterns = []string{`^kiji$`}
+	options.ExtraNegativePatterns = []string{`^pr-box$`}
+
+	for _, tc := range []struct {
+		element  *dom.VElement
+		expected float64
+	}{
+		{kiji, 25},
+		{ad, -25},
+	} {
+		if weight := GetClassWeight(tc.element); weight != 0 {
+			t.Errorf("Expected GetClassWeight to ignore custom patterns, got %f", weight)
+		}
+		weight, err := GetClassWeightWithOptions(tc.element, options)
+		if err != nil {
+			t.Fatalf("GetClassWeightWithOptions failed: %v", err)
+		}
+		if weight != tc.expected {
+			t.Errorf("Expected weight %f for class %q, got %f", tc.expected, tc.element.ClassName(), weight)
+		}
+	}
+
+	// An extra unlikely pattern rules out content that passes the default checks
+	content := dom.NewVElement("div")
+	content.SetAttribute("class", "promo-teaser")
+	content.AppendChild(dom.NewVText(strings.Repeat("This paragraph holds enough text to count as content. ", 5)))
+	if !IsProbablyContent(content) {
+		t.Fatal("Expected the element to be content with the default patterns")
+	}
+	options.ExtraUnlikelyPatterns = []string{`promo-teaser`}
+	if ok, err := IsProbablyContentWithOptions(content, options); err != nil || ok {
+		t.Errorf("Expected the extra unlikely pattern to rule out the element, got %v (%v)", ok, err)
+	}
+
+	options.ExtraPositivePatterns = []string{"(unclosed"}
+	if _, err := GetClassWeightWithOptions(kiji, options); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("Expected ErrInvalidOptions for an invalid pattern, got %v", err)
+	}
+}
+
 func TestFindStructuralElements(t *testing.T) {
 	testCases := []struct {
 		name                   string
@@ -931,6 +1104,156 @@ func TestCreateExtractor(t *testing.T) {
 	}
 }
 
+func TestExtractWithoutContent(t *testing.T) {
+	inputs := map[string]string{
+		"empty string":       "",
+		"bare text fragment": "plain text",
+		"empty html element": "<html></html>",
+	}
+
+	for name, html := range inputs {
+		t.Run(name, func(t *testing.T) {
+			result, err := Extract(html, DefaultOptions())
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if result.Root != nil {
+				t.Errorf("Expected Root to be nil, got %s", result.Root.TagName)
+			}
+		})
+	}
+}
+
+func TestExtractContentWithoutBody(t *testing.T) {
+	testCases := []struct {
+		name string
+		doc  *dom.VDocument
+	}{
+		{name: "nil document", doc: nil},
+		{name: "empty document", doc: &dom.VDocument{}},
+		{name: "document without body", doc: &dom.VDocument{DocumentElement: dom.NewVElement("html")}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := ExtractContent(tc.doc, DefaultOptions())
+			if result.Root != nil || result.PageType != "" || result.Title != "" {
+				t.Errorf("Expected an empty article, got %+v", result)
+			}
+			if PreprocessDocument(tc.doc) != tc.doc {
+				t.Error("Expected PreprocessDocument to return the document unchanged")
+			}
+			if tree := BuildAriaTree(tc.doc); tree != nil {
+				t.Errorf("Expected no ARIA tree, got %+v", tree)
+			}
+			if pageType := ClassifyPageType(tc.doc, []*dom.VElement{dom.NewVElement("div")}, 0, ""); pageType != PageTypeOther {
+				t.Errorf("Expected page type 'other', got '%s'", pageType)
+			}
+		})
+	}
+}
+
+func TestExtractAllowShortContent(t *testing.T) {
+	// About 120 characters of text
+	shortArticle := `<html><head><title>Short</title></head><body>
+		<article><p>Shipped the new release today. It fixes the crash on startup and makes parsing about twice as fast.</p></article>
+	</body></html>`
+	shortDiv := `<html><head><title>Short</title></head><body>
+		<div class="sidebar"><p>Shipped the new release today. It fixes the crash on startup and makes parsing about twice as fast.</p></div>
+	</body></html>`
+	linkList := `<html><head><title>Links</title></head><body>
+		<article><p><a href="/a">First linked post title</a> <a href="/b">Second linked post title</a> <a href="/c">Third one</a></p></article>
+	</body></html>`
+
+	testCases := []struct {
+		name              string
+		html              string
+		allowShortContent bool
+		expectRoot        bool
+	}{
+		{name: "short article without option", html: shortArticle, allowShortContent: false, expectRoot: false},
+		{name: "short article with option", html: shortArticle, allowShortContent: true, expectRoot: true},
+		{name: "short non-semantic container", html: shortDiv, allowShortContent: true, expectRoot: false},
+		{name: "short article made of links", html: linkList, allowShortContent: true, expectRoot: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			options := DefaultOptions()
+			options.AllowShortContent = tc.allowShortContent
+
+			result, err := Extract(tc.html, options)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if (result.Root != nil) != tc.expectRoot {
+				t.Errorf("Expected content extracted = %v, got Root = %v", tc.expectRoot, result.Root)
+			}
+		})
+	}
+}
+
+// recordingHandler is a slog.Handler that keeps every record it receives.
+type recordingHandler struct {
+	records *[]slog.Record
+}
+
+func (h recordingHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h recordingHandler) Handle(_ context.Context, record slog.Record) error {
+	*h.records = append(*h.records, record)
+	return nil
+}
+
+func (h recordingHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+
+func (h recordingHandler) WithGroup(string) slog.Handler { return h }
+
+func TestExtractLogger(t *testing.T) {
+	html := `<html><head><title>Logging</title></head><body>
+		<nav><a href="/">Home</a></nav>
+		<div class="ad-banner">Buy now</div>
+		<article>` + strings.Repeat("<p>This paragraph has enough text, and commas, to be scored as content.</p>", 10) + `</article>
+	</body></html>`
+
+	var records []slog.Record
+	options := DefaultOptions()
+	options.Logger = slog.New(recordingHandler{records: &records})
+
+	if _, err := Extract(html, options); err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+
+	attrs := map[string]map[string]slog.Value{}
+	for _, record := range records {
+		if record.Level != slog.LevelDebug {
+			t.Errorf("Expected debug level for %q, got %v", record.Message, record.Level)
+		}
+		values := map[string]slog.Value{}
+		record.Attrs(func(attr slog.Attr) bool {
+			values[attr.Key] = attr.Value
+			return true
+		})
+		attrs[record.Message] = values
+	}
+
+	if count := attrs["removed unwanted tags"]["count"]; count.Int64() < 1 {
+		t.Errorf("Expected the nav to be reported as removed, got %v", count)
+	}
+	if count := attrs["removed ad elements"]["count"]; count.Int64() != 1 {
+		t.Errorf("Expected one removed ad element, got %v", count)
+	}
+	if tag := attrs["selected top candidate"]["tag"]; tag.String() != "article" {
+		t.Errorf("Expected the article to be the top candidate, got %v", tag)
+	}
+	if accepted := attrs["selected top candidate"]["accepted"]; !accepted.Bool() {
+		t.Error("Expected the top candidate to be accepted")
+	}
+	if pageType := attrs["decided page type"]["pageType"]; pageType.String() != string(PageTypeArticle) {
+		t.Errorf("Expected page type 'article', got %v", pageType)
+	}
+}
+
 func TestExtractContent(t *testing.T) {
 	testCases := []struct {
 		name        string
@@ -1439,3 +1762,420 @@ func TestAddSignificantElementsByClassOrId(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractTagsToScore(t *testing.T) {
+	quote := `<blockquote>"Readability is about finding the content, and dropping everything else," she said, again.</blockquote>`
+	html := `<html><head><title>Quotes</title></head><body>
+		<div id="quotes">` + strings.Repeat(quote, 12) + `</div>
+		<div id="notes">
+			<p>These notes have a couple of paragraphs with some text, and a comma or two.</p>
+			<p>They are shorter than the quotes, but they are the only scored paragraphs.</p>
+		</div>
+	</body></html>`
+
+	tests := []struct {
+		name        string
+		tagsToScore []string
+		expectedID  string
+	}{
+		{name: "default tags", tagsToScore: nil, expectedID: "notes"},
+		{name: "blockquote added", tagsToScore: append(DefaultTagsToScore(), "blockquote"), expectedID: "quotes"},
+		{name: "uppercase blockquote added", tagsToScore: append(DefaultTagsToScore(), "BlockQuote"), expectedID: "quotes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultOptions()
+			options.CharThreshold = 100
+			options.TagsToScore = tt.tagsToScore
+
+			article, err := Extract(html, options)
+			if err != nil {
+				t.Fatalf("Extract failed: %v", err)
+			}
+			if article.Root == nil {
+				t.Fatal("Expected content to be extracted")
+			}
+			if article.Root.ID() != tt.expectedID {
+				t.Errorf("Expected <div id=%q> to win, got <%s id=%q>", tt.expectedID, article.Root.TagName, article.Root.ID())
+			}
+		})
+	}
+
+	t.Run("invalid tag name", func(t *testing.T) {
+		options := DefaultOptions()
+		options.TagsToScore = []string{"p", "block quote"}
+		if _, err := Extract(html, options); !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("Expected ErrInvalidOptions, got %v", err)
+		}
+	})
+}
+
+func TestNormalizeTagsToScore(t *testing.T) {
+	tags, err := normalizeTagsToScore([]string{"p", "P", " BlockQuote ", "blockquote", "pre"})
+	if err != nil {
+		t.Fatalf("normalizeTagsToScore failed: %v", err)
+	}
+	if expected := []string{"p", "blockquote", "pre"}; !slices.Equal(tags, expected) {
+		t.Errorf("normalizeTagsToScore() = %v, want %v", tags, expected)
+	}
+
+	if _, err := normalizeTagsToScore([]string{"p", "h 2"}); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("Expected ErrInvalidOptions, got %v", err)
+	}
+}
+
+func TestExtractDuplicateTagsToScore(t *testing.T) {
+	html := `<html><body><div id="content">` +
+		strings.Repeat("<p>This paragraph has enough text to be scored, with a few commas, and some words.</p>", 6) +
+		`</div></body></html>`
+	score := func(tags []string) float64 {
+		options := DefaultOptions()
+		options.CharThreshold = 100
+		options.TagsToScore = tags
+		options.Debug = true
+		article, err := Extract(html, options)
+		if err != nil {
+			t.Fatalf("Extract failed: %v", err)
+		}
+		if len(article.Candidates) == 0 {
+			t.Fatal("Expected candidates to be reported")
+		}
+		return article.Candidates[0].Score
+	}
+
+	if single, duplicated := score([]string{"p"}), score([]string{"p", "P", "p"}); single != duplicated {
+		t.Errorf("Expected duplicate tags not to change the score, got %v and %v", single, duplicated)
+	}
+}
+
+func TestExtractExtraPatterns(t *testing.T) {
+	paragraph := "<p>This paragraph has enough text to be scored, with a few commas, and some words.</p>"
+	html := `<html><head><title>Patterns</title></head><body>
+		<div class="kiji">` + strings.Repeat(paragraph, 5) + `</div>
+		<div class="hako">` + strings.Repeat(paragraph, 7) + `</div>
+	</body></html>`
+
+	tests := []struct {
+		name          string
+		configure     func(*ReadabilityOptions)
+		expectedClass string
+	}{
+		{name: "defaults", configure: func(*ReadabilityOptions) {}, expectedClass: "hako"},
+		{
+			name:          "extra positive pattern promotes a custom class",
+			configure:     func(o *ReadabilityOptions) { o.ExtraPositivePatterns = []string{`^kiji$`} },
+			expectedClass: "kiji",
+		},
+		{
+			name:          "extra negative pattern demotes a custom class",
+			configure:     func(o *ReadabilityOptions) { o.ExtraNegativePatterns = []string{`hako`} },
+			expectedClass: "kiji",
+		},
+		{
+			name:          "extra unlikely pattern demotes a custom class",
+			configure:     func(o *ReadabilityOptions) { o.ExtraUnlikelyPatterns = []string{`hako`} },
+			expectedClass: "kiji",
+		},
+		{
+			name: "extra likely pattern overrides an unlikely one",
+			configure: func(o *ReadabilityOptions) {
+				o.ExtraUnlikelyPatterns = []string{`hako`}
+				o.ExtraLikelyPatterns = []string{`hako`}
+			},
+			expectedClass: "hako",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultOptions()
+			options.CharThreshold = 100
+			tt.configure(&options)
+
+			article, err := Extract(html, options)
+			if err != nil {
+				t.Fatalf("Extract failed: %v", err)
+			}
+			if article.Root == nil {
+				t.Fatal("Expected content to be extracted")
+			}
+			if className := article.Root.ClassName(); className != tt.expectedClass {
+				t.Errorf("Expected <div class=%q> to win, got <%s class=%q>", tt.expectedClass, article.Root.TagName, className)
+			}
+		})
+	}
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		options := DefaultOptions()
+		options.ExtraPositivePatterns = []string{"(unclosed"}
+		if _, err := Extract(html, options); !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("Expected ErrInvalidOptions, got %v", err)
+		}
+	})
+}
+
+func TestExtractIncludeRawHTML(t *testing.T) {
+	html := `<html><head><title>Raw</title></head><body><article class="post-body" data-id="42">` +
+		strings.Repeat(`<p class="lead">Readability extracts the main content of a page and drops
+			navigation, advertisements and other clutter from it.</p>`, 5) +
+		`<p>Copyright © 2024 Example Corp. All rights reserved.</p></article></body></html>`
+
+	options := DefaultOptions()
+	options.CharThreshold = 100
+
+	article, err := Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if article.RawHTML != "" {
+		t.Errorf("Expected no RawHTML by default, got %q", article.RawHTML)
+	}
+
+	options.IncludeRawHTML = true
+	options.TrimBoilerplate = true
+	article, err = Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if !strings.Contains(article.RawHTML, `class="lead"`) || !strings.Contains(article.RawHTML, `data-id="42"`) {
+		t.Errorf("Expected attributes to survive in RawHTML, got %q", article.RawHTML)
+	}
+	if cleaned := ToHTML(article.Root); strings.Contains(cleaned, `class="lead"`) {
+		t.Errorf("Expected classes to be stripped by ToHTML, got %q", cleaned)
+	}
+	// RawHTML is taken before postprocessing options change Root
+	if !strings.Contains(article.RawHTML, "All rights reserved") {
+		t.Errorf("Expected RawHTML to keep the trimmed boilerplate, got %q", article.RawHTML)
+	}
+}
+
+// largeTestPage builds a page with enough paragraphs to be scored in parallel.
+func largeTestPage(paragraphs int) string {
+	var html strings.Builder
+	html.WriteString(`<html><head><title>Large</title></head><body>`)
+	for i := 0; i < paragraphs; i++ {
+		if i%20 == 0 {
+			if i > 0 {
+				html.WriteString(`</div>`)
+			}
+			fmt.Fprintf(&html, `<div class="section-%d">`, i/20)
+		}
+		fmt.Fprintf(&html, `<p>Paragraph %d of the page, with some commas, words, and enough text to be scored.</p>`, i)
+	}
+	html.WriteString(`</div><div class="comments"><p>A comment, short but long enough to count.</p></div></body></html>`)
+	return html.String()
+}
+
+// TestMeasureElementsParallel checks the parallel measurement against the sequential one.
+// CI also runs it with -race.
+func TestMeasureElementsParallel(t *testing.T) {
+	doc, err := ParseHTML(largeTestPage(2*parallelScoringThreshold), "")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+	patterns, err := newClassPatterns(ReadabilityOptions{ExtraUnlikelyPatterns: []string{"comments"}})
+	if err != nil {
+		t.Fatalf("Failed to build patterns: %v", err)
+	}
+
+	elements := GetElementsByTagName(doc.Body, "p")
+	if len(elements) < parallelScoringThreshold {
+		t.Fatalf("Expected at least %d elements, got %d", parallelScoringThreshold, len(elements))
+	}
+	measurements := measureElements(elements, patterns)
+	for i, element := range elements {
+		if expected := measureElement(element, patterns); measurements[i] != expected {
+			t.Fatalf("Element %d: expected %+v, got %+v", i, expected, measurements[i])
+		}
+	}
+	if measurements[len(measurements)-1].ok {
+		t.Error("Expected the demoted comment to be skipped")
+	}
+}
+
+func BenchmarkExtract(b *testing.B) {
+	fixture, err := os.ReadFile("testdata/fixtures/001/source.html")
+	if err != nil {
+		b.Fatalf("Failed to read fixture: %v", err)
+	}
+	pages := map[string]string{
+		"fixture": string(fixture),
+		"large":   largeTestPage(4 * parallelScoringThreshold),
+	}
+	for _, name := range []string{"fixture", "large"} {
+		b.Run(name, func(b *testing.B) {
+			for b.Loop() {
+				if _, err := Extract(pages[name], DefaultOptions()); err != nil {
+					b.Fatalf("Extract failed: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestMeasureElementJapaneseCommas(t *testing.T) {
+	withCommas := "今日は天気が良いので、公園まで散歩に行き、帰りに本屋へ寄りました｡途中で友人に会い､少し話をしました。"
+	withoutCommas := strings.NewReplacer("、", "の", "､", "の").Replace(withCommas)
+	if len(withCommas) != len(withoutCommas) {
+		t.Fatal("Expected the texts to have the same length")
+	}
+
+	html := `<html><body>` +
+		`<div id="plain">` + strings.Repeat("<p>"+withoutCommas+"</p>", 3) + `</div>` +
+		`<div id="prose">` + strings.Repeat("<p>"+withCommas+"</p>", 3) + `</div>` +
+		`</body></html>`
+	doc, err := ParseHTML(html, "")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	paragraphs := GetElementsByTagName(doc.Body, "p")
+	plain := measureElement(paragraphs[0], defaultClassPatterns)
+	prose := measureElement(paragraphs[3], defaultClassPatterns)
+	if prose.score-plain.score != 3 {
+		t.Errorf("Expected 3 points for the Japanese commas, got %v (with) and %v (without)", prose.score, plain.score)
+	}
+
+	candidates := FindMainCandidates(doc, 5)
+	if len(candidates) == 0 {
+		t.Fatal("Expected candidates")
+	}
+	if id := candidates[0].ID(); id != "prose" {
+		t.Errorf("Expected the text with commas to be the top candidate, got %q", id)
+	}
+}
+
+func TestFindStructuralElementsDocumentOrder(t *testing.T) {
+	paragraph := `<p>Some text that makes this part of the page significant enough to be listed, with a few more words.</p>`
+	html := `<html><body>` +
+		`<section id="first" class="content">` + paragraph + `</section>` +
+		`<aside id="second">` + paragraph + `</aside>` +
+		`<main id="third">` + paragraph + `</main>` +
+		`<section id="fourth" class="content">` + paragraph + `</section>` +
+		`<div id="fifth" class="post-content">` + paragraph + `</div>` +
+		`<article id="sixth">` + paragraph + `</article>` +
+		`</body></html>`
+
+	expected := "first second third fourth fifth sixth"
+	for range 5 {
+		doc, err := ParseHTML(html, "")
+		if err != nil {
+			t.Fatalf("Failed to parse HTML: %v", err)
+		}
+		_, _, nodes := FindStructuralElements(doc)
+		ids := make([]string, len(nodes))
+		for i, node := range nodes {
+			ids[i] = node.ID()
+		}
+		if actual := strings.Join(ids, " "); actual != expected {
+			t.Fatalf("Expected nodes in document order %q, got %q", expected, actual)
+		}
+	}
+}
+
+func TestFindStructuralElementsAriaLabelledBy(t *testing.T) {
+	paragraph := `<p>Some text that makes this part of the page significant enough to be listed, with a few more words.</p>`
+	html := `<html><body>` +
+		`<div id="story" role="region" aria-labelledby="story-title"><h2 id="story-title">Main story</h2>` + paragraph + `</div>` +
+		`<div id="unlabelled" role="region">` + paragraph + `</div>` +
+		`</body></html>`
+
+	doc, err := ParseHTML(html, "")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+	_, _, nodes := FindStructuralElements(doc)
+	ids := make([]string, len(nodes))
+	for i, node := range nodes {
+		ids[i] = node.ID()
+	}
+	if actual := strings.Join(ids, " "); actual != "story" {
+		t.Errorf("Expected only the labelled region, got %q", actual)
+	}
+
+	story := GetElementsByTagName(doc.Body, "div")[0]
+	if name := GetAccessibleName(story); name != "Main story" {
+		t.Errorf("Expected the name from aria-labelledby, got %q", name)
+	}
+	if node := BuildAriaNode(story); node.Type != AriaNodeTypeRegion || node.Name != "Main story" {
+		t.Errorf("Expected a region named from aria-labelledby in the ARIA tree, got %s %q", node.Type, node.Name)
+	}
+}
+
+func TestExtractDebugCandidates(t *testing.T) {
+	paragraph := `<p>Readability extracts the main content of a page, and drops navigation, advertisements, and other clutter from it.</p>`
+	html := `<html><head><title>Candidates</title></head><body>` +
+		`<div id="content" class="post-body">` + strings.Repeat(paragraph, 8) + `</div>` +
+		`<div id="teaser" class="promo">` + paragraph + `</div>` +
+		`<div id="related">` + strings.Repeat(`<p>Another story, <a href="/a">read</a>.</p>`, 3) + `</div>` +
+		`</body></html>`
+
+	options := DefaultOptions()
+	article, err := Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if article.Candidates != nil {
+		t.Errorf("Expected no candidates without Debug, got %+v", article.Candidates)
+	}
+
+	options.Debug = true
+	article, err = Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if len(article.Candidates) < 2 {
+		t.Fatalf("Expected several candidates, got %+v", article.Candidates)
+	}
+	if first := article.Candidates[0]; first.TagName != "div" || first.ID != "content" || first.Class != "post-body" {
+		t.Errorf("Expected the content div as the top candidate, got %+v", first)
+	}
+	for i := 1; i < len(article.Candidates); i++ {
+		if article.Candidates[i].Score > article.Candidates[i-1].Score {
+			t.Errorf("Expected candidates ordered by score, got %+v", article.Candidates)
+			break
+		}
+	}
+}
+
+func TestExtractScore(t *testing.T) {
+	rich := `<html><head><title>Rich</title></head><body><article>` +
+		strings.Repeat(`<p>Readability extracts the main content of a page, and drops navigation,
+			advertisements, and other clutter from it, so that the text can be read, indexed, or
+			summarized without the noise of the surrounding site. See <a href="/docs">the docs</a>.</p>`, 10) +
+		`</article></body></html>`
+	thin := `<html><head><title>Thin</title></head><body><article>` +
+		`<p>Only a short teaser of the story, <a href="/more">more</a>.</p>` +
+		`</article></body></html>`
+
+	options := DefaultOptions()
+	options.AllowShortContent = true
+
+	richArticle, err := Extract(rich, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	thinArticle, err := Extract(thin, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if richArticle.Root == nil || thinArticle.Root == nil {
+		t.Fatal("Expected content to be extracted from both pages")
+	}
+
+	if richArticle.Score < 0.8 || richArticle.Score > 1 {
+		t.Errorf("Expected a high score for the rich article, got %v", richArticle.Score)
+	}
+	if thinArticle.Score > 0.3 || thinArticle.Score < 0 {
+		t.Errorf("Expected a low score for the thin page, got %v", thinArticle.Score)
+	}
+
+	empty, err := Extract(`<html><body><p>Too short.</p></body></html>`, DefaultOptions())
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if empty.Score != 0 {
+		t.Errorf("Expected a score of 0 without content, got %v", empty.Score)
+	}
+}
@@ -0,0 +1,269 @@
+// Package readability provides functionality to extract readable content from HTML documents.
+// It implements an algorithm similar to Mozilla's Readability.js to identify and extract
+// the main content from web pages, removing clutter, navigation, ads, and other non-content elements.
+package readability
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mackee/go-readability/internal/dom"
+	"github.com/mackee/go-readability/internal/util"
+)
+
+// Regular expressions for published date extraction
+var (
+	// "3 days ago", "an hour ago", "about 5 mins ago"
+	relativeAgoRegex = regexp.MustCompile(`(?i)\b(\d+|an?|one)\s*(seconds?|secs?|minutes?|mins?|hours?|hrs?|days?|weeks?|wks?|months?|years?|yrs?)\s+ago\b`)
+	// "last week", "last month", "last year"
+	relativeLastRegex = regexp.MustCompile(`(?i)\blast\s+(week|month|year)\b`)
+	// "just now", "moments ago"
+	relativeNowRegex = regexp.MustCompile(`(?i)\b(?:just now|moments? ago|a moment ago)\b`)
+	// "today", "yesterday"
+	relativeDayRegex = regexp.MustCompile(`(?i)\b(today|yesterday)\b`)
+	// Class and ID names of elements that typically hold the publication date
+	dateContainerRegex = regexp.MustCompile(`(?i)byline|date|time|posted|published|meta|timestamp`)
+)
+
+// timeTextLayouts are the date formats accepted in the text of a <time> element
+// without a datetime attribute.
+var timeTextLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02",
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"Jan. 2, 2006",
+	"2 January 2006",
+	"2 Jan 2006",
+}
+
+// maxDateTextLength is the maximum text length of an element searched for a relative date.
+const maxDateTextLength = 100
+
+// dateMetaNames are the meta name/property/itemprop values holding the publication date,
+// in order of preference.
+var dateMetaNames = []string{
+	"article:published_time",
+	"og:published_time",
+	"datepublished",
+	"dc.date.issued",
+	"dc.date",
+	"dcterms.date",
+	"parsely-pub-date",
+	"sailthru.date",
+	"pubdate",
+	"publishdate",
+	"date",
+}
+
+// ParseRelativeTime parses an English relative time expression such as "2 hours ago",
+// "yesterday", or "last week" found in a text, relative to now.
+// Days ("today", "yesterday") resolve to midnight in now's location.
+//
+// Parameters:
+//   - text: The text containing the expression
+//   - now: The reference time
+//
+// Returns:
+//   - The resolved time
+//   - true if the text contains a relative time expression
+func ParseRelativeTime(text string, now time.Time) (time.Time, bool) {
+	if match := relativeAgoRegex.FindStringSubmatch(text); match != nil {
+		count := 1
+		if n, err := strconv.Atoi(match[1]); err == nil {
+			count = n
+		}
+		unit := strings.ToLower(match[2])
+		switch {
+		case strings.HasPrefix(unit, "s"):
+			return now.Add(-time.Duration(count) * time.Second), true
+		case strings.HasPrefix(unit, "mi"):
+			return now.Add(-time.Duration(count) * time.Minute), true
+		case strings.HasPrefix(unit, "h"):
+			return now.Add(-time.Duration(count) * time.Hour), true
+		case strings.HasPrefix(unit, "d"):
+			return now.AddDate(0, 0, -count), true
+		case strings.HasPrefix(unit, "w"):
+			return now.AddDate(0, 0, -7*count), true
+		case strings.HasPrefix(unit, "mo"):
+			return now.AddDate(0, -count, 0), true
+		case strings.HasPrefix(unit, "y"):
+			return now.AddDate(-count, 0, 0), true
+		}
+	}
+
+	if match := relativeLastRegex.FindStringSubmatch(text); match != nil {
+		switch strings.ToLower(match[1]) {
+		case "week":
+			return now.AddDate(0, 0, -7), true
+		case "month":
+			return now.AddDate(0, -1, 0), true
+		case "year":
+			return now.AddDate(-1, 0, 0), true
+		}
+	}
+
+	if relativeNowRegex.MatchString(text) {
+		return now, true
+	}
+
+	if match := relativeDayRegex.FindStringSubmatch(text); match != nil {
+		midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		if strings.EqualFold(match[1], "yesterday") {
+			return midnight.AddDate(0, 0, -1), true
+		}
+		return midnight, true
+	}
+
+	return time.Time{}, false
+}
+
+// GetPublishedTime finds the publication date of the article. It checks, in order,
+// date meta tags, JSON-LD and microdata datePublished, and <time> elements. As a last
+// resort, a relative date ("3 days ago") near the byline is resolved against now.
+//
+// Parameters:
+//   - doc: The parsed HTML document
+//   - now: The reference time for relative dates
+//
+// Returns:
+//   - The publication date as found in the document, or in RFC 3339 format when resolved
+//     from a relative date. An empty string if no date is found.
+func GetPublishedTime(doc *dom.VDocument, now time.Time) string {
+	if doc == nil || doc.DocumentElement == nil {
+		return ""
+	}
+
+	// 1. Meta tags (name, property, or itemprop)
+	if published := getMetaContent(doc, dateMetaNames...); published != "" {
+		return published
+	}
+
+	// 2. JSON-LD and microdata
+	if published := GetJSONLD(doc).PublishedTime; published != "" {
+		return published
+	}
+	if published := GetMicrodata(doc).PublishedTime; published != "" {
+		return published
+	}
+
+	if !hasBody(doc) {
+		return ""
+	}
+
+	// 3. <time> elements, preferring the earliest one near the byline
+	timeElements := GetElementsByTagName(doc.Body, "time")
+	if published := findTimeElementDate(timeElements); published != "" {
+		return published
+	}
+
+	// 4. Relative dates in <time> elements and date-like containers
+	candidates := timeElements
+	for _, element := range GetElementsByTagName(doc.Body, "*") {
+		if dateContainerRegex.MatchString(element.ClassName() + " " + element.ID()) {
+			candidates = append(candidates, element)
+		}
+	}
+	for _, element := range candidates {
+		text := GetInnerText(element, true)
+		if text == "" || utf8.RuneCountInString(text) > maxDateTextLength {
+			continue
+		}
+		if published, ok := ParseRelativeTime(text, now); ok {
+			return published.Format(time.RFC3339)
+		}
+	}
+	return ""
+}
+
+// parseTimeValue parses a date as found in a datetime attribute or the text of a <time> element.
+//
+// Parameters:
+//   - value: The date to parse
+//
+// Returns:
+//   - The parsed time
+//   - true if the value matches one of timeTextLayouts
+func parseTimeValue(value string) (time.Time, bool) {
+	value = strings.Join(strings.Fields(value), " ")
+	for _, layout := range timeTextLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// isNearByline checks whether an element sits in the byline or header of an article:
+// in a byline or date container, or in a <header> or <address> element.
+//
+// Parameters:
+//   - element: The element to check
+//
+// Returns:
+//   - true if the element or one of its close ancestors is a byline or header
+func isNearByline(element *dom.VElement) bool {
+	for depth, ancestor := 0, element.Parent(); ancestor != nil && depth < 3; depth, ancestor = depth+1, ancestor.Parent() {
+		if ancestor.TagName == "header" || ancestor.TagName == "address" ||
+			util.Regexps.Byline.MatchString(ancestor.ClassName()+" "+ancestor.ID()) ||
+			dateContainerRegex.MatchString(ancestor.ClassName()+" "+ancestor.ID()) ||
+			ancestor.GetAttribute("rel") == "author" || ancestor.GetAttribute("itemprop") == "author" {
+			return true
+		}
+	}
+	return false
+}
+
+// findTimeElementDate picks the publication date among <time> elements. The value of
+// an element is its datetime attribute, or its text if that parses as a date. Elements
+// near the byline (see isNearByline) are preferred, and among them the earliest date
+// wins, since a later one is usually the date of an update. Otherwise the first element
+// in document order is used.
+//
+// Parameters:
+//   - timeElements: The <time> elements in document order
+//
+// Returns:
+//   - The date as found in the document, or an empty string if no element has a date
+func findTimeElementDate(timeElements []*dom.VElement) string {
+	first := ""
+	nearest := ""
+	var earliest time.Time
+	for _, element := range timeElements {
+		value := strings.TrimSpace(element.GetAttribute("datetime"))
+		if value == "" {
+			text := GetInnerText(element, true)
+			if _, ok := parseTimeValue(text); !ok {
+				continue
+			}
+			value = text
+		}
+		if first == "" {
+			first = value
+		}
+		if !isNearByline(element) {
+			continue
+		}
+		parsed, ok := parseTimeValue(value)
+		switch {
+		case nearest == "":
+			nearest = value
+			if ok {
+				earliest = parsed
+			}
+		case ok && (earliest.IsZero() || parsed.Before(earliest)):
+			nearest = value
+			earliest = parsed
+		}
+	}
+	if nearest != "" {
+		return nearest
+	}
+	return first
+}
@@ -0,0 +1,127 @@
+package readability
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRelativeTime(t *testing.T) {
+	now := time.Date(2024, time.March, 15, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		text     string
+		expected time.Time
+		ok       bool
+	}{
+		{"2 hours ago", now.Add(-2 * time.Hour), true},
+		{"Posted 3 days ago by Jane", time.Date(2024, time.March, 12, 14, 30, 0, 0, time.UTC), true},
+		{"an hour ago", now.Add(-time.Hour), true},
+		{"about 5 mins ago", now.Add(-5 * time.Minute), true},
+		{"30 seconds ago", now.Add(-30 * time.Second), true},
+		{"1 week ago", time.Date(2024, time.March, 8, 14, 30, 0, 0, time.UTC), true},
+		{"2 months ago", time.Date(2024, time.January, 15, 14, 30, 0, 0, time.UTC), true},
+		{"a year ago", time.Date(2023, time.March, 15, 14, 30, 0, 0, time.UTC), true},
+		{"last week", time.Date(2024, time.March, 8, 14, 30, 0, 0, time.UTC), true},
+		{"Updated last month", time.Date(2024, time.February, 15, 14, 30, 0, 0, time.UTC), true},
+		{"yesterday", time.Date(2024, time.March, 14, 0, 0, 0, 0, time.UTC), true},
+		{"Today", time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC), true},
+		{"just now", now, true},
+		{"March 3, 2024", time.Time{}, false},
+		{"ago", time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			actual, ok := ParseRelativeTime(tt.text, now)
+			if ok != tt.ok {
+				t.Fatalf("Expected ok=%v, got %v", tt.ok, ok)
+			}
+			if !actual.Equal(tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestGetPublishedTime(t *testing.T) {
+	now := time.Date(2024, time.March, 15, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		html     string
+		expected string
+	}{
+		{
+			name:     "meta tag",
+			html:     `<html><head><meta property="article:published_time" content="2024-01-02T03:04:05Z"></head><body><p class="date">2 days ago</p></body></html>`,
+			expected: "2024-01-02T03:04:05Z",
+		},
+		{
+			name: "JSON-LD",
+			html: `<html><head><script type="application/ld+json">{"@context":"https://schema.org","@type":"NewsArticle","datePublished":"2024-02-03"}</script></head>` +
+				`<body><p class="date">2 days ago</p></body></html>`,
+			expected: "2024-02-03",
+		},
+		{
+			name:     "time element",
+			html:     `<html><body><p>By Jane <time datetime="2024-03-01">March 1</time></p></body></html>`,
+			expected: "2024-03-01",
+		},
+		{
+			name: "earliest time element near the byline",
+			html: `<html><body><header><p class="byline">By Jane · Updated <time datetime="2024-03-10">March 10</time>` +
+				` · Published <time datetime="2024-03-01">March 1</time></p></header>` +
+				`<p>Released <time datetime="2023-12-24">last Christmas</time>.</p></body></html>`,
+			expected: "2024-03-01",
+		},
+		{
+			name:     "time element text",
+			html:     `<html><body><div class="meta"><time>March 2, 2024</time></div><p>Article text.</p></body></html>`,
+			expected: "March 2, 2024",
+		},
+		{
+			name:     "time element without a date",
+			html:     `<html><body><p>Open from <time>9:00</time>.</p></body></html>`,
+			expected: "",
+		},
+		{
+			name:     "relative date near the byline",
+			html:     `<html><body><div class="byline">By Jane Doe · 3 hours ago</div><p>Article text.</p></body></html>`,
+			expected: "2024-03-15T11:30:00Z",
+		},
+		{
+			name:     "relative date in article text is ignored",
+			html:     `<html><body><p>The company announced the change 3 days ago, in a statement.</p></body></html>`,
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := ParseHTML(tt.html, "")
+			if err != nil {
+				t.Fatalf("Failed to parse HTML: %v", err)
+			}
+			if actual := GetPublishedTime(doc, now); actual != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestExtractPublishedTime(t *testing.T) {
+	html := `<html><head><title>Dates</title></head><body><header><span class="posted-on">Yesterday</span></header><article>` +
+		`<p>Readability extracts the main content of a page and drops navigation, advertisements and other clutter from it.</p>` +
+		`</article></body></html>`
+
+	options := DefaultOptions()
+	options.Now = time.Date(2024, time.March, 15, 14, 30, 0, 0, time.UTC)
+
+	article, err := Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if article.PublishedTime != "2024-03-14T00:00:00Z" {
+		t.Errorf("Expected the relative date in the header to be resolved, got %q", article.PublishedTime)
+	}
+}
@@ -0,0 +1,105 @@
+// Package readability provides functionality to extract readable content from HTML documents.
+// It implements an algorithm similar to Mozilla's Readability.js to identify and extract
+// the main content from web pages, removing clutter, navigation, ads, and other non-content elements.
+package readability
+
+// FieldChange is a metadata field whose value differs between two extractions.
+type FieldChange struct {
+	Field  string `json:"field"`  // Name of the field: "title", "byline", or "pageType"
+	Before string `json:"before"` // Value in the first article
+	After  string `json:"after"`  // Value in the second article
+}
+
+// ArticleDiff describes how a second extraction differs from a first one.
+type ArticleDiff struct {
+	Changes []FieldChange `json:"changes"` // Title, byline, and page type differences
+	Removed []string      `json:"removed"` // Text lines of the first article missing from the second, in order
+	Added   []string      `json:"added"`   // Text lines of the second article missing from the first, in order
+}
+
+// Equal reports whether the two extractions have the same metadata and text.
+//
+// Returns:
+//   - true if the diff has no changes, removed lines, or added lines
+func (d ArticleDiff) Equal() bool {
+	return len(d.Changes) == 0 && len(d.Removed) == 0 && len(d.Added) == 0
+}
+
+// CompareArticles compares two extractions, e.g. of the same page before and after
+// a change to the heuristics. The title, byline, and page type are compared as is. The
+// content is compared as text lines, one per block with whitespace collapsed, so that
+// changes in markup alone are not reported.
+//
+// Parameters:
+//   - a: The first (reference) article
+//   - b: The second article
+//
+// Returns:
+//   - The differences of b from a
+func CompareArticles(a, b ReadabilityArticle) ArticleDiff {
+	var diff ArticleDiff
+	for _, field := range []FieldChange{
+		{Field: "title", Before: a.Title, After: b.Title},
+		{Field: "byline", Before: a.Byline, After: b.Byline},
+		{Field: "pageType", Before: string(a.PageType), After: string(b.PageType)},
+	} {
+		if field.Before != field.After {
+			diff.Changes = append(diff.Changes, field)
+		}
+	}
+
+	var linesA, linesB []string
+	if a.Root != nil {
+		linesA = collectTextBlocks(a.Root)
+	}
+	if b.Root != nil {
+		linesB = collectTextBlocks(b.Root)
+	}
+	diff.Removed, diff.Added = diffLines(linesA, linesB)
+	return diff
+}
+
+// diffLines compares two sequences of lines through their longest common subsequence.
+//
+// Parameters:
+//   - a: The first lines
+//   - b: The second lines
+//
+// Returns:
+//   - The lines of a that are not in the common subsequence, in order
+//   - The lines of b that are not in the common subsequence, in order
+func diffLines(a, b []string) ([]string, []string) {
+	// lengths[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	var removed, added []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			removed = append(removed, a[i])
+			i++
+		default:
+			added = append(added, b[j])
+			j++
+		}
+	}
+	removed = append(removed, a[i:]...)
+	added = append(added, b[j:]...)
+	return removed, added
+}
@@ -0,0 +1,53 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompareArticles(t *testing.T) {
+	before := articleFromHTML(t, `<div><p>First paragraph.</p><p>Second paragraph.</p><p>Third paragraph.</p></div>`)
+	before.Title = "Title"
+	before.PageType = PageTypeArticle
+
+	t.Run("identical articles", func(t *testing.T) {
+		restyled := articleFromHTML(t, `<div class="post"><p>First   paragraph.</p><section><p>Second <b>paragraph.</b></p></section><p>Third paragraph.</p></div>`)
+		restyled.Title = "Title"
+		restyled.PageType = PageTypeArticle
+
+		diff := CompareArticles(before, restyled)
+		if !diff.Equal() {
+			t.Errorf("Expected no differences for the same text in different markup, got %+v", diff)
+		}
+	})
+
+	t.Run("differing articles", func(t *testing.T) {
+		after := articleFromHTML(t, `<div><p>First paragraph.</p><p>Third paragraph.</p><p>Comment from a reader.</p></div>`)
+		after.Title = "Title - Site"
+		after.PageType = PageTypeArticle
+
+		diff := CompareArticles(before, after)
+		if diff.Equal() {
+			t.Fatal("Expected differences")
+		}
+		if len(diff.Changes) != 1 || diff.Changes[0] != (FieldChange{Field: "title", Before: "Title", After: "Title - Site"}) {
+			t.Errorf("Expected only the title to change, got %+v", diff.Changes)
+		}
+		if strings.Join(diff.Removed, "|") != "Second paragraph." {
+			t.Errorf("Expected the second paragraph to be removed, got %q", diff.Removed)
+		}
+		if strings.Join(diff.Added, "|") != "Comment from a reader." {
+			t.Errorf("Expected the comment to be added, got %q", diff.Added)
+		}
+	})
+
+	t.Run("content lost", func(t *testing.T) {
+		diff := CompareArticles(before, ReadabilityArticle{Title: "Title", PageType: PageTypeOther})
+		if len(diff.Removed) != 3 || len(diff.Added) != 0 {
+			t.Errorf("Expected every line to be removed, got %+v", diff)
+		}
+		if len(diff.Changes) != 1 || diff.Changes[0].Field != "pageType" {
+			t.Errorf("Expected the page type to change, got %+v", diff.Changes)
+		}
+	})
+}
@@ -0,0 +1,271 @@
+// Package readability provides functionality to extract readable content from HTML documents.
+// It implements an algorithm similar to Mozilla's Readability.js to identify and extract
+// the main content from web pages, removing clutter, navigation, ads, and other non-content elements.
+package readability
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/mackee/go-readability/internal/dom"
+	"github.com/mackee/go-readability/internal/parser"
+	"github.com/mackee/go-readability/internal/util"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Document is a parsed and preprocessed HTML document. It lets callers extract the
+// article, build the ARIA tree, read metadata, and render the content in several formats
+// without parsing or preprocessing the HTML again. The extraction result is memoized.
+// A Document is not safe for concurrent use.
+type Document struct {
+	doc     *dom.VDocument
+	options ReadabilityOptions
+
+	// Information read before preprocessing removes the script and overlay elements
+	structuredData []map[string]interface{}
+	jsonLD         ReadabilityMetadata
+	paywalled      bool
+	byline         string
+	publishedTime  string
+	section        string
+	breadcrumbs    []string
+	prevURL        string
+	nextURL        string
+	footnotes      map[string]*dom.VElement
+
+	extracted bool
+	article   ReadabilityArticle
+	err       error
+}
+
+// Parse parses and preprocesses HTML for extraction with the given options.
+//
+// Parameters:
+//   - html: The HTML string to parse
+//   - options: Configuration options for the extraction process
+//
+// Returns:
+//   - The parsed and preprocessed Document
+//   - An error wrapping ErrParseFailed if the HTML parsing fails, or ErrNoBody if the document has no body.
+//     An error wrapping ErrInvalidOptions is returned if options.TagsToScore, an extra pattern,
+//     a TagScores key, an IncludeOnlySelectors entry, StopSelector, or OutputFormat is invalid,
+//     or if options.AncestorDepth is negative.
+//     With options.StrictErrors, ErrEmptyDocument is returned for a document without text.
+func Parse(html string, options ReadabilityOptions) (*Document, error) {
+	if len(options.TagsToScore) > 0 {
+		tags, err := normalizeTagsToScore(options.TagsToScore)
+		if err != nil {
+			return nil, err
+		}
+		options.TagsToScore = tags
+	}
+	if options.AncestorDepth < 0 {
+		return nil, fmt.Errorf("%w: AncestorDepth %d is negative", ErrInvalidOptions, options.AncestorDepth)
+	}
+	switch options.OutputFormat {
+	case OutputFormatText, OutputFormatHTML, OutputFormatMarkdown:
+	default:
+		return nil, fmt.Errorf("%w: unknown OutputFormat %q", ErrInvalidOptions, options.OutputFormat)
+	}
+	if _, err := newClassPatterns(options); err != nil {
+		return nil, err
+	}
+	included, err := parseSelectors(options.IncludeOnlySelectors)
+	if err != nil {
+		return nil, fmt.Errorf("%w: IncludeOnlySelectors: %w", ErrInvalidOptions, err)
+	}
+	if options.StopSelector != "" {
+		if _, err := parseSelectors([]string{options.StopSelector}); err != nil {
+			return nil, fmt.Errorf("%w: StopSelector: %w", ErrInvalidOptions, err)
+		}
+	}
+
+	// Parse HTML to create virtual DOM
+	parseHTML := ParseHTML
+	if options.ParseCommentedContent {
+		parseHTML = parser.ParseHTMLWithComments
+	}
+	doc, err := parseHTML(html, options.BaseURL)
+	if err != nil {
+		return nil, parseError(err)
+	}
+	if !hasBody(doc) {
+		return nil, ErrNoBody
+	}
+	if options.StrictErrors && strings.TrimSpace(GetInnerText(doc.Body, false)) == "" {
+		return nil, ErrEmptyDocument
+	}
+
+	// Promote template/noscript content before preprocessing removes noscript
+	if options.UnwrapTemplates {
+		unwrapped := unwrapTemplates(doc)
+		if options.Logger != nil {
+			options.Logger.Debug("unwrapped templates", slog.Int("count", unwrapped))
+		}
+	}
+
+	// Read JSON-LD, paywall markers, bylines, dates, breadcrumbs, and post navigation
+	// before preprocessing removes them
+	document := &Document{doc: doc}
+	document.structuredData = GetStructuredData(doc)
+	document.jsonLD = GetJSONLD(doc)
+	document.paywalled = isPaywalledDocument(doc, document.structuredData)
+	document.byline = GetArticleByline(doc)
+	document.publishedTime = GetPublishedTime(doc, options.now())
+	document.section = GetArticleSection(doc)
+	document.breadcrumbs = breadcrumbNames(document.structuredData)
+	document.prevURL, document.nextURL = GetAdjacentArticleURLs(doc)
+	if options.ResolveFootnotes {
+		document.footnotes = findFootnoteDefinitions(doc)
+	}
+
+	// Execute preprocessing
+	if len(included) > 0 {
+		preprocessIncluded(doc, included, options.Logger)
+	} else {
+		preprocessDocument(doc, options.Logger)
+	}
+	if options.DropScreenReaderOnly {
+		dropped := removeScreenReaderOnly(doc.Body)
+		if options.Logger != nil {
+			options.Logger.Debug("removed screen-reader-only elements", slog.Int("count", dropped))
+		}
+	}
+	if options.StripInvisibleChars {
+		cleaned := removeInvisibleChars(doc.DocumentElement)
+		if options.Logger != nil {
+			options.Logger.Debug("stripped invisible characters", slog.Int("textNodes", cleaned))
+		}
+	}
+	if options.NormalizeUnicode {
+		normalized := normalizeUnicode(doc.DocumentElement)
+		if options.Logger != nil {
+			options.Logger.Debug("normalized unicode", slog.Int("textNodes", normalized))
+		}
+	}
+
+	// Set default values if not provided
+	if options.CharThreshold <= 0 {
+		options.CharThreshold = util.DefaultCharThreshold
+	}
+
+	if options.NbTopCandidates <= 0 {
+		options.NbTopCandidates = util.DefaultNTopCandidates
+	}
+
+	// Set default page type if not specified
+	if options.ForcedPageType == "" {
+		options.ForcedPageType = PageTypeArticle
+	}
+
+	document.options = options
+	return document, nil
+}
+
+// VDocument returns the underlying preprocessed document.
+//
+// Returns:
+//   - The preprocessed VDocument
+func (d *Document) VDocument() *dom.VDocument {
+	return d.doc
+}
+
+// Extract extracts the article content from the document. The extraction runs once;
+// later calls return the same result. Postprocessing options (e.g. IncludeByline) modify
+// the document, so the ARIA tree built after extraction reflects those changes.
+//
+// Returns:
+//   - A ReadabilityArticle containing the extracted content and metadata
+//   - With options.StrictErrors, an error wrapping ErrNoContent when an article page yields no content
+func (d *Document) Extract() (ReadabilityArticle, error) {
+	if d.extracted {
+		return d.article, d.err
+	}
+	d.extracted = true
+
+	article, charThreshold := extractContent(d.doc, d.options)
+	article.StructuredData = d.structuredData
+	article.Paywalled = article.Paywalled || d.paywalled
+	if d.byline != "" {
+		article.Byline = d.byline
+	}
+	if d.publishedTime != "" {
+		article.PublishedTime = d.publishedTime
+	}
+	if d.section != "" {
+		article.Section = d.section
+	}
+	if len(d.breadcrumbs) > 0 {
+		article.Breadcrumbs = d.breadcrumbs
+	}
+	if excerpt, siteName := getExcerptAndSiteName(d.doc, d.jsonLD); excerpt != "" || siteName != "" {
+		article.Excerpt, article.SiteName = excerpt, siteName
+	}
+	if d.prevURL != "" {
+		article.PrevURL = d.prevURL
+	}
+	if d.nextURL != "" {
+		article.NextURL = d.nextURL
+	}
+	if resolveFootnotes(article.Root, d.footnotes) > 0 {
+		article.NodeCount = CountNodes(article.Root)
+	}
+	// The title and byline may come from attributes, which are not text nodes
+	if d.options.NormalizeUnicode {
+		article.Title = norm.NFC.String(article.Title)
+		article.Byline = norm.NFC.String(article.Byline)
+	}
+	d.article = article
+	if d.options.StrictErrors && article.PageType == PageTypeArticle && article.Root == nil {
+		d.err = fmt.Errorf("%w: no candidate met the character threshold of %d", ErrNoContent, charThreshold)
+	}
+	return d.article, d.err
+}
+
+// AriaTree builds the ARIA tree of the document, descending at most options.MaxDepth levels
+// and merging at most options.AriaMaxGroupSize similar siblings into a group.
+//
+// Returns:
+//   - An AriaTree representing the document's accessibility structure
+func (d *Document) AriaTree() *AriaTree {
+	return buildAriaTree(d.doc, d.options.maxDepth(), d.options.AriaMaxGroupSize)
+}
+
+// Metadata returns the metadata of the document: title, byline, excerpt, site name,
+// publication date, and section. JSON-LD values are preferred over meta tags.
+//
+// Returns:
+//   - The ReadabilityMetadata of the document
+func (d *Document) Metadata() ReadabilityMetadata {
+	titleMinLength, titleMaxLength := d.options.titleBounds()
+	metadata := ReadabilityMetadata{
+		Title:         getArticleTitle(d.doc, titleMinLength, titleMaxLength, d.options.TitlePrecedence),
+		Byline:        d.byline,
+		PublishedTime: d.publishedTime,
+		Section:       d.section,
+	}
+	metadata.Excerpt, metadata.SiteName = getExcerptAndSiteName(d.doc, d.jsonLD)
+	return metadata
+}
+
+// HTML renders the extracted content as HTML (see ToHTML).
+//
+// Returns:
+//   - The HTML of the content, or an empty string if no content was extracted
+func (d *Document) HTML() string {
+	article, _ := d.Extract()
+	return ToHTML(article.Root)
+}
+
+// Markdown renders the extracted content as Markdown (see ToMarkdown).
+//
+// Returns:
+//   - The Markdown of the content, or an empty string if no content was extracted
+func (d *Document) Markdown() string {
+	article, _ := d.Extract()
+	if article.Root == nil {
+		return ""
+	}
+	return ToMarkdown(article.Root)
+}
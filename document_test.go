@@ -0,0 +1,124 @@
+package readability
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDocument(t *testing.T) {
+	html := `<html><head><title>Shared Document | Example</title>
+		<meta property="og:site_name" content="Example">
+		<meta name="description" content="How to reuse a parsed document.">
+		<script type="application/ld+json">{"@context":"https://schema.org","@type":"Article","author":{"name":"Jane Doe"},"datePublished":"2024-05-06"}</script>
+		</head><body><nav><a href="/">Home</a></nav><main><article><h1>Shared Document</h1>` +
+		strings.Repeat(`<p>Readability extracts the main content of a page and drops
+			navigation, advertisements and other clutter from it.</p>`, 5) +
+		`</article></main></body></html>`
+
+	options := DefaultOptions()
+	options.CharThreshold = 100
+	document, err := Parse(html, options)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	// Preprocessing ran once, in Parse
+	if len(GetElementsByTagName(document.VDocument().Body, "nav")) != 0 {
+		t.Error("Expected the document to be preprocessed")
+	}
+
+	article, err := document.Extract()
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if article.Root == nil {
+		t.Fatal("Expected content to be extracted")
+	}
+	if again, _ := document.Extract(); again.Root != article.Root {
+		t.Error("Expected the extraction result to be memoized")
+	}
+	if article.PublishedTime != "2024-05-06" {
+		t.Errorf("Expected the JSON-LD date read before preprocessing, got %q", article.PublishedTime)
+	}
+
+	if markdown := document.Markdown(); !strings.HasPrefix(markdown, "# Shared Document") {
+		t.Errorf("Expected Markdown of the content, got %q", markdown)
+	}
+	if output := document.HTML(); !strings.HasPrefix(output, "<article><h1>Shared Document</h1>") {
+		t.Errorf("Expected HTML of the content, got %q", output)
+	}
+	if tree := document.AriaTree(); tree == nil || tree.Root == nil {
+		t.Error("Expected an ARIA tree")
+	}
+
+	metadata := document.Metadata()
+	if metadata.Byline != "Jane Doe" {
+		t.Errorf("Expected the JSON-LD byline, got %q", metadata.Byline)
+	}
+	if metadata.SiteName != "Example" || metadata.Excerpt != "How to reuse a parsed document." {
+		t.Errorf("Expected the site name and excerpt from meta tags, got %+v", metadata)
+	}
+	if metadata.PublishedTime != "2024-05-06" {
+		t.Errorf("Expected the published time, got %q", metadata.PublishedTime)
+	}
+
+	// Extract on the HTML gives the same result as the Document
+	direct, err := Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if ToHTML(direct.Root) != document.HTML() {
+		t.Error("Expected Extract and Document.Extract to agree")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	if _, err := Parse("<html><body><p>x</p></body></html>", ReadabilityOptions{TagsToScore: []string{"<p>"}}); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("Expected ErrInvalidOptions, got %v", err)
+	}
+
+	document, err := Parse(`<html><body><p>Too short.</p></body></html>`, ReadabilityOptions{StrictErrors: true})
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if _, err := document.Extract(); !errors.Is(err, ErrNoContent) {
+		t.Errorf("Expected ErrNoContent, got %v", err)
+	}
+	if _, err := document.Extract(); !errors.Is(err, ErrNoContent) {
+		t.Errorf("Expected the memoized ErrNoContent, got %v", err)
+	}
+	if document.Markdown() != "" || document.HTML() != "" {
+		t.Error("Expected empty output without content")
+	}
+}
+
+func TestExtractParseCommentedContent(t *testing.T) {
+	html := `<html><head><title>Story</title></head><body>
+		<div id="app"></div>
+		<!--<article><h1>Title</h1>` +
+		strings.Repeat("<p>This paragraph has enough text, and commas, to be scored as content.</p>", 10) +
+		`</article>-->
+	</body></html>`
+
+	options := DefaultOptions()
+	article, err := Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if article.Root != nil {
+		t.Errorf("Expected no content without ParseCommentedContent, got <%s>", article.Root.TagName)
+	}
+
+	options.ParseCommentedContent = true
+	article, err = Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if article.Root == nil || article.Root.TagName != "article" {
+		t.Fatalf("Expected the commented article to be extracted, got %v", article.Root)
+	}
+	if !strings.Contains(ToMarkdown(article.Root), "# Title") {
+		t.Errorf("Expected the heading in the output, got %q", ToMarkdown(article.Root))
+	}
+}
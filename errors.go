@@ -0,0 +1,39 @@
+// Package readability provides functionality to extract readable content from HTML documents.
+// It implements an algorithm similar to Mozilla's Readability.js to identify and extract
+// the main content from web pages, removing clutter, navigation, ads, and other non-content elements.
+package readability
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors returned (possibly wrapped) by the extraction functions.
+// Use errors.Is to check for them.
+var (
+	// ErrParseFailed is returned when the HTML cannot be parsed
+	ErrParseFailed = errors.New("readability: failed to parse HTML")
+	// ErrNoBody is returned when a document has no body or document element to extract content from
+	ErrNoBody = errors.New("readability: document has no body")
+	// ErrEmptyDocument is returned with ReadabilityOptions.StrictErrors when the document has no text
+	ErrEmptyDocument = errors.New("readability: document is empty")
+	// ErrInvalidOptions is returned when ReadabilityOptions contains an invalid value
+	ErrInvalidOptions = errors.New("readability: invalid options")
+	// ErrNoContent is returned with ReadabilityOptions.StrictErrors when an article page yields no content
+	ErrNoContent = errors.New("readability: no content found")
+	// ErrExtractionFailed is returned by Extract when the extraction fails unexpectedly
+	// (a bug triggered by unusual input) instead of panicking
+	ErrExtractionFailed = errors.New("readability: extraction failed")
+)
+
+// parseError wraps an error from the HTML parser so that it matches ErrParseFailed
+// while keeping the original error available to errors.Is and errors.As.
+//
+// Parameters:
+//   - err: The error returned by the parser
+//
+// Returns:
+//   - The wrapped error
+func parseError(err error) error {
+	return fmt.Errorf("%w: %w", ErrParseFailed, err)
+}
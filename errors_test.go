@@ -0,0 +1,116 @@
+package readability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/mackee/go-readability/internal/dom"
+)
+
+func TestExtractErrors(t *testing.T) {
+	article := `<html><head><title>Article</title></head><body><article>` +
+		strings.Repeat("<p>The extraction algorithm scores paragraphs, and picks the article with the highest score.</p>", 8) +
+		`</article></body></html>`
+	short := `<html><head><title>Short</title></head><body><article><p>Too short to be an article.</p></article></body></html>`
+
+	tests := []struct {
+		name         string
+		html         string
+		strictErrors bool
+		expected     error
+	}{
+		{name: "empty document is not an error by default", html: "", strictErrors: false, expected: nil},
+		{name: "empty document", html: "", strictErrors: true, expected: ErrEmptyDocument},
+		{name: "whitespace-only document", html: "<html><body>\n  \n</body></html>", strictErrors: true, expected: ErrEmptyDocument},
+		{name: "no content is not an error by default", html: short, strictErrors: false, expected: nil},
+		{name: "no content", html: short, strictErrors: true, expected: ErrNoContent},
+		{name: "content found", html: article, strictErrors: true, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultOptions()
+			options.StrictErrors = tt.strictErrors
+
+			_, err := Extract(tt.html, options)
+			if tt.expected == nil {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Expected error %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestErrNoContentReportsAppliedThreshold(t *testing.T) {
+	html := `<html lang="ja"><head><title>短い</title></head><body><article><p>記事と呼ぶには短すぎる本文です。</p></article></body></html>`
+	options := DefaultOptions()
+	options.StrictErrors = true
+	options.AutoThreshold = true
+
+	_, err := Extract(html, options)
+	if !errors.Is(err, ErrNoContent) {
+		t.Fatalf("Expected ErrNoContent, got %v", err)
+	}
+	applied := effectiveCharThreshold(options.CharThreshold, ScriptCJK)
+	if want := fmt.Sprintf("threshold of %d", applied); !strings.Contains(err.Error(), want) {
+		t.Errorf("Expected %q in the error, got %q", want, err.Error())
+	}
+}
+
+func TestParseError(t *testing.T) {
+	err := parseError(io.ErrUnexpectedEOF)
+	if !errors.Is(err, ErrParseFailed) {
+		t.Errorf("Expected %v to match ErrParseFailed", err)
+	}
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Expected %v to keep the original error", err)
+	}
+}
+
+// panicHandler is a slog.Handler that panics on every record, to simulate a failure
+// in the middle of an extraction.
+type panicHandler struct{}
+
+func (panicHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (panicHandler) Handle(context.Context, slog.Record) error { panic("handler failure") }
+
+func (h panicHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+
+func (h panicHandler) WithGroup(string) slog.Handler { return h }
+
+func TestExtractRecoversFromPanics(t *testing.T) {
+	options := DefaultOptions()
+	options.Logger = slog.New(panicHandler{})
+	_, err := Extract(`<html><body><nav>Menu</nav><article><p>Text</p></article></body></html>`, options)
+	if !errors.Is(err, ErrExtractionFailed) {
+		t.Errorf("Expected ErrExtractionFailed, got %v", err)
+	}
+}
+
+func TestDocumentFunctionsWithoutDocument(t *testing.T) {
+	for _, doc := range []*dom.VDocument{nil, dom.NewVDocument(nil, nil), dom.NewVDocument(dom.NewVElement("html"), nil)} {
+		if title := GetArticleTitle(doc); title != "" {
+			t.Errorf("Expected no title, got %q", title)
+		}
+		if data := GetStructuredData(doc); data != nil {
+			t.Errorf("Expected no structured data, got %v", data)
+		}
+		if candidates := FindMainCandidates(doc, 5); len(candidates) != 0 {
+			t.Errorf("Expected no candidates, got %d", len(candidates))
+		}
+		if header, footer, others := FindStructuralElements(doc); header != nil || footer != nil || len(others) != 0 {
+			t.Errorf("Expected no structural elements")
+		}
+	}
+}
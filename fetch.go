@@ -0,0 +1,148 @@
+// Package readability provides functionality to extract readable content from HTML documents.
+// It implements an algorithm similar to Mozilla's Readability.js to identify and extract
+// the main content from web pages, removing clutter, navigation, ads, and other non-content elements.
+package readability
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/mackee/go-readability/internal/dom"
+)
+
+// Kinds of alternate versions returned by FindAlternateVersion
+const (
+	// AlternateKindAMP is an AMP version linked with rel="amphtml"
+	AlternateKindAMP = "amp"
+	// AlternateKindPrint is a print version linked with rel="alternate" media="print"
+	AlternateKindPrint = "print"
+)
+
+// FindAlternateVersion looks for a cleaner variant of the page, such as an AMP or print version.
+// AMP versions are preferred over print versions.
+//
+// Parameters:
+//   - doc: The parsed HTML document
+//   - baseURL: The URL of the document, used to resolve relative links
+//
+// Returns:
+//   - The absolute URL of the alternate version, or an empty string if none is found
+//   - The kind of the alternate version (AlternateKindAMP or AlternateKindPrint)
+func FindAlternateVersion(doc *dom.VDocument, baseURL string) (string, string) {
+	if doc == nil {
+		return "", ""
+	}
+
+	var printURL string
+	for _, link := range GetElementsByTagName(doc.DocumentElement, "link") {
+		href := strings.TrimSpace(link.GetAttribute("href"))
+		if href == "" {
+			continue
+		}
+		rels := strings.Fields(strings.ToLower(link.GetAttribute("rel")))
+		for _, rel := range rels {
+			if rel == "amphtml" {
+				return resolveURL(baseURL, href), AlternateKindAMP
+			}
+			if rel == "alternate" && printURL == "" &&
+				strings.Contains(strings.ToLower(link.GetAttribute("media")), "print") {
+				printURL = resolveURL(baseURL, href)
+			}
+		}
+	}
+
+	if printURL != "" {
+		return printURL, AlternateKindPrint
+	}
+	return "", ""
+}
+
+// resolveURL resolves a possibly relative reference against a base URL.
+// The reference is returned unchanged if either URL cannot be parsed.
+//
+// Parameters:
+//   - baseURL: The base URL
+//   - ref: The reference to resolve
+//
+// Returns:
+//   - The resolved absolute URL
+func resolveURL(baseURL, ref string) string {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return ref
+	}
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return base.ResolveReference(refURL).String()
+}
+
+// fetchHTML fetches a URL and returns the response body as a string.
+//
+// Parameters:
+//   - pageURL: The URL to fetch
+//
+// Returns:
+//   - The response body
+//   - An error if the request fails or the response status is not 200 OK
+func fetchHTML(pageURL string) (string, error) {
+	resp, err := http.Get(pageURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch URL: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("HTTP request failed with status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+	return string(body), nil
+}
+
+// ExtractFromURL fetches a web page and extracts its article content.
+// When options.PreferCleanVariant is set and the page links an AMP or print version,
+// that version is fetched and extracted instead. If this fails or yields no content,
+// the original page is used.
+//
+// Parameters:
+//   - pageURL: The URL of the page to extract content from
+//   - options: Configuration options for the extraction process
+//
+// Returns:
+//   - A ReadabilityArticle containing the extracted content and metadata
+//   - An error if the page cannot be fetched or parsed
+func ExtractFromURL(pageURL string, options ReadabilityOptions) (ReadabilityArticle, error) {
+	html, err := fetchHTML(pageURL)
+	if err != nil {
+		return ReadabilityArticle{}, err
+	}
+	if options.BaseURL == "" {
+		options.BaseURL = pageURL
+	}
+
+	if options.PreferCleanVariant {
+		doc, err := ParseHTML(html, pageURL)
+		if err != nil {
+			return ReadabilityArticle{}, err
+		}
+		if alternateURL, _ := FindAlternateVersion(doc, pageURL); alternateURL != "" && alternateURL != pageURL {
+			if alternateHTML, err := fetchHTML(alternateURL); err == nil {
+				alternateOptions := options
+				alternateOptions.BaseURL = alternateURL
+				if article, err := Extract(alternateHTML, alternateOptions); err == nil && article.Root != nil {
+					return article, nil
+				}
+			}
+		}
+	}
+
+	return Extract(html, options)
+}
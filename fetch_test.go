@@ -0,0 +1,119 @@
+package readability
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mackee/go-readability/internal/parser"
+)
+
+func TestFindAlternateVersion(t *testing.T) {
+	tests := []struct {
+		name         string
+		html         string
+		expectedURL  string
+		expectedKind string
+	}{
+		{
+			name:         "relative amphtml link",
+			html:         `<html><head><link rel="amphtml" href="/amp/article-1"></head><body></body></html>`,
+			expectedURL:  "https://example.com/amp/article-1",
+			expectedKind: AlternateKindAMP,
+		},
+		{
+			name:         "print alternate",
+			html:         `<html><head><link rel="alternate" media="print" href="article-1?print=1"></head><body></body></html>`,
+			expectedURL:  "https://example.com/news/article-1?print=1",
+			expectedKind: AlternateKindPrint,
+		},
+		{
+			name: "amp is preferred over print",
+			html: `<html><head>
+				<link rel="alternate" media="print" href="https://example.com/print/1">
+				<link rel="amphtml" href="https://amp.example.com/1">
+			</head><body></body></html>`,
+			expectedURL:  "https://amp.example.com/1",
+			expectedKind: AlternateKindAMP,
+		},
+		{
+			name:         "other alternates are ignored",
+			html:         `<html><head><link rel="alternate" type="application/rss+xml" href="/feed"></head><body></body></html>`,
+			expectedURL:  "",
+			expectedKind: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.ParseHTML(tt.html, "")
+			if err != nil {
+				t.Fatalf("Failed to parse HTML: %v", err)
+			}
+
+			url, kind := FindAlternateVersion(doc, "https://example.com/news/article-1")
+			if url != tt.expectedURL || kind != tt.expectedKind {
+				t.Errorf("FindAlternateVersion() = (%q, %q), want (%q, %q)", url, kind, tt.expectedURL, tt.expectedKind)
+			}
+		})
+	}
+}
+
+func TestExtractFromURL(t *testing.T) {
+	article := func(text string) string {
+		return `<article><h1>Title</h1>` + strings.Repeat("<p>"+text+" This paragraph has enough text, and commas, to be scored.</p>", 5) + `</article>`
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/with-amp", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><head><link rel="amphtml" href="/amp"></head><body>`+article("Original version.")+`</body></html>`)
+	})
+	mux.HandleFunc("/amp", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><body>`+article("Clean version.")+`</body></html>`)
+	})
+	mux.HandleFunc("/broken-amp", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><head><link rel="amphtml" href="/missing"></head><body>`+article("Original version.")+`</body></html>`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	options := DefaultOptions()
+	options.CharThreshold = 100
+
+	tests := []struct {
+		name               string
+		path               string
+		preferCleanVariant bool
+		expected           string
+	}{
+		{name: "original by default", path: "/with-amp", preferCleanVariant: false, expected: "Original version."},
+		{name: "amp variant when preferred", path: "/with-amp", preferCleanVariant: true, expected: "Clean version."},
+		{name: "fall back when the variant fails", path: "/broken-amp", preferCleanVariant: true, expected: "Original version."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := options
+			opts.PreferCleanVariant = tt.preferCleanVariant
+
+			result, err := ExtractFromURL(server.URL+tt.path, opts)
+			if err != nil {
+				t.Fatalf("ExtractFromURL failed: %v", err)
+			}
+			if result.Root == nil {
+				t.Fatal("Expected content to be extracted")
+			}
+			if text := ExtractTextContent(result.Root); !strings.Contains(text, tt.expected) {
+				t.Errorf("Expected content to contain %q, got %q", tt.expected, text)
+			}
+		})
+	}
+
+	t.Run("error status", func(t *testing.T) {
+		if _, err := ExtractFromURL(server.URL+"/missing", options); err == nil {
+			t.Error("Expected an error for a missing page")
+		}
+	})
+}
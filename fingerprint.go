@@ -0,0 +1,114 @@
+// Package readability provides functionality to extract readable content from HTML documents.
+// It implements an algorithm similar to Mozilla's Readability.js to identify and extract
+// the main content from web pages, removing clutter, navigation, ads, and other non-content elements.
+package readability
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash/fnv"
+	"strings"
+	"unicode"
+)
+
+// normalizedTokens returns the words of an article's content for fingerprinting:
+// lowercased, without punctuation or symbols, with each CJK character as its own word.
+//
+// Parameters:
+//   - article: The article to tokenize
+//
+// Returns:
+//   - The words of the content, or nil if the article has no content
+func normalizedTokens(article ReadabilityArticle) []string {
+	if article.Root == nil {
+		return nil
+	}
+
+	var tokens []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, word.String())
+			word.Reset()
+		}
+	}
+	// Join blocks with spaces so that words of adjacent paragraphs are not glued together
+	for _, r := range strings.Join(collectTextBlocks(article.Root), " ") {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			flush()
+			tokens = append(tokens, string(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r):
+			word.WriteRune(unicode.ToLower(r))
+		default:
+			// Whitespace, punctuation, and symbols separate words
+			flush()
+		}
+	}
+	flush()
+	return tokens
+}
+
+// ContentFingerprint computes a stable hash of an article's text for deduplication.
+// The text is lowercased and stripped of punctuation and markup, and whitespace is
+// collapsed, so the same text in different markup yields the same fingerprint.
+//
+// Parameters:
+//   - article: The extracted article
+//
+// Returns:
+//   - The hex-encoded SHA-256 of the normalized text, or "" if the article has no content
+func ContentFingerprint(article ReadabilityArticle) string {
+	if article.Root == nil {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(strings.Join(normalizedTokens(article), " ")))
+	return hex.EncodeToString(sum[:])
+}
+
+// ContentSimHash computes a 64-bit SimHash of an article's text for near-duplicate
+// detection. Similar texts yield hashes that differ in few bits; compare two hashes
+// with bits.OnesCount64(a ^ b) (a distance of 3 or less usually means a near duplicate).
+// The features are pairs of consecutive normalized words.
+//
+// Parameters:
+//   - article: The extracted article
+//
+// Returns:
+//   - The SimHash of the normalized text, or 0 if the article has no text
+func ContentSimHash(article ReadabilityArticle) uint64 {
+	tokens := normalizedTokens(article)
+	if len(tokens) == 0 {
+		return 0
+	}
+
+	features := tokens
+	if len(tokens) > 1 {
+		features = make([]string, 0, len(tokens)-1)
+		for i := 0; i+1 < len(tokens); i++ {
+			features = append(features, tokens[i]+" "+tokens[i+1])
+		}
+	}
+
+	var weights [64]int
+	for _, feature := range features {
+		h := fnv.New64a()
+		h.Write([]byte(feature))
+		sum := h.Sum64()
+		for bit := 0; bit < 64; bit++ {
+			if sum&(1<<bit) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+
+	var simHash uint64
+	for bit, weight := range weights {
+		if weight > 0 {
+			simHash |= 1 << bit
+		}
+	}
+	return simHash
+}
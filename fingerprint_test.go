@@ -0,0 +1,71 @@
+package readability
+
+import (
+	"math/bits"
+	"strings"
+	"testing"
+)
+
+// articleFromHTML builds an article whose Root is the first div of the given markup.
+func articleFromHTML(t *testing.T, html string) ReadabilityArticle {
+	t.Helper()
+	doc, err := ParseHTML("<html><body>"+html+"</body></html>", "")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+	return ReadabilityArticle{Root: GetElementsByTagName(doc.Body, "div")[0]}
+}
+
+func TestContentFingerprint(t *testing.T) {
+	plain := articleFromHTML(t, `<div><p>Hello, World! This is a test.</p><p>Second paragraph.</p></div>`)
+	styled := articleFromHTML(t, `<div class="post">
+		<p>hello   <b>world</b> --
+		this is a <a href="/test">TEST</a></p>
+		<section><p><em>Second</em> paragraph</p></section></div>`)
+	different := articleFromHTML(t, `<div><p>Hello, World! This is another test.</p><p>Second paragraph.</p></div>`)
+
+	fingerprint := ContentFingerprint(plain)
+	if len(fingerprint) != 64 {
+		t.Fatalf("Expected a hex SHA-256, got %q", fingerprint)
+	}
+	if styled := ContentFingerprint(styled); styled != fingerprint {
+		t.Errorf("Expected the same fingerprint for the same text in different markup, got %q and %q", fingerprint, styled)
+	}
+	if ContentFingerprint(different) == fingerprint {
+		t.Error("Expected a different fingerprint for different text")
+	}
+
+	// Words of adjacent blocks are not glued together
+	glued := articleFromHTML(t, `<div><p>Hello, World! This is a test.</p><p>Secondparagraph.</p></div>`)
+	if ContentFingerprint(glued) == fingerprint {
+		t.Error("Expected block boundaries to separate words")
+	}
+
+	if empty := ContentFingerprint(ReadabilityArticle{}); empty != "" {
+		t.Errorf("Expected an empty fingerprint without content, got %q", empty)
+	}
+}
+
+func TestContentSimHash(t *testing.T) {
+	text := strings.Repeat("Readability extracts the main content of a page and drops navigation, advertisements and other clutter from it. ", 3) +
+		"The algorithm scores paragraphs by their length, commas, and class names, then picks the best candidate."
+	original := articleFromHTML(t, "<div><p>"+text+"</p></div>")
+	restyled := articleFromHTML(t, "<div><h2>"+strings.ToUpper(text[:20])+"</h2><p>"+text[20:]+"</p></div>")
+	edited := articleFromHTML(t, "<div><p>"+strings.Replace(text, "best candidate", "top candidate", 1)+"</p></div>")
+	unrelated := articleFromHTML(t, "<div><p>Cats sleep for most of the day and spend their remaining hours grooming, "+
+		"hunting imaginary prey, and knocking things off tables for reasons nobody fully understands.</p></div>")
+
+	hash := ContentSimHash(original)
+	if restyled := ContentSimHash(restyled); restyled != hash {
+		t.Errorf("Expected the same SimHash for the same text in different markup, got %x and %x", hash, restyled)
+	}
+	if distance := bits.OnesCount64(hash ^ ContentSimHash(edited)); distance > 10 {
+		t.Errorf("Expected a small distance for a near duplicate, got %d", distance)
+	}
+	if distance := bits.OnesCount64(hash ^ ContentSimHash(unrelated)); distance < 15 {
+		t.Errorf("Expected a large distance for unrelated text, got %d", distance)
+	}
+	if empty := ContentSimHash(ReadabilityArticle{}); empty != 0 {
+		t.Errorf("Expected 0 without content, got %x", empty)
+	}
+}
@@ -0,0 +1,238 @@
+// Package readability provides functionality to extract readable content from HTML documents.
+// It implements an algorithm similar to Mozilla's Readability.js to identify and extract
+// the main content from web pages, removing clutter, navigation, ads, and other non-content elements.
+package readability
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mackee/go-readability/internal/dom"
+)
+
+// Regular expressions for footnote detection
+var (
+	// Class names of footnote reference links, such as footnote-ref or fnref
+	footnoteRefRegex = regexp.MustCompile(`(?i)footnote|fnref|noteref`)
+	// Class names of the links from a footnote back to its reference
+	footnoteBacklinkRegex = regexp.MustCompile(`(?i)backref|backlink|footnote-back|reversefootnote`)
+)
+
+// footnoteTarget returns the ID of the footnote a link refers to. A footnote reference is an
+// in-page link inside <sup> (or containing one), or a link marked with role="doc-noteref",
+// rel="footnote", or a footnote class.
+//
+// Parameters:
+//   - a: The <a> element to check
+//
+// Returns:
+//   - The ID of the referenced element, or an empty string if the link is not a footnote reference
+func footnoteTarget(a *dom.VElement) string {
+	href := strings.TrimSpace(a.GetAttribute("href"))
+	if !strings.HasPrefix(href, "#") || len(href) < 2 {
+		return ""
+	}
+	id := href[1:]
+
+	if a.GetAttribute("role") == "doc-noteref" || a.GetAttribute("rel") == "footnote" ||
+		footnoteRefRegex.MatchString(a.ClassName()) {
+		return id
+	}
+	if parent := a.Parent(); parent != nil && parent.TagName == "sup" {
+		return id
+	}
+	if len(GetElementsByTagName(a, "sup")) > 0 {
+		return id
+	}
+	return ""
+}
+
+// findFootnoteDefinitions finds the definitions of the footnotes referenced in the document:
+// the elements whose ID is the target of a footnote reference. An empty anchor such as
+// <a id="fn1"></a> stands for its parent. Footnotes are often in a section after the
+// article, so this must run before preprocessing.
+//
+// Parameters:
+//   - doc: The parsed HTML document
+//
+// Returns:
+//   - The definitions by ID, or nil if the document has no footnote references
+func findFootnoteDefinitions(doc *dom.VDocument) map[string]*dom.VElement {
+	if !hasBody(doc) {
+		return nil
+	}
+
+	elementsByID := make(map[string]*dom.VElement)
+	for _, element := range GetElementsByTagName(doc.Body, "*") {
+		if id := element.ID(); id != "" && elementsByID[id] == nil {
+			elementsByID[id] = element
+		}
+	}
+
+	var definitions map[string]*dom.VElement
+	for _, a := range GetElementsByTagName(doc.Body, "a") {
+		id := footnoteTarget(a)
+		definition := elementsByID[id]
+		if definition == nil {
+			continue
+		}
+		if GetInnerText(definition, true) == "" && definition.Parent() != nil {
+			definition = definition.Parent()
+		}
+		// A link inside its own target is a backlink, not a reference
+		if isAncestorOrSelf(definition, a) {
+			continue
+		}
+		if definitions == nil {
+			definitions = make(map[string]*dom.VElement)
+		}
+		definitions[id] = definition
+	}
+	return definitions
+}
+
+// isAncestorOrSelf checks whether element is node or one of its ancestors.
+func isAncestorOrSelf(element *dom.VElement, node dom.VNode) bool {
+	if node == dom.VNode(element) {
+		return true
+	}
+	for ancestor := node.Parent(); ancestor != nil; ancestor = ancestor.Parent() {
+		if ancestor == element {
+			return true
+		}
+	}
+	return false
+}
+
+// isFootnoteBacklink checks whether a link in a footnote definition points back to the
+// reference (e.g. <a href="#fnref1" class="footnote-backref">↩</a>).
+func isFootnoteBacklink(a *dom.VElement) bool {
+	if !strings.HasPrefix(strings.TrimSpace(a.GetAttribute("href")), "#") {
+		return false
+	}
+	if a.GetAttribute("role") == "doc-backlink" || footnoteBacklinkRegex.MatchString(a.ClassName()) {
+		return true
+	}
+	switch strings.TrimSpace(GetInnerText(a, true)) {
+	case "↩", "↩︎", "↑", "^":
+		return true
+	}
+	return false
+}
+
+// resolveFootnotes links the footnote references in root to their definitions. The references
+// are numbered in order of first appearance and marked with data-footnote-ref, and the
+// definitions are moved, without their backlinks and labels, into an <ol data-footnotes>
+// appended to root, which Markdown renders as [^1] footnotes. Definitions inside root are
+// removed from their place, along with containers left empty.
+//
+// Parameters:
+//   - root: The extracted content, modified in place
+//   - definitions: The footnote definitions by ID (see findFootnoteDefinitions)
+//
+// Returns:
+//   - The number of footnotes resolved
+func resolveFootnotes(root *dom.VElement, definitions map[string]*dom.VElement) int {
+	if root == nil || len(definitions) == 0 {
+		return 0
+	}
+
+	numbers := make(map[string]int)
+	labels := make(map[string]string)
+	var order []string
+	for _, a := range GetElementsByTagName(root, "a") {
+		id := footnoteTarget(a)
+		definition := definitions[id]
+		if definition == nil || isAncestorOrSelf(definition, a) {
+			continue
+		}
+		number := numbers[id]
+		if number == 0 {
+			order = append(order, id)
+			number = len(order)
+			numbers[id] = number
+			labels[id] = strings.Trim(GetInnerText(a, true), "[]() ")
+		}
+		label := strconv.Itoa(number)
+		a.SetAttribute("href", "#fn-"+label)
+		a.SetAttribute("data-footnote-ref", label)
+		a.Children = nil
+		a.AppendChild(dom.NewVText(label))
+	}
+	if len(order) == 0 {
+		return 0
+	}
+
+	list := dom.NewVElement("ol")
+	list.SetAttribute("data-footnotes", "")
+	for i, id := range order {
+		definition := definitions[id]
+		for _, a := range GetElementsByTagName(definition, "a") {
+			if isFootnoteBacklink(a) && a.Parent() != nil {
+				a.Parent().RemoveChild(a)
+			}
+		}
+		removeFootnoteLabel(definition, labels[id])
+
+		if parent := definition.Parent(); parent != nil {
+			parent.RemoveChild(definition)
+			if isAncestorOrSelf(root, parent) {
+				removeEmptyContainers(root, parent)
+			}
+		}
+
+		label := strconv.Itoa(i + 1)
+		item := dom.NewVElement("li")
+		item.SetAttribute("id", "fn-"+label)
+		item.SetAttribute("data-footnote", label)
+		children := definition.Children
+		definition.Children = nil
+		for _, child := range children {
+			item.AppendChild(child)
+		}
+		list.AppendChild(item)
+	}
+	root.AppendChild(list)
+	return len(order)
+}
+
+// removeFootnoteLabel removes the leading label of a footnote definition, such as the
+// <sup>1</sup> in <p id="fn1"><sup>1</sup> Text</p>, when it repeats the reference text.
+func removeFootnoteLabel(definition *dom.VElement, label string) {
+	if label == "" {
+		return
+	}
+	for _, child := range definition.Children {
+		if text, ok := dom.AsVText(child); ok {
+			if strings.TrimSpace(text.TextContent) == "" {
+				continue
+			}
+			return
+		}
+		element, ok := dom.AsVElement(child)
+		if !ok {
+			return
+		}
+		if strings.Trim(GetInnerText(element, true), "[](). ") == label {
+			definition.RemoveChild(element)
+		}
+		return
+	}
+}
+
+// removeEmptyContainers removes element and its ancestors below root while they have no
+// text and no media, e.g. the footnotes list and section emptied by resolveFootnotes.
+func removeEmptyContainers(root, element *dom.VElement) {
+	for element != nil && element != root {
+		if GetInnerText(element, true) != "" || len(dom.GetElementsByTagNames(element, linkMediaTags)) > 0 {
+			return
+		}
+		parent := element.Parent()
+		if parent == nil {
+			return
+		}
+		parent.RemoveChild(element)
+		element = parent
+	}
+}
@@ -0,0 +1,101 @@
+package readability
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestResolveFootnotes(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		expected string // Markdown of the resolved content
+		resolved int
+	}{
+		{
+			name: "definitions inside the content",
+			html: `<div><p>Text<sup><a href="#note-b">b</a></sup> and more<sup><a href="#note-a">a</a></sup>.</p>` +
+				`<section class="footnotes"><hr/><ol><li id="note-a">First <a href="#ref-a" role="doc-backlink">↩</a></li>` +
+				`<li id="note-b">Second</li></ol></section></div>`,
+			expected: "Text[^1] and more[^2].\n\n[^1]: Second\n[^2]: First",
+			resolved: 2,
+		},
+		{
+			name:     "leading label of a paragraph definition",
+			html:     `<div><p>Claim<a class="footnote-ref" href="#fn7">[7]</a> holds.</p><p id="fn7"><sup>7</sup> Source.</p></div>`,
+			expected: "Claim[^1] holds.\n\n[^1]: Source.",
+			resolved: 1,
+		},
+		{
+			name: "definition with several paragraphs",
+			html: `<div><p>Claim<sup><a href="#n1">1</a></sup></p>` +
+				`<div id="n1"><p>First paragraph.</p><p>Second paragraph.</p></div></div>`,
+			expected: "Claim[^1]\n\n[^1]: First paragraph.\n\n    Second paragraph.",
+			resolved: 1,
+		},
+		{
+			name:     "in-page links are not footnotes",
+			html:     `<div><p><a href="#intro">Intro</a></p><h2 id="intro">Intro</h2></div>`,
+			expected: "[Intro](#intro)\n\n## Intro",
+			resolved: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := ParseHTML("<html><body>"+tt.html+"</body></html>", "")
+			if err != nil {
+				t.Fatalf("Failed to parse HTML: %v", err)
+			}
+			definitions := findFootnoteDefinitions(doc)
+			root := GetElementsByTagName(doc.Body, "div")[0]
+			if resolved := resolveFootnotes(root, definitions); resolved != tt.resolved {
+				t.Errorf("Expected %d resolved footnotes, got %d", tt.resolved, resolved)
+			}
+			if markdown := ToMarkdown(root); markdown != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, markdown)
+			}
+			if tt.resolved > 0 && strings.Contains(ToHTML(root), "footnotes\"") {
+				t.Errorf("Expected the emptied footnotes section to be removed, got %q", ToHTML(root))
+			}
+		})
+	}
+}
+
+func TestExtractResolveFootnotes(t *testing.T) {
+	source, err := os.ReadFile("testdata/footnotes/source.html")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+
+	article, err := Extract(string(source), DefaultOptions())
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if markdown := ToMarkdown(article.Root); strings.Contains(markdown, "[^1]") {
+		t.Errorf("Expected footnotes to be left alone by default, got %q", markdown)
+	}
+
+	options := DefaultOptions()
+	options.ResolveFootnotes = true
+	article, err = Extract(string(source), options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	markdown := ToMarkdown(article.Root)
+	for _, expected := range []string{
+		"around 1440[^1].",
+		"olive oil[^2],",
+		"afterwards[^1],",
+		"[^1]: Dates vary between sources; see the [city archive](https://example.com/archive).\n",
+		"[^2]: Screw presses had been in use since Roman times.",
+	} {
+		if !strings.Contains(markdown, expected) {
+			t.Errorf("Expected Markdown to contain %q, got %q", expected, markdown)
+		}
+	}
+	if strings.Contains(markdown, "↩") {
+		t.Errorf("Expected backlinks to be removed, got %q", markdown)
+	}
+}
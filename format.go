@@ -4,6 +4,9 @@
 package readability
 
 import (
+	"net/url"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/mackee/go-readability/internal/dom"
@@ -64,6 +67,70 @@ var blockElements = map[string]bool{
 	"ul":         true,
 }
 
+// HTMLOptions contains configuration options for HTML output.
+type HTMLOptions struct {
+	// PreserveSectioning keeps <section>, <article>, and <figure> wrappers in the output.
+	// When false, the wrappers are dropped and their children are output in their place.
+	PreserveSectioning bool
+	// StripIDs removes id attributes, and name attributes of <a> elements, from the output
+	StripIDs bool
+	// PreserveInternalAnchors keeps the targets of in-page links (<a href="#fn1">) intact:
+	// their id or name survives StripIDs, and a <span> target is output instead of being unwrapped
+	PreserveInternalAnchors bool
+	// CollapseSrcset replaces the srcset of images with a src holding the URL of its
+	// largest candidate, so that each image has a single URL
+	CollapseSrcset bool
+	// LineEnding is the line ending of the output: "\n" (default if empty) or "\r\n"
+	LineEnding string
+	// LinkRel is a space-separated list of rel values, such as "nofollow noopener", added to
+	// the rel attribute of external links: links to another host than BaseURL. Relative links
+	// and in-page links (href="#...") are internal. Empty leaves rel unchanged.
+	LinkRel string
+	// BaseURL is the URL of the page, whose host LinkRel links are compared with. When empty,
+	// every absolute link is external
+	BaseURL string
+	// AddNoopener adds "noopener" to the rel attribute of links with target="_blank", so that
+	// the opened page cannot access the window of the output
+	AddNoopener bool
+	// WrapLooseText wraps runs of text and inline elements placed directly in a block
+	// container (such as <div> or <section>) next to or instead of block children in <p>
+	// elements, so that every paragraph of text is marked up as one
+	WrapLooseText bool
+}
+
+// DefaultHTMLOptions returns an HTMLOptions struct with default values.
+//
+// Returns:
+//   - An HTMLOptions struct initialized with default values
+func DefaultHTMLOptions() HTMLOptions {
+	return HTMLOptions{
+		PreserveSectioning:      true, // Keep the document hierarchy
+		PreserveInternalAnchors: true, // Keep footnote and other in-page link targets
+	}
+}
+
+// sectioningTags are the wrapper elements controlled by HTMLOptions.PreserveSectioning.
+var sectioningTags = map[string]bool{
+	"section": true,
+	"article": true,
+	"figure":  true,
+}
+
+// looseTextContainers are the elements whose loose text HTMLOptions.WrapLooseText wraps in
+// paragraphs. Elements that hold text themselves, such as <li> and <td>, are left out.
+var looseTextContainers = map[string]bool{
+	"article":    true,
+	"aside":      true,
+	"blockquote": true,
+	"body":       true,
+	"div":        true,
+	"footer":     true,
+	"header":     true,
+	"main":       true,
+	"nav":        true,
+	"section":    true,
+}
+
 // ToHTML generates HTML string from VElement, omitting span tags and class attributes.
 // This produces a cleaner HTML representation of the extracted content by removing
 // unnecessary styling and presentation elements.
@@ -74,38 +141,161 @@ var blockElements = map[string]bool{
 // Returns:
 //   - A string containing the HTML representation of the element
 func ToHTML(element *dom.VElement) string {
+	return ToHTMLWithOptions(element, DefaultHTMLOptions())
+}
+
+// ToHTMLWithOptions generates HTML string from VElement like ToHTML, with configurable options.
+//
+// Parameters:
+//   - element: The element to convert to HTML
+//   - options: Options controlling the output
+//
+// Returns:
+//   - A string containing the HTML representation of the element
+func ToHTMLWithOptions(element *dom.VElement, options HTMLOptions) string {
+	var anchors map[string]bool
+	if options.PreserveInternalAnchors {
+		anchors = internalLinkTargets(element)
+	}
+	return ConvertLineEndings(toHTML(element, options, anchors), options.LineEnding)
+}
+
+// ConvertLineEndings converts the line endings of a text to the given style. Existing
+// "\r\n" line endings are normalized first, so they are never doubled.
+//
+// Parameters:
+//   - text: The text to convert
+//   - lineEnding: "\r\n" for CRLF line endings; any other value, including "", yields "\n"
+//
+// Returns:
+//   - The text with consistent line endings
+func ConvertLineEndings(text, lineEnding string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	if lineEnding == "\r\n" {
+		text = strings.ReplaceAll(text, "\n", "\r\n")
+	}
+	return text
+}
+
+// internalLinkTargets collects the fragments referenced by in-page links (href="#...")
+// within an element.
+//
+// Parameters:
+//   - element: The element to search
+//
+// Returns:
+//   - The set of referenced ids and names
+func internalLinkTargets(element *dom.VElement) map[string]bool {
+	targets := make(map[string]bool)
+	for _, link := range GetElementsByTagName(element, "a") {
+		href := strings.TrimSpace(link.GetAttribute("href"))
+		if !strings.HasPrefix(href, "#") || len(href) == 1 {
+			continue
+		}
+		fragment := href[1:]
+		if unescaped, err := url.PathUnescape(fragment); err == nil {
+			fragment = unescaped
+		}
+		targets[fragment] = true
+	}
+	return targets
+}
+
+// isAnchorTarget checks whether an element is the target of an in-page link.
+//
+// Parameters:
+//   - element: The element to check
+//   - anchors: The referenced ids and names (see internalLinkTargets)
+//
+// Returns:
+//   - true if the element's id, or name for <a>, is referenced
+func isAnchorTarget(element *dom.VElement, anchors map[string]bool) bool {
+	if id := element.ID(); id != "" && anchors[id] {
+		return true
+	}
+	name := element.GetAttribute("name")
+	return element.TagName == "a" && name != "" && anchors[name]
+}
+
+// toHTML implements ToHTMLWithOptions for an element and its descendants.
+//
+// Parameters:
+//   - element: The element to convert to HTML
+//   - options: Options controlling the output
+//   - anchors: The ids and names to preserve as in-page link targets (may be nil)
+//
+// Returns:
+//   - A string containing the HTML representation of the element
+func toHTML(element *dom.VElement, options HTMLOptions, anchors map[string]bool) string {
 	if element == nil {
 		return ""
 	}
 
 	tagName := strings.ToLower(element.TagName)
 
-	// Omit span tags, process children directly
-	if tagName == "span" {
+	// Omit span tags (and unwanted sectioning wrappers), process children directly.
+	// Spans that are in-page link targets are kept so that the links still work.
+	isTarget := isAnchorTarget(element, anchors)
+	wrapLooseText := options.WrapLooseText && looseTextContainers[tagName]
+	if (tagName == "span" && !isTarget) || (!options.PreserveSectioning && sectioningTags[tagName]) {
 		var result strings.Builder
-		for _, child := range element.Children {
-			if text, ok := dom.AsVText(child); ok {
-				result.WriteString(escapeHTML(text.TextContent))
-			} else if elem, ok := dom.AsVElement(child); ok {
-				result.WriteString(ToHTML(elem))
-			}
-		}
+		writeChildrenHTML(&result, element, options, anchors, wrapLooseText)
 		return result.String()
 	}
 
-	// Generate attribute string, excluding 'class'
+	// Images with a srcset get the URL of its largest candidate as their only source
+	collapsedSrc := ""
+	if options.CollapseSrcset && tagName == "img" {
+		collapsedSrc = bestSrcsetURL(element.GetAttribute("srcset"))
+	}
+
+	// Links get the rel values required by LinkRel and AddNoopener
+	rel := ""
+	if tagName == "a" {
+		rel = linkRel(element, options)
+	}
+
+	// Generate attribute string, excluding 'class', link tracking pings (and ids unless
+	// they are link targets)
 	var attrs strings.Builder
+	writeAttribute := func(key, value string) {
+		if attrs.Len() > 0 {
+			attrs.WriteString(" ")
+		}
+		attrs.WriteString(key)
+		attrs.WriteString("=\"")
+		attrs.WriteString(escapeHTML(value))
+		attrs.WriteString("\"")
+	}
 	for key, value := range element.Attributes {
-		if key != "class" { // Exclude class attribute
-			if attrs.Len() > 0 {
-				attrs.WriteString(" ")
+		isID := key == "id" || (key == "name" && tagName == "a")
+		if options.StripIDs && isID && !isTarget {
+			continue
+		}
+		if key == "ping" && (tagName == "a" || tagName == "area") {
+			continue
+		}
+		if collapsedSrc != "" {
+			if key == "srcset" {
+				continue
 			}
-			attrs.WriteString(key)
-			attrs.WriteString("=\"")
-			attrs.WriteString(escapeHTML(value))
-			attrs.WriteString("\"")
+			if key == "src" {
+				value = collapsedSrc
+			}
+		}
+		if key == "rel" && rel != "" {
+			value = rel
+		}
+		if key != "class" { // Exclude class attribute
+			writeAttribute(key, value)
 		}
 	}
+	if _, ok := element.Attributes["src"]; collapsedSrc != "" && !ok {
+		writeAttribute("src", collapsedSrc)
+	}
+	if _, ok := element.Attributes["rel"]; rel != "" && !ok {
+		writeAttribute("rel", rel)
+	}
 
 	// For self-closing tags
 	if selfClosingTags[tagName] && len(element.Children) == 0 {
@@ -124,18 +314,128 @@ func ToHTML(element *dom.VElement) string {
 	}
 
 	// Process child elements
+	writeChildrenHTML(&result, element, options, anchors, wrapLooseText)
+
+	// End tag
+	result.WriteString("</" + tagName + ">")
+
+	return result.String()
+}
+
+// writeChildrenHTML writes the HTML of the children of an element. With wrapLooseText,
+// each run of text and inline elements between block children is wrapped in a <p>;
+// runs of whitespace only are written as is.
+//
+// Parameters:
+//   - result: The builder to write to
+//   - element: The element whose children are written
+//   - options: Options controlling the output
+//   - anchors: The ids and names to preserve as in-page link targets (may be nil)
+//   - wrapLooseText: Whether to wrap loose text in paragraphs
+func writeChildrenHTML(result *strings.Builder, element *dom.VElement, options HTMLOptions, anchors map[string]bool, wrapLooseText bool) {
+	var run strings.Builder
+	runHasContent := false
+	flushRun := func() {
+		if runHasContent {
+			result.WriteString("<p>" + strings.TrimSpace(run.String()) + "</p>")
+		} else {
+			result.WriteString(run.String())
+		}
+		run.Reset()
+		runHasContent = false
+	}
+
 	for _, child := range element.Children {
 		if text, ok := dom.AsVText(child); ok {
-			result.WriteString(escapeHTML(text.TextContent))
+			if !wrapLooseText {
+				result.WriteString(escapeHTML(text.TextContent))
+				continue
+			}
+			run.WriteString(escapeHTML(text.TextContent))
+			runHasContent = runHasContent || strings.TrimSpace(text.TextContent) != ""
 		} else if elem, ok := dom.AsVElement(child); ok {
-			result.WriteString(ToHTML(elem))
+			html := toHTML(elem, options, anchors)
+			if !wrapLooseText {
+				result.WriteString(html)
+				continue
+			}
+			if containsBlockElement(elem) {
+				flushRun()
+				result.WriteString(html)
+				continue
+			}
+			run.WriteString(html)
+			runHasContent = runHasContent || (elem.TagName != "br" && html != "")
 		}
 	}
+	if wrapLooseText {
+		flushRun()
+	}
+}
 
-	// End tag
-	result.WriteString("</" + tagName + ">")
+// containsBlockElement checks whether an element is a block-level element or contains one,
+// such as a <span> around a paragraph.
+func containsBlockElement(element *dom.VElement) bool {
+	if blockElements[element.TagName] {
+		return true
+	}
+	for _, child := range element.Children {
+		if elem, ok := dom.AsVElement(child); ok && containsBlockElement(elem) {
+			return true
+		}
+	}
+	return false
+}
 
-	return result.String()
+// linkRel returns the rel attribute of a link with the values required by HTMLOptions.LinkRel
+// and HTMLOptions.AddNoopener appended, skipping the values it already has.
+//
+// Parameters:
+//   - a: The <a> element
+//   - options: Options controlling the output
+//
+// Returns:
+//   - The new rel attribute, or an empty string if the link needs no change
+func linkRel(a *dom.VElement, options HTMLOptions) string {
+	var required []string
+	href := strings.TrimSpace(a.GetAttribute("href"))
+	if options.LinkRel != "" && isExternalLink(href, options.BaseURL) {
+		required = append(required, strings.Fields(options.LinkRel)...)
+	}
+	if options.AddNoopener && strings.EqualFold(strings.TrimSpace(a.GetAttribute("target")), "_blank") {
+		required = append(required, "noopener")
+	}
+	if len(required) == 0 {
+		return ""
+	}
+
+	values := strings.Fields(a.GetAttribute("rel"))
+	for _, value := range required {
+		if !slices.ContainsFunc(values, func(existing string) bool { return strings.EqualFold(existing, value) }) {
+			values = append(values, value)
+		}
+	}
+	return strings.Join(values, " ")
+}
+
+// isExternalLink checks whether a link points to another host than the page.
+//
+// Parameters:
+//   - href: The href attribute of the link
+//   - baseURL: The URL of the page, or an empty string if unknown
+//
+// Returns:
+//   - true if the link is absolute and its host differs from the host of baseURL
+func isExternalLink(href, baseURL string) bool {
+	link, err := url.Parse(href)
+	if err != nil || link.Host == "" {
+		return false
+	}
+	base, err := url.Parse(baseURL)
+	if err != nil || base.Host == "" {
+		return true
+	}
+	return !strings.EqualFold(link.Hostname(), base.Hostname())
 }
 
 // escapeHTML escapes HTML special characters.
@@ -313,3 +613,273 @@ func CountNodes(element *dom.VElement) int {
 
 	return count
 }
+
+// GetOutline collects the headings (h1-h6) within a VElement in document order.
+// Headings without any text are skipped.
+//
+// Parameters:
+//   - element: The element to collect headings from
+//
+// Returns:
+//   - A slice of OutlineItem, or nil if the element is nil or has no headings
+func GetOutline(element *dom.VElement) []OutlineItem {
+	if element == nil {
+		return nil
+	}
+
+	var outline []OutlineItem
+	headings := dom.GetElementsByTagNames(element, []string{"h1", "h2", "h3", "h4", "h5", "h6"})
+	for _, heading := range headings {
+		text := dom.GetInnerText(heading, true)
+		if text == "" {
+			continue
+		}
+		level, _ := strconv.Atoi(heading.TagName[1:])
+		outline = append(outline, OutlineItem{Level: level, Text: text})
+	}
+	return outline
+}
+
+// bestSrcsetURL picks the largest-resolution candidate of a srcset attribute. Width
+// descriptors (800w) take precedence over pixel density descriptors (2x); a candidate
+// without a descriptor counts as 1x. Among equally large candidates, the first one wins.
+//
+// Parameters:
+//   - srcset: The value of the srcset attribute
+//
+// Returns:
+//   - The URL of the largest candidate, or an empty string if the srcset has no candidates
+func bestSrcsetURL(srcset string) string {
+	bestURL := ""
+	bestWidth, bestDensity := 0.0, 0.0
+	rest := srcset
+	for {
+		rest = strings.TrimLeft(rest, " \t\n\r\f,")
+		if rest == "" {
+			break
+		}
+
+		// The URL runs up to the next whitespace; commas at its end separate candidates
+		end := strings.IndexAny(rest, " \t\n\r\f")
+		if end < 0 {
+			end = len(rest)
+		}
+		candidate := rest[:end]
+		descriptors := ""
+		rest = rest[end:]
+		if trimmed := strings.TrimRight(candidate, ","); trimmed != candidate {
+			candidate = trimmed
+		} else if comma := strings.IndexByte(rest, ','); comma >= 0 {
+			descriptors, rest = rest[:comma], rest[comma+1:]
+		} else {
+			descriptors, rest = rest, ""
+		}
+
+		width, density := 0.0, 1.0
+		for _, descriptor := range strings.Fields(descriptors) {
+			value, err := strconv.ParseFloat(descriptor[:len(descriptor)-1], 64)
+			if err != nil || value <= 0 {
+				continue
+			}
+			switch descriptor[len(descriptor)-1] {
+			case 'w':
+				width = value
+			case 'x':
+				density = value
+			}
+		}
+
+		switch {
+		case width > bestWidth:
+			bestURL, bestWidth, bestDensity = candidate, width, density
+		case width == bestWidth && bestWidth == 0 && density > bestDensity:
+			bestURL, bestDensity = candidate, density
+		}
+	}
+	return bestURL
+}
+
+// GetImages collects the images (img elements) within a VElement in document order.
+// The src attribute (or data-src for lazy-loaded images) is resolved against baseURL.
+// Images without alt text are kept with an empty Alt, so that they can be audited;
+// images without any source are skipped. An image whose URL is the same as an earlier
+// one once canonicalized (see canonicalURL) is listed once, at its first occurrence,
+// with the alt text and title of a later occurrence if the first has none.
+//
+// Parameters:
+//   - element: The element to collect images from
+//   - baseURL: The URL to resolve relative sources against (may be empty)
+//
+// Returns:
+//   - A slice of ImageInfo, or nil if the element is nil or has no images
+func GetImages(element *dom.VElement, baseURL string) []ImageInfo {
+	if element == nil {
+		return nil
+	}
+
+	var images []ImageInfo
+	indexes := make(map[string]int)
+	for _, img := range dom.GetElementsByTagName(element, "img") {
+		src := strings.TrimSpace(img.GetAttribute("src"))
+		if src == "" {
+			src = strings.TrimSpace(img.GetAttribute("data-src"))
+		}
+		if src == "" {
+			continue
+		}
+		width, _ := strconv.Atoi(strings.TrimSpace(img.GetAttribute("width")))
+		height, _ := strconv.Atoi(strings.TrimSpace(img.GetAttribute("height")))
+		image := ImageInfo{
+			Src:    resolveURL(baseURL, src),
+			Alt:    strings.TrimSpace(img.GetAttribute("alt")),
+			Title:  strings.TrimSpace(img.GetAttribute("title")),
+			Width:  width,
+			Height: height,
+		}
+
+		key := canonicalURL(image.Src)
+		if i, ok := indexes[key]; ok {
+			if images[i].Alt == "" {
+				images[i].Alt = image.Alt
+			}
+			if images[i].Title == "" {
+				images[i].Title = image.Title
+			}
+			continue
+		}
+		indexes[key] = len(images)
+		images = append(images, image)
+	}
+	return images
+}
+
+// canonicalURL returns the form of a URL used to detect duplicates: the scheme and host
+// are lowercased, the default port and the fragment are removed, and an empty path
+// becomes "/". URLs that do not parse are returned as is.
+//
+// Parameters:
+//   - rawURL: The URL to canonicalize
+//
+// Returns:
+//   - The canonical URL
+func canonicalURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	if port := u.Port(); (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		u.Host = strings.TrimSuffix(u.Host, ":"+port)
+	}
+	if u.Host != "" && u.Path == "" {
+		u.Path = "/"
+	}
+	u.Fragment = ""
+	u.RawFragment = ""
+	return u.String()
+}
+
+// GetMediaTracks collects the text tracks (<track> elements) of the video and audio elements
+// within a VElement in document order. The src attribute is resolved against baseURL, and
+// a missing kind defaults to "subtitles" as in HTML. Tracks without a source are skipped.
+//
+// Parameters:
+//   - element: The element to collect tracks from
+//   - baseURL: The URL to resolve relative sources against (may be empty)
+//
+// Returns:
+//   - A slice of TrackInfo, or nil if the element is nil or has no tracks
+func GetMediaTracks(element *dom.VElement, baseURL string) []TrackInfo {
+	if element == nil {
+		return nil
+	}
+
+	var tracks []TrackInfo
+	for _, media := range dom.GetElementsByTagNames(element, []string{"video", "audio"}) {
+		for _, track := range dom.GetElementsByTagName(media, "track") {
+			src := strings.TrimSpace(track.GetAttribute("src"))
+			if src == "" {
+				continue
+			}
+			kind := strings.ToLower(strings.TrimSpace(track.GetAttribute("kind")))
+			if kind == "" {
+				kind = "subtitles"
+			}
+			tracks = append(tracks, TrackInfo{
+				Kind:    kind,
+				Src:     resolveURL(baseURL, src),
+				SrcLang: strings.TrimSpace(track.GetAttribute("srclang")),
+				Label:   strings.TrimSpace(track.GetAttribute("label")),
+			})
+		}
+	}
+	return tracks
+}
+
+// FallbackNodes returns the elements to show for an article when no content was extracted:
+// the page header, the other significant nodes, and the page footer, in that order.
+// Elements inside another returned element are omitted so that no part of the page is
+// repeated. If the article has content, only Root is returned.
+//
+// Parameters:
+//   - article: The extracted article
+//
+// Returns:
+//   - The elements to render, or nil if the article has neither content nor structural elements
+func FallbackNodes(article ReadabilityArticle) []*dom.VElement {
+	if article.Root != nil {
+		return []*dom.VElement{article.Root}
+	}
+
+	var candidates []*dom.VElement
+	if article.Header != nil {
+		candidates = append(candidates, article.Header)
+	}
+	candidates = append(candidates, article.OtherSignificantNodes...)
+	if article.Footer != nil {
+		candidates = append(candidates, article.Footer)
+	}
+
+	var nodes []*dom.VElement
+	for _, candidate := range candidates {
+		nested := false
+		for _, other := range candidates {
+			if other != candidate && isDescendantOf(candidate, other) {
+				nested = true
+				break
+			}
+		}
+		if !nested {
+			nodes = append(nodes, candidate)
+		}
+	}
+	return nodes
+}
+
+// isDescendantOf checks whether an element is inside another element.
+func isDescendantOf(element, ancestor *dom.VElement) bool {
+	for parent := element.Parent(); parent != nil; parent = parent.Parent() {
+		if parent == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// RenderFallback renders an article as HTML even when no content was extracted:
+// Root if present, otherwise the page structure found by FindStructuralElements
+// (see FallbackNodes). This gives thin article pages some output.
+//
+// Parameters:
+//   - article: The extracted article
+//
+// Returns:
+//   - The HTML of the content or of the structural elements, or an empty string if there is none
+func RenderFallback(article ReadabilityArticle) string {
+	var parts []string
+	for _, node := range FallbackNodes(article) {
+		parts = append(parts, ToHTML(node))
+	}
+	return strings.Join(parts, "\n")
+}
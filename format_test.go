@@ -109,6 +109,38 @@ func TestToHTML(t *testing.T) {
 		}
 	})
 
+	t.Run("should keep mark elements", func(t *testing.T) {
+		p := dom.NewVElement("p")
+		p.AppendChild(dom.NewVText("Some "))
+		mark := dom.NewVElement("mark")
+		mark.AppendChild(dom.NewVText("highlighted"))
+		p.AppendChild(mark)
+		p.AppendChild(dom.NewVText(" text."))
+
+		expectedHTML := "<p>Some <mark>highlighted</mark> text.</p>"
+		if html := ToHTML(p); html != expectedHTML {
+			t.Errorf("Expected HTML: %s, got: %s", expectedHTML, html)
+		}
+	})
+
+	t.Run("should keep details and summary structure", func(t *testing.T) {
+		details := dom.NewVElement("details")
+		details.SetAttribute("class", "faq")
+
+		summary := dom.NewVElement("summary")
+		summary.AppendChild(dom.NewVText("Question"))
+		details.AppendChild(summary)
+
+		p := dom.NewVElement("p")
+		p.AppendChild(dom.NewVText("Answer"))
+		details.AppendChild(p)
+
+		expectedHTML := "<details><summary>Question</summary><p>Answer</p></details>"
+		if html := ToHTML(details); html != expectedHTML {
+			t.Errorf("Expected HTML: %s, got: %s", expectedHTML, html)
+		}
+	})
+
 	t.Run("should return empty string for nil input", func(t *testing.T) {
 		if html := ToHTML(nil); html != "" {
 			t.Errorf("Expected empty string for nil input, got: %s", html)
@@ -168,6 +200,94 @@ func TestToHTML(t *testing.T) {
 	})
 }
 
+func TestToHTMLWithOptions(t *testing.T) {
+	source := `<article class="post"><section id="intro"><h2>Intro</h2><section><p>Nested.</p></section></section>` +
+		`<figure><img src="a.png"/><figcaption>Caption</figcaption></figure></article>`
+	doc, err := ParseHTML("<html><body>"+source+"</body></html>", "")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+	article := GetElementsByTagName(doc.Body, "article")[0]
+
+	preserved := `<article><section id="intro"><h2>Intro</h2><section><p>Nested.</p></section></section>` +
+		`<figure><img src="a.png"/><figcaption>Caption</figcaption></figure></article>`
+	if html := ToHTMLWithOptions(article, HTMLOptions{PreserveSectioning: true}); html != preserved {
+		t.Errorf("Expected HTML: %s, got: %s", preserved, html)
+	}
+	if html := ToHTML(article); html != preserved {
+		t.Errorf("Expected ToHTML to preserve sectioning: %s, got: %s", preserved, html)
+	}
+
+	flattened := `<h2>Intro</h2><p>Nested.</p><img src="a.png"/><figcaption>Caption</figcaption>`
+	if html := ToHTMLWithOptions(article, HTMLOptions{}); html != flattened {
+		t.Errorf("Expected HTML: %s, got: %s", flattened, html)
+	}
+}
+
+func TestToHTMLInternalAnchors(t *testing.T) {
+	source := `<div><p id="intro">Text<a href="#fn1">1</a> and <a href="#note-2">2</a>.</p>` +
+		`<ol><li><a name="fn1">First note.</a></li><li><span id="note-2">Second note.</span></li></ol></div>`
+	doc, err := ParseHTML("<html><body>"+source+"</body></html>", "")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+	root := GetElementsByTagName(doc.Body, "div")[0]
+
+	tests := []struct {
+		name     string
+		options  HTMLOptions
+		expected string
+	}{
+		{
+			name:    "default keeps targets and ids",
+			options: DefaultHTMLOptions(),
+			expected: `<div><p id="intro">Text<a href="#fn1">1</a> and <a href="#note-2">2</a>.</p>` +
+				`<ol><li><a name="fn1">First note.</a></li><li><span id="note-2">Second note.</span></li></ol></div>`,
+		},
+		{
+			name:    "stripped ids keep link targets",
+			options: HTMLOptions{StripIDs: true, PreserveInternalAnchors: true},
+			expected: `<div><p>Text<a href="#fn1">1</a> and <a href="#note-2">2</a>.</p>` +
+				`<ol><li><a name="fn1">First note.</a></li><li><span id="note-2">Second note.</span></li></ol></div>`,
+		},
+		{
+			name:    "stripped ids without preserving anchors",
+			options: HTMLOptions{StripIDs: true},
+			expected: `<div><p>Text<a href="#fn1">1</a> and <a href="#note-2">2</a>.</p>` +
+				`<ol><li><a>First note.</a></li><li>Second note.</li></ol></div>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if html := ToHTMLWithOptions(root, tt.options); html != tt.expected {
+				t.Errorf("Expected HTML: %s, got: %s", tt.expected, html)
+			}
+		})
+	}
+}
+
+func TestExtractKeepsNestedSections(t *testing.T) {
+	paragraph := `<p>Readability extracts the main content of a page and drops navigation, advertisements and other clutter from it.</p>`
+	html := `<html><head><title>Sections</title></head><body><article><h1>Sections</h1>` +
+		`<section><h2>First</h2>` + paragraph + paragraph + `<section><h3>Nested</h3>` + paragraph + `</section></section>` +
+		`<section><h2>Second</h2>` + paragraph + paragraph + `</section></article></body></html>`
+
+	options := DefaultOptions()
+	options.CharThreshold = 100
+	result, err := Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	output := ToHTML(result.Root)
+	if !strings.Contains(output, "<section><h3>Nested</h3>") {
+		t.Errorf("Expected the nested section to survive, got %s", output)
+	}
+	if strings.Count(output, "<section>") != 3 {
+		t.Errorf("Expected 3 sections, got %s", output)
+	}
+}
+
 func TestStringify(t *testing.T) {
 	t.Run("should convert element to readable string format", func(t *testing.T) {
 		article := dom.NewVElement("article")
@@ -335,3 +455,472 @@ func TestCountNodes(t *testing.T) {
 func formatContains(s, substr string) bool {
 	return strings.Contains(s, substr)
 }
+
+func TestGetOutline(t *testing.T) {
+	t.Run("should collect headings in document order with levels", func(t *testing.T) {
+		html := `<!DOCTYPE html>
+<html>
+<head><title>Outline Test</title></head>
+<body>
+  <article>
+    <h1>Main Title</h1>
+    <p>Lorem ipsum dolor sit amet, consectetur adipiscing elit. Sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+    <h2>First   Section</h2>
+    <p>Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</p>
+    <h3>Detail</h3>
+    <p>Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur.</p>
+    <h2></h2>
+    <h2>Second Section</h2>
+    <p>Excepteur sint occaecat cupidatat non proident, sunt in culpa qui officia deserunt mollit anim id est laborum.</p>
+  </article>
+</body>
+</html>`
+		result, err := Extract(html, ReadabilityOptions{CharThreshold: 100})
+		if err != nil {
+			t.Fatalf("Extract failed: %v", err)
+		}
+		if result.Root == nil {
+			t.Fatal("Expected content to be extracted, but Root is nil")
+		}
+
+		expected := []OutlineItem{
+			{Level: 1, Text: "Main Title"},
+			{Level: 2, Text: "First Section"},
+			{Level: 3, Text: "Detail"},
+			{Level: 2, Text: "Second Section"},
+		}
+		if len(result.Outline) != len(expected) {
+			t.Fatalf("Expected %d outline items, got %d: %v", len(expected), len(result.Outline), result.Outline)
+		}
+		for i, item := range expected {
+			if result.Outline[i] != item {
+				t.Errorf("Outline[%d] = %+v, want %+v", i, result.Outline[i], item)
+			}
+		}
+	})
+
+	t.Run("should return nil for nil input", func(t *testing.T) {
+		if outline := GetOutline(nil); outline != nil {
+			t.Errorf("Expected nil outline for nil input, got %v", outline)
+		}
+	})
+}
+
+func TestGetImages(t *testing.T) {
+	t.Run("should collect images with resolved sources and missing alt text", func(t *testing.T) {
+		html := `<!DOCTYPE html>
+<html>
+<head><title>Images Test</title></head>
+<body>
+  <article>
+    <h1>Gallery</h1>
+    <p>Lorem ipsum dolor sit amet, consectetur adipiscing elit. Sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+    <img src="/images/first.png" alt="The first image" width="640" height="480">
+    <p>Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</p>
+    <img src="second.jpg" title="No alt text">
+    <img data-src="https://cdn.example.com/lazy.webp" alt="">
+    <img alt="No source">
+  </article>
+</body>
+</html>`
+		options := DefaultOptions()
+		options.CharThreshold = 100
+		options.BaseURL = "https://example.com/posts/gallery"
+		result, err := Extract(html, options)
+		if err != nil {
+			t.Fatalf("Extract failed: %v", err)
+		}
+		if result.Root == nil {
+			t.Fatal("Expected content to be extracted, but Root is nil")
+		}
+
+		expected := []ImageInfo{
+			{Src: "https://example.com/images/first.png", Alt: "The first image", Width: 640, Height: 480},
+			{Src: "https://example.com/posts/second.jpg", Title: "No alt text"},
+			{Src: "https://cdn.example.com/lazy.webp"},
+		}
+		if len(result.Images) != len(expected) {
+			t.Fatalf("Expected %d images, got %d: %v", len(expected), len(result.Images), result.Images)
+		}
+		missingAlt := 0
+		for i, image := range expected {
+			if result.Images[i] != image {
+				t.Errorf("Images[%d] = %+v, want %+v", i, result.Images[i], image)
+			}
+			if result.Images[i].Alt == "" {
+				missingAlt++
+			}
+		}
+		if missingAlt != 2 {
+			t.Errorf("Expected 2 images without alt text, got %d", missingAlt)
+		}
+	})
+
+	t.Run("should list images with the same canonical URL once", func(t *testing.T) {
+		doc, err := ParseHTML(`<html><body><div>`+
+			`<img src="/images/chart.png">`+
+			`<img src="HTTPS://Example.com:443/images/chart.png#zoom" alt="Sales chart">`+
+			`<img src="/images/chart.png?size=large">`+
+			`</div></body></html>`, "")
+		if err != nil {
+			t.Fatalf("Failed to parse HTML: %v", err)
+		}
+
+		images := GetImages(doc.Body, "https://example.com/posts/")
+		expected := []ImageInfo{
+			{Src: "https://example.com/images/chart.png", Alt: "Sales chart"},
+			{Src: "https://example.com/images/chart.png?size=large"},
+		}
+		if len(images) != len(expected) {
+			t.Fatalf("Expected %d images, got %d: %v", len(expected), len(images), images)
+		}
+		for i, image := range expected {
+			if images[i] != image {
+				t.Errorf("Images[%d] = %+v, want %+v", i, images[i], image)
+			}
+		}
+	})
+
+	t.Run("should return nil for nil input", func(t *testing.T) {
+		if images := GetImages(nil, ""); images != nil {
+			t.Errorf("Expected nil images for nil input, got %v", images)
+		}
+	})
+}
+
+func TestGetMediaTracks(t *testing.T) {
+	t.Run("should collect caption tracks with resolved sources", func(t *testing.T) {
+		html := `<!DOCTYPE html>
+<html>
+<head><title>Video Test</title></head>
+<body>
+  <article>
+    <h1>Interview</h1>
+    <p>Lorem ipsum dolor sit amet, consectetur adipiscing elit. Sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>
+    <video src="/media/interview.mp4" controls>
+      <track kind="captions" src="/media/interview.en.vtt" srclang="en" label="English">
+      <track kind="captions" src="interview.ja.vtt" srclang="ja" label="日本語">
+    </video>
+    <p>Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</p>
+  </article>
+</body>
+</html>`
+		options := DefaultOptions()
+		options.CharThreshold = 100
+		options.BaseURL = "https://example.com/videos/interview"
+		result, err := Extract(html, options)
+		if err != nil {
+			t.Fatalf("Extract failed: %v", err)
+		}
+		if result.Root == nil {
+			t.Fatal("Expected content to be extracted, but Root is nil")
+		}
+
+		expected := []TrackInfo{
+			{Kind: "captions", Src: "https://example.com/media/interview.en.vtt", SrcLang: "en", Label: "English"},
+			{Kind: "captions", Src: "https://example.com/videos/interview.ja.vtt", SrcLang: "ja", Label: "日本語"},
+		}
+		if len(result.MediaTracks) != len(expected) {
+			t.Fatalf("Expected %d tracks, got %d: %v", len(expected), len(result.MediaTracks), result.MediaTracks)
+		}
+		for i, track := range expected {
+			if result.MediaTracks[i] != track {
+				t.Errorf("MediaTracks[%d] = %+v, want %+v", i, result.MediaTracks[i], track)
+			}
+		}
+	})
+
+	t.Run("should default the kind and skip tracks without source", func(t *testing.T) {
+		doc, err := ParseHTML(`<html><body><audio><track src="a.vtt"><track kind="chapters"></audio><track src="orphan.vtt"></body></html>`, "")
+		if err != nil {
+			t.Fatalf("Failed to parse HTML: %v", err)
+		}
+		tracks := GetMediaTracks(doc.Body, "https://example.com/")
+		if len(tracks) != 1 || tracks[0] != (TrackInfo{Kind: "subtitles", Src: "https://example.com/a.vtt"}) {
+			t.Errorf("Expected a single subtitles track, got %+v", tracks)
+		}
+	})
+}
+
+func TestLineEndings(t *testing.T) {
+	source := "<div><h2>Title</h2><p>First line<br>second line</p><pre><code>a := 1\nb := 2\n</code></pre></div>"
+	doc, err := ParseHTML("<html><body>"+source+"</body></html>", "")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+	root := GetElementsByTagName(doc.Body, "div")[0]
+
+	markdownOptions := DefaultMarkdownOptions()
+	markdownOptions.LineEnding = "\r\n"
+	markdown := ToMarkdownWithOptions(root, markdownOptions)
+	if expected := strings.ReplaceAll(ToMarkdown(root), "\n", "\r\n"); markdown != expected {
+		t.Errorf("Expected Markdown %q, got %q", expected, markdown)
+	}
+	if !strings.Contains(markdown, "a := 1\r\nb := 2\r\n") {
+		t.Errorf("Expected CRLF inside the code block, got %q", markdown)
+	}
+
+	htmlOptions := DefaultHTMLOptions()
+	htmlOptions.LineEnding = "\r\n"
+	if html := ToHTMLWithOptions(root, htmlOptions); !strings.Contains(html, "a := 1\r\nb := 2\r\n") {
+		t.Errorf("Expected CRLF in the HTML output, got %q", html)
+	}
+
+	tests := []struct {
+		text       string
+		lineEnding string
+		expected   string
+	}{
+		{"a\nb", "\r\n", "a\r\nb"},
+		{"a\r\nb\nc", "\r\n", "a\r\nb\r\nc"},
+		{"a\r\nb", "\n", "a\nb"},
+		{"a\r\nb", "", "a\nb"},
+	}
+	for _, tt := range tests {
+		if actual := ConvertLineEndings(tt.text, tt.lineEnding); actual != tt.expected {
+			t.Errorf("ConvertLineEndings(%q, %q): expected %q, got %q", tt.text, tt.lineEnding, tt.expected, actual)
+		}
+	}
+}
+
+func TestRenderFallback(t *testing.T) {
+	html := `<html><head><title>Thin</title></head><body>` +
+		`<div class="site-header"><a href="/">Home</a></div>` +
+		`<main><section><h2>Welcome</h2><p>A short introduction to the project.</p></section></main>` +
+		`<div class="site-footer">Contact us</div></body></html>`
+
+	article, err := Extract(html, DefaultOptions())
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if article.Root != nil {
+		t.Fatal("Expected no content for a thin page")
+	}
+
+	expected := `<div><a href="/">Home</a></div>` + "\n" +
+		`<main><section><h2>Welcome</h2><p>A short introduction to the project.</p></section></main>` + "\n" +
+		`<div>Contact us</div>`
+	if rendered := RenderFallback(article); rendered != expected {
+		t.Errorf("Expected %q, got %q", expected, rendered)
+	}
+
+	if rendered := RenderFallback(ReadabilityArticle{}); rendered != "" {
+		t.Errorf("Expected empty output without content or structure, got %q", rendered)
+	}
+}
+
+func TestToHTMLKeepsWbr(t *testing.T) {
+	doc, err := ParseHTML(`<html><body><p>https://example.com/very<wbr>/long</p></body></html>`, "")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+	p := GetElementsByTagName(doc.Body, "p")[0]
+	if html := ToHTML(p); html != "<p>https://example.com/very<wbr/>/long</p>" {
+		t.Errorf("Expected <wbr> to be kept, got %q", html)
+	}
+	if text := GetInnerText(p, true); text != "https://example.com/very/long" {
+		t.Errorf("Expected no space at <wbr>, got %q", text)
+	}
+}
+
+func TestToHTMLKeepsTableCaption(t *testing.T) {
+	doc, err := ParseHTML(`<html><body><table><caption>Results</caption><tr><td>1</td></tr></table></body></html>`, "")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+	table := GetElementsByTagName(doc.Body, "table")[0]
+	expected := "<table><caption>Results</caption><tbody><tr><td>1</td></tr></tbody></table>"
+	if html := ToHTML(table); html != expected {
+		t.Errorf("Expected %q, got %q", expected, html)
+	}
+}
+
+func TestBestSrcsetURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		srcset   string
+		expected string
+	}{
+		{"width descriptors", "a.jpg 480w, c.jpg 1200w, b.jpg 800w", "c.jpg"},
+		{"density descriptors", "a.jpg, b.jpg 3x, c.jpg 2x", "b.jpg"},
+		{"width wins over density", "a.jpg 3x, b.jpg 320w", "b.jpg"},
+		{"commas in urls", "https://cdn.example.com/w_400,h_300/a.jpg 400w, https://cdn.example.com/w_800,h_600/a.jpg 800w", "https://cdn.example.com/w_800,h_600/a.jpg"},
+		{"single candidate", "only.jpg", "only.jpg"},
+		{"empty", "  ", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bestSrcsetURL(tt.srcset); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestToHTMLCollapseSrcset(t *testing.T) {
+	doc, err := ParseHTML(`<html><body><p><img srcset="small.jpg 480w, large.jpg 1200w, medium.jpg 800w"></p></body></html>`, "")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+	img := GetElementsByTagName(doc.Body, "img")[0]
+
+	if html := ToHTMLWithOptions(img, HTMLOptions{CollapseSrcset: true}); html != `<img src="large.jpg"/>` {
+		t.Errorf("Expected the srcset to be collapsed into src, got %q", html)
+	}
+	if html := ToHTML(img); !strings.Contains(html, "srcset=") {
+		t.Errorf("Expected the srcset to be kept by default, got %q", html)
+	}
+}
+
+func TestToHTMLLinkRel(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		options  HTMLOptions
+		expected string // The expected rel attribute, or "" for none
+	}{
+		{
+			name:     "forced nofollow",
+			html:     `<a href="https://example.com/">Example</a>`,
+			options:  HTMLOptions{LinkRel: "nofollow noopener"},
+			expected: "nofollow noopener",
+		},
+		{
+			name:     "appended to an existing rel",
+			html:     `<a href="https://example.com/" rel="author NoFollow">Example</a>`,
+			options:  HTMLOptions{LinkRel: "nofollow noopener"},
+			expected: "author NoFollow noopener",
+		},
+		{
+			name:     "in-page links unchanged",
+			html:     `<a href="#section">Section</a>`,
+			options:  HTMLOptions{LinkRel: "nofollow"},
+			expected: "",
+		},
+		{
+			name:     "same-host links unchanged",
+			html:     `<a href="https://Example.com/about">About</a>`,
+			options:  HTMLOptions{LinkRel: "nofollow", BaseURL: "https://example.com/post/1"},
+			expected: "",
+		},
+		{
+			name:     "relative links unchanged",
+			html:     `<a href="/about">About</a>`,
+			options:  HTMLOptions{LinkRel: "nofollow"},
+			expected: "",
+		},
+		{
+			name:     "other-host links with a base URL",
+			html:     `<a href="https://other.example/">Other</a>`,
+			options:  HTMLOptions{LinkRel: "nofollow", BaseURL: "https://example.com/post/1"},
+			expected: "nofollow",
+		},
+		{
+			name:     "noopener for target blank",
+			html:     `<a href="https://example.com/" target="_blank">Example</a>`,
+			options:  HTMLOptions{AddNoopener: true},
+			expected: "noopener",
+		},
+		{
+			name:     "no noopener without target blank",
+			html:     `<a href="https://example.com/">Example</a>`,
+			options:  HTMLOptions{AddNoopener: true},
+			expected: "",
+		},
+		{
+			name:     "unchanged by default",
+			html:     `<a href="https://example.com/" target="_blank">Example</a>`,
+			options:  DefaultHTMLOptions(),
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := ParseHTML("<html><body><p>"+tt.html+"</p></body></html>", "")
+			if err != nil {
+				t.Fatalf("Failed to parse HTML: %v", err)
+			}
+			a := GetElementsByTagName(doc.Body, "a")[0]
+
+			if rel := linkRel(a, tt.options); rel != tt.expected {
+				t.Errorf("Expected rel %q, got %q", tt.expected, rel)
+			}
+			html := ToHTMLWithOptions(a, tt.options)
+			if tt.expected != "" && !strings.Contains(html, `rel="`+tt.expected+`"`) {
+				t.Errorf("Expected rel=%q in the output, got %q", tt.expected, html)
+			}
+			if tt.expected == "" && strings.Contains(html, "rel=") {
+				t.Errorf("Expected no rel in the output, got %q", html)
+			}
+		})
+	}
+}
+
+func TestToHTMLWrapLooseText(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		options  HTMLOptions
+		expected string
+	}{
+		{
+			name:     "loose text between blocks",
+			html:     `<div>Intro with <b>bold</b> text<p>Paragraph</p> Outro <br></div>`,
+			options:  HTMLOptions{WrapLooseText: true},
+			expected: `<div><p>Intro with <b>bold</b> text</p><p>Paragraph</p><p>Outro <br/></p></div>`,
+		},
+		{
+			name:     "whitespace between blocks",
+			html:     "<section>\n<p>One</p>\n<p>Two</p>\n</section>",
+			options:  HTMLOptions{WrapLooseText: true, PreserveSectioning: true},
+			expected: "<section>\n<p>One</p>\n<p>Two</p>\n</section>",
+		},
+		{
+			name:     "span around a block",
+			html:     `<div>Text<span><p>Inside</p></span></div>`,
+			options:  HTMLOptions{WrapLooseText: true},
+			expected: `<div><p>Text</p><p>Inside</p></div>`,
+		},
+		{
+			name:     "text of list items",
+			html:     `<ul><li>Item</li></ul>`,
+			options:  HTMLOptions{WrapLooseText: true},
+			expected: `<ul><li>Item</li></ul>`,
+		},
+		{
+			name:     "unchanged by default",
+			html:     `<div>Loose<p>Paragraph</p></div>`,
+			options:  DefaultHTMLOptions(),
+			expected: `<div>Loose<p>Paragraph</p></div>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := ParseHTML("<html><body>"+tt.html+"</body></html>", "")
+			if err != nil {
+				t.Fatalf("Failed to parse HTML: %v", err)
+			}
+			element, ok := dom.AsVElement(doc.Body.Children[0])
+			if !ok {
+				t.Fatalf("Expected an element, got %v", doc.Body.Children[0])
+			}
+			if html := ToHTMLWithOptions(element, tt.options); html != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, html)
+			}
+		})
+	}
+}
+
+func TestToHTMLStripsPing(t *testing.T) {
+	doc, err := ParseHTML(`<html><body><p><a href="https://example.com/" ping="https://tracker.example.com/ping">Example</a></p></body></html>`, "")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	html := ToHTML(GetElementsByTagName(doc.Body, "p")[0])
+	if expected := `<p><a href="https://example.com/">Example</a></p>`; html != expected {
+		t.Errorf("Expected %q, got %q", expected, html)
+	}
+}
@@ -0,0 +1,85 @@
+package readability
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// addFixtureSeeds adds the source HTML of the test fixtures, and a few malformed documents,
+// to the seed corpus of a fuzz target.
+func addFixtureSeeds(f *testing.F) {
+	f.Helper()
+	sources, err := filepath.Glob(filepath.Join("testdata", "*", "*", "source.html"))
+	if err != nil {
+		f.Fatalf("Failed to list fixtures: %v", err)
+	}
+	more, err := filepath.Glob(filepath.Join("testdata", "*", "source.html"))
+	if err != nil {
+		f.Fatalf("Failed to list fixtures: %v", err)
+	}
+	for _, source := range append(sources, more...) {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			f.Fatalf("Failed to read fixture %s: %v", source, err)
+		}
+		f.Add(string(data))
+	}
+	for _, seed := range []string{
+		"",
+		"<",
+		"<html>",
+		"<title></title>",
+		"<title> | </title>",
+		"<html><head><title>A: B - C</title></head><body></body></html>",
+		"<body><body><p>Two bodies</p></body>",
+		"<table><tr><td><table><caption>|</caption></table>",
+		"<ul><li><ol><li><pre><code>x</code></pre>",
+		"<svg><title>t</title><path/></svg><math><mi>x</mi></math>",
+		"<p>a<br><br><br><wbr>b</p><img srcset=\", ,1x\">",
+		"<!-- <article><p>Commented</p></article> --><noscript><p>No script</p></noscript><template><p>T</p></template>",
+	} {
+		f.Add(seed)
+	}
+}
+
+func FuzzExtract(f *testing.F) {
+	addFixtureSeeds(f)
+	f.Fuzz(func(t *testing.T, html string) {
+		options := DefaultOptions()
+		options.GenerateAriaTree = true
+		options.ParseCommentedContent = true
+		options.UnwrapTemplates = true
+		article, err := Extract(html, options)
+		// Extract recovers panics as ErrExtractionFailed; they are the bugs this target looks for
+		if errors.Is(err, ErrExtractionFailed) {
+			t.Fatalf("Extract failed: %v", err)
+		}
+		if err != nil {
+			return
+		}
+		if article.Root != nil {
+			ToHTML(article.Root)
+			ToMarkdown(article.Root)
+		}
+		RenderFallback(article)
+	})
+}
+
+func FuzzToMarkdown(f *testing.F) {
+	addFixtureSeeds(f)
+	f.Fuzz(func(t *testing.T, html string) {
+		doc, err := ParseHTML(html, "https://example.com/")
+		if err != nil || doc.Body == nil {
+			return
+		}
+		ToMarkdown(doc.Body)
+		ToMarkdownWithOptions(doc.Body, MarkdownOptions{
+			CitationStyle:  CitationStyleReference,
+			WrapWidth:      20,
+			BrToParagraph:  true,
+			CollapseSrcset: true,
+		})
+	})
+}
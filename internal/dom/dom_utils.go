@@ -3,6 +3,7 @@ package dom
 
 import (
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -27,6 +28,10 @@ func GetElementsByTagNames(element *VElement, tagNames []string) []*VElement {
 
 // getElementsByTagNameInternal is the internal implementation for GetElementsByTagName and GetElementsByTagNames.
 func getElementsByTagNameInternal(element *VElement, tagNames []string) []*VElement {
+	if element == nil {
+		return nil
+	}
+
 	var result []*VElement
 
 	// Check if this element matches (using lowercase)
@@ -48,15 +53,115 @@ func getElementsByTagNameInternal(element *VElement, tagNames []string) []*VElem
 }
 
 // IsProbablyVisible checks if an element is likely to be visible based on its attributes.
+// Besides display/visibility, the hidden attribute, and aria-hidden, it detects inline styles
+// that hide content visually: opacity 0, off-screen positioning, and zero-size clipping.
+// Headings hidden with the off-screen or clipping techniques (e.g. ".sr-only" headings)
+// are still treated as visible, since they provide document structure.
 func IsProbablyVisible(node *VElement) bool {
 	style := node.GetAttribute("style")
 	hidden := node.HasAttribute("hidden")
 	ariaHidden := node.GetAttribute("aria-hidden") == "true"
 
-	return !strings.Contains(style, "display: none") &&
-		!strings.Contains(style, "visibility: hidden") &&
-		!hidden &&
-		!ariaHidden
+	if strings.Contains(style, "display: none") ||
+		strings.Contains(style, "visibility: hidden") ||
+		hidden ||
+		ariaHidden {
+		return false
+	}
+	if style == "" {
+		return true
+	}
+
+	declarations := parseInlineStyle(style)
+	if declarations["display"] == "none" || declarations["visibility"] == "hidden" {
+		return false
+	}
+	if opacity, ok := cssNumber(declarations["opacity"]); ok && opacity == 0 {
+		return false
+	}
+
+	if isOffScreen(declarations) || isClippedToNothing(declarations) {
+		// Screen-reader-only headings still structure the content
+		switch node.TagName {
+		case "h1", "h2", "h3", "h4", "h5", "h6":
+			return true
+		}
+		return false
+	}
+
+	return true
+}
+
+// IsVisuallyHidden checks whether the inline style of an element hides it visually while
+// leaving it to screen readers: off-screen positioning or zero-size clipping.
+func IsVisuallyHidden(node *VElement) bool {
+	style := node.GetAttribute("style")
+	if style == "" {
+		return false
+	}
+	declarations := parseInlineStyle(style)
+	return isOffScreen(declarations) || isClippedToNothing(declarations)
+}
+
+// parseInlineStyle parses a style attribute into lowercase property/value pairs.
+// Whitespace and !important are removed from values.
+func parseInlineStyle(style string) map[string]string {
+	declarations := make(map[string]string)
+	for _, declaration := range strings.Split(style, ";") {
+		property, value, ok := strings.Cut(declaration, ":")
+		if !ok {
+			continue
+		}
+		value = strings.ReplaceAll(strings.ToLower(value), "!important", "")
+		declarations[strings.ToLower(strings.TrimSpace(property))] = strings.Join(strings.Fields(value), "")
+	}
+	return declarations
+}
+
+// cssNumber parses the leading number of a CSS value such as "-9999px", "0.5" or "50%".
+// Returns false if the value does not start with a number.
+func cssNumber(value string) (float64, bool) {
+	end := 0
+	for end < len(value) && strings.ContainsRune("+-.0123456789", rune(value[end])) {
+		end++
+	}
+	number, err := strconv.ParseFloat(value[:end], 64)
+	if err != nil {
+		return 0, false
+	}
+	return number, true
+}
+
+// isOffScreen checks whether absolute or fixed positioning moves an element far off screen.
+func isOffScreen(declarations map[string]string) bool {
+	if position := declarations["position"]; position != "absolute" && position != "fixed" {
+		return false
+	}
+	for _, property := range []string{"left", "top", "right"} {
+		if offset, ok := cssNumber(declarations[property]); ok && offset <= -999 {
+			return true
+		}
+	}
+	return false
+}
+
+// isClippedToNothing checks whether an element is clipped or sized so that nothing of it shows.
+func isClippedToNothing(declarations map[string]string) bool {
+	switch declarations["clip"] {
+	case "rect(0,0,0,0)", "rect(0000)", "rect(1px,1px,1px,1px)", "rect(1px1px1px1px)":
+		return true
+	}
+	switch declarations["clip-path"] {
+	case "inset(50%)", "inset(100%)", "circle(0)", "circle(0px)":
+		return true
+	}
+
+	if declarations["overflow"] != "hidden" {
+		return false
+	}
+	width, widthOK := cssNumber(declarations["width"])
+	height, heightOK := cssNumber(declarations["height"])
+	return widthOK && heightOK && width <= 1 && height <= 1
 }
 
 // GetNodeAncestors returns the ancestor elements of a node up to a specified depth.
@@ -131,11 +236,19 @@ func GetInnerText(node VNode, normalizeSpaces bool) string {
 	case *VText:
 		text = n.TextContent
 	case *VElement:
+		afterWordBreak := false
 		for i, child := range n.Children {
+			// <wbr> is a zero-width line break opportunity: no space around it
+			if childElement, ok := AsVElement(child); ok && childElement.TagName == "wbr" {
+				afterWordBreak = true
+				continue
+			}
+
 			// Add space between text nodes if not the first child
-			if i > 0 && text != "" {
+			if i > 0 && text != "" && !afterWordBreak {
 				text += " "
 			}
+			afterWordBreak = false
 			
 			if childText, ok := AsVText(child); ok {
 				text += childText.TextContent
@@ -123,6 +123,141 @@ func TestIsProbablyVisible(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"Hidden with display:none without space",
+			func() *VElement {
+				el := NewVElement("div")
+				el.SetAttribute("style", "display:none")
+				return el
+			},
+			false,
+		},
+		{
+			"Hidden with opacity:0",
+			func() *VElement {
+				el := NewVElement("div")
+				el.SetAttribute("style", "opacity: 0")
+				return el
+			},
+			false,
+		},
+		{
+			"Visible with opacity:0.5",
+			func() *VElement {
+				el := NewVElement("div")
+				el.SetAttribute("style", "opacity: 0.5")
+				return el
+			},
+			true,
+		},
+		{
+			"Hidden off-screen with negative left",
+			func() *VElement {
+				el := NewVElement("div")
+				el.SetAttribute("style", "position: absolute; left: -9999px")
+				return el
+			},
+			false,
+		},
+		{
+			"Hidden off-screen with negative top",
+			func() *VElement {
+				el := NewVElement("div")
+				el.SetAttribute("style", "position: fixed; top: -10000px")
+				return el
+			},
+			false,
+		},
+		{
+			"Visible with relative positioning far to the left",
+			func() *VElement {
+				el := NewVElement("div")
+				el.SetAttribute("style", "position: relative; left: -9999px")
+				return el
+			},
+			true,
+		},
+		{
+			"Visible with small negative offset",
+			func() *VElement {
+				el := NewVElement("div")
+				el.SetAttribute("style", "position: absolute; left: -5px")
+				return el
+			},
+			true,
+		},
+		{
+			"Visible absolutely positioned on screen",
+			func() *VElement {
+				el := NewVElement("div")
+				el.SetAttribute("style", "position: absolute; left: -10px")
+				return el
+			},
+			true,
+		},
+		{
+			"Hidden with clip rect",
+			func() *VElement {
+				el := NewVElement("div")
+				el.SetAttribute("style", "position: absolute; clip: rect(0 0 0 0)")
+				return el
+			},
+			false,
+		},
+		{
+			"Hidden with clip-path inset",
+			func() *VElement {
+				el := NewVElement("div")
+				el.SetAttribute("style", "clip-path: inset(50%)")
+				return el
+			},
+			false,
+		},
+		{
+			"Hidden with 1px size and overflow hidden",
+			func() *VElement {
+				el := NewVElement("div")
+				el.SetAttribute("style", "position: absolute; width: 1px; height: 1px; overflow: hidden")
+				return el
+			},
+			false,
+		},
+		{
+			"Visible with size but overflow visible",
+			func() *VElement {
+				el := NewVElement("div")
+				el.SetAttribute("style", "width: 1px; height: 1px")
+				return el
+			},
+			true,
+		},
+		{
+			"Screen-reader-only heading stays visible",
+			func() *VElement {
+				el := NewVElement("h2")
+				el.SetAttribute("style", "position: absolute; width: 1px; height: 1px; overflow: hidden; clip: rect(1px, 1px, 1px, 1px)")
+				return el
+			},
+			true,
+		},
+		{
+			"Off-screen heading stays visible",
+			func() *VElement {
+				el := NewVElement("h3")
+				el.SetAttribute("style", "position: absolute; left: -9999px")
+				return el
+			},
+			true,
+		},
+		{
+			"Heading with opacity:0 is hidden",
+			func() *VElement {
+				el := NewVElement("h2")
+				el.SetAttribute("style", "opacity: 0 !important")
+				return el
+			},
+			false,
+		},
 	}
 
 	for _, tc := range tests {
@@ -319,6 +454,14 @@ func TestGetInnerText(t *testing.T) {
 	// Empty element
 	emptyDiv := NewVElement("div")
 	
+	// Long URL with word break opportunities
+	url := NewVElement("p")
+	url.AppendChild(NewVText("https://example.com/very"))
+	url.AppendChild(NewVElement("wbr"))
+	url.AppendChild(NewVText("/long"))
+	url.AppendChild(NewVElement("wbr"))
+	url.AppendChild(NewVText("/path"))
+
 	// Text node
 	textNode := NewVText("  Direct  text  node  ")
 
@@ -334,6 +477,7 @@ func TestGetInnerText(t *testing.T) {
 		{"Element with nested text (no normalize)", p2, false, "Paragraph  2   Nested  text"},
 		{"Parent element with multiple children", div, true, "Paragraph 1 Paragraph 2 Nested text"},
 		{"Empty element", emptyDiv, true, ""},
+		{"Element with wbr", url, false, "https://example.com/very/long/path"},
 		{"Text node", textNode, true, "Direct text node"},
 		{"Text node (no normalize)", textNode, false, "Direct  text  node"},
 	}
@@ -114,6 +114,25 @@ func (e *VElement) AppendChild(child VNode) {
 	e.Children = append(e.Children, child)
 }
 
+// PrependChild adds a child node at the beginning of this element.
+func (e *VElement) PrependChild(child VNode) {
+	child.SetParent(e)
+	e.Children = append([]VNode{child}, e.Children...)
+}
+
+// RemoveChild removes a child node from this element.
+// Returns true if the child was found and removed.
+func (e *VElement) RemoveChild(child VNode) bool {
+	for i, c := range e.Children {
+		if c == child {
+			e.Children = append(e.Children[:i], e.Children[i+1:]...)
+			child.SetParent(nil)
+			return true
+		}
+	}
+	return false
+}
+
 // SetAttribute sets an attribute on this element.
 func (e *VElement) SetAttribute(name, value string) {
 	e.Attributes[name] = value
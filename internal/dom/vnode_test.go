@@ -121,6 +121,43 @@ func TestVElement(t *testing.T) {
 	}
 }
 
+func TestVElementRemoveChild(t *testing.T) {
+	element := NewVElement("div")
+	first := NewVText("first")
+	second := NewVElement("span")
+	element.AppendChild(first)
+	element.AppendChild(second)
+
+	if !element.RemoveChild(first) {
+		t.Fatalf("Expected RemoveChild to return true for an existing child")
+	}
+	if len(element.Children) != 1 || element.Children[0] != second {
+		t.Errorf("Expected only the span to remain, got %v", element.Children)
+	}
+	if first.Parent() != nil {
+		t.Errorf("Expected removed child to have no parent")
+	}
+	if element.RemoveChild(first) {
+		t.Errorf("Expected RemoveChild to return false for a non-child")
+	}
+}
+
+func TestVElementPrependChild(t *testing.T) {
+	element := NewVElement("div")
+	second := NewVText("second")
+	element.AppendChild(second)
+
+	first := NewVElement("span")
+	element.PrependChild(first)
+
+	if len(element.Children) != 2 || element.Children[0] != first || element.Children[1] != second {
+		t.Errorf("Expected the span to be the first child, got %v", element.Children)
+	}
+	if first.Parent() != element {
+		t.Errorf("Expected prepended child's parent to be the element")
+	}
+}
+
 func TestVDocument(t *testing.T) {
 	html := NewVElement("html")
 	body := NewVElement("body")
@@ -9,11 +9,24 @@ import (
 
 	"github.com/mackee/go-readability/internal/dom"
 	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
 )
 
 // ParseHTML parses an HTML string and returns a virtual DOM document.
 // It uses golang.org/x/net/html for parsing and converts the result to our internal DOM structure.
 func ParseHTML(htmlContent string, baseURI string) (*dom.VDocument, error) {
+	return parseHTML(htmlContent, baseURI, false)
+}
+
+// ParseHTMLWithComments parses an HTML string like ParseHTML, but comments whose content
+// is HTML with block elements (e.g. an article commented out by a script-driven page)
+// are replaced by the parsed content instead of being dropped. Other comments are ignored.
+func ParseHTMLWithComments(htmlContent string, baseURI string) (*dom.VDocument, error) {
+	return parseHTML(htmlContent, baseURI, true)
+}
+
+// parseHTML implements ParseHTML and ParseHTMLWithComments.
+func parseHTML(htmlContent string, baseURI string, expandComments bool) (*dom.VDocument, error) {
 	// Parse HTML using golang.org/x/net/html
 	doc, err := html.Parse(strings.NewReader(htmlContent))
 	if err != nil {
@@ -47,22 +60,23 @@ func ParseHTML(htmlContent string, baseURI string) (*dom.VDocument, error) {
 	
 	// Process the document structure
 	if htmlNode != nil {
+		// Keep the attributes of the html element, such as lang
+		for _, attr := range htmlNode.Attr {
+			htmlElement.SetAttribute(attr.Key, attr.Val)
+		}
+
 		// Process only the children of the html node to avoid duplication
 		for child := htmlNode.FirstChild; child != nil; child = child.NextSibling {
-			processNode(child, htmlElement)
+			processNode(child, htmlElement, expandComments)
 		}
 		
-		// Find the body element in our processed structure
-		for _, child := range htmlElement.Children {
-			if element, ok := dom.AsVElement(child); ok && element.TagName == "body" {
-				bodyElement = element
-				break
-			}
-		}
+		// Find the body element in our processed structure.
+		// Malformed documents may yield several; merge them into the first one in order
+		bodyElement = mergeBodyElements(htmlElement)
 	} else {
 		// If no html element is found, process all children of the document
 		for c := doc.FirstChild; c != nil; c = c.NextSibling {
-			processNode(c, htmlElement)
+			processNode(c, htmlElement, expandComments)
 		}
 	}
 	
@@ -73,7 +87,7 @@ func ParseHTML(htmlContent string, baseURI string) (*dom.VDocument, error) {
 		// If bodyNode was found, process its children
 		if bodyNode != nil {
 			for child := bodyNode.FirstChild; child != nil; child = child.NextSibling {
-				processNode(child, bodyElement)
+				processNode(child, bodyElement, expandComments)
 			}
 		}
 		
@@ -89,9 +103,56 @@ func ParseHTML(htmlContent string, baseURI string) (*dom.VDocument, error) {
 	return vdoc, nil
 }
 
+// mergeBodyElements finds the <body> children of the html element and moves the children
+// of every body after the first into the first one, preserving document order.
+// The emptied bodies are removed. It returns the remaining body, or nil if there is none.
+func mergeBodyElements(htmlElement *dom.VElement) *dom.VElement {
+	var bodyElement *dom.VElement
+	var children []dom.VNode
+	for _, child := range htmlElement.Children {
+		element, ok := dom.AsVElement(child)
+		if !ok || element.TagName != "body" {
+			children = append(children, child)
+			continue
+		}
+		if bodyElement == nil {
+			bodyElement = element
+			children = append(children, child)
+			continue
+		}
+		for _, bodyChild := range element.Children {
+			bodyElement.AppendChild(bodyChild)
+		}
+		element.Children = nil
+		element.SetParent(nil)
+	}
+	htmlElement.Children = children
+	return bodyElement
+}
+
+// ParseFragment parses an HTML fragment in the context of a <body> element
+// and returns the resulting top-level nodes, detached from any parent.
+func ParseFragment(htmlContent string) ([]dom.VNode, error) {
+	context := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(htmlContent), context)
+	if err != nil {
+		return nil, err
+	}
+
+	container := dom.NewVElement("body")
+	for _, node := range nodes {
+		processNode(node, container, false)
+	}
+	for _, child := range container.Children {
+		child.SetParent(nil)
+	}
+	return container.Children, nil
+}
+
 // processNode recursively processes an HTML node and its children,
 // converting them to our virtual DOM structure.
-func processNode(node *html.Node, parent *dom.VElement) {
+// With expandComments, comments holding block-level HTML are converted as their content.
+func processNode(node *html.Node, parent *dom.VElement, expandComments bool) {
 	switch node.Type {
 	case html.ElementNode:
 		// Create a new element
@@ -107,7 +168,7 @@ func processNode(node *html.Node, parent *dom.VElement) {
 		
 		// Process children
 		for child := node.FirstChild; child != nil; child = child.NextSibling {
-			processNode(child, element)
+			processNode(child, element, expandComments)
 		}
 		
 	case html.TextNode:
@@ -118,11 +179,59 @@ func processNode(node *html.Node, parent *dom.VElement) {
 	case html.DocumentNode:
 		// Process children of document node
 		for child := node.FirstChild; child != nil; child = child.NextSibling {
-			processNode(child, parent)
+			processNode(child, parent, expandComments)
 		}
 		
-	// Other node types (comments, etc.) are ignored
+	case html.CommentNode:
+		if expandComments {
+			expandComment(node, parent)
+		}
+
+	// Other node types (doctype, etc.) are ignored
+	}
+}
+
+// blockTags are the elements that make the content of a comment count as commented-out markup.
+var blockTags = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "dd": true,
+	"div": true, "dl": true, "dt": true, "figure": true, "footer": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"header": true, "hr": true, "li": true, "main": true, "nav": true, "ol": true,
+	"p": true, "pre": true, "section": true, "table": true, "ul": true,
+}
+
+// expandComment parses the content of a comment as an HTML fragment and appends the result
+// to parent, if it contains at least one block element. Comments with plain text or only
+// inline markup are left out.
+func expandComment(node *html.Node, parent *dom.VElement) {
+	if !strings.Contains(node.Data, "<") {
+		return
+	}
+	context := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(node.Data), context)
+	if err != nil || !containsBlock(nodes) {
+		return
+	}
+	for _, child := range nodes {
+		processNode(child, parent, true)
+	}
+}
+
+// containsBlock checks whether any of the nodes or their descendants is a block element.
+func containsBlock(nodes []*html.Node) bool {
+	for _, node := range nodes {
+		if node.Type == html.ElementNode && blockTags[strings.ToLower(node.Data)] {
+			return true
+		}
+		var children []*html.Node
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			children = append(children, child)
+		}
+		if containsBlock(children) {
+			return true
+		}
 	}
+	return false
 }
 
 // SerializeToHTML converts a virtual DOM element to an HTML string.
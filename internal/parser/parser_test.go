@@ -133,6 +133,46 @@ func TestParseHTML(t *testing.T) {
 	}
 }
 
+func TestParseHTMLKeepsHTMLAttributes(t *testing.T) {
+	doc, err := ParseHTML(`<html lang="ja" dir="ltr"><body><p>本文</p></body></html>`, "")
+	if err != nil {
+		t.Fatalf("ParseHTML failed: %v", err)
+	}
+	if lang := doc.DocumentElement.GetAttribute("lang"); lang != "ja" {
+		t.Errorf("Expected lang %q, got %q", "ja", lang)
+	}
+	if dir := doc.DocumentElement.GetAttribute("dir"); dir != "ltr" {
+		t.Errorf("Expected dir %q, got %q", "ltr", dir)
+	}
+}
+
+func TestParseFragment(t *testing.T) {
+	nodes, err := ParseFragment(`<p class="intro">Hello <b>world</b></p>text<div>More</div>`)
+	if err != nil {
+		t.Fatalf("Failed to parse fragment: %v", err)
+	}
+
+	if len(nodes) != 3 {
+		t.Fatalf("Expected 3 nodes, got %d", len(nodes))
+	}
+	for _, node := range nodes {
+		if node.Parent() != nil {
+			t.Errorf("Expected top-level nodes to be detached")
+		}
+	}
+
+	p, ok := dom.AsVElement(nodes[0])
+	if !ok || p.TagName != "p" || p.ClassName() != "intro" {
+		t.Errorf("Expected first node to be <p class=\"intro\">, got %v", nodes[0])
+	}
+	if text, ok := dom.AsVText(nodes[1]); !ok || text.TextContent != "text" {
+		t.Errorf("Expected second node to be a text node, got %v", nodes[1])
+	}
+	if got := SerializeToHTML(nodes[2]); got != "<div>More</div>" {
+		t.Errorf("Expected third node to be <div>More</div>, got %s", got)
+	}
+}
+
 func TestSerializeToHTML(t *testing.T) {
 	// Create a test document
 	div := dom.NewVElement("div")
@@ -263,3 +303,73 @@ func TestRoundTrip(t *testing.T) {
 		t.Errorf("Round-trip conversion failed to preserve img element")
 	}
 }
+
+func TestParseHTMLMultipleBodies(t *testing.T) {
+	doc, err := ParseHTML(`<html><head><title>Two bodies</title></head>`+
+		`<body><p>First body.</p></body><body class="injected"><p>Second body.</p></body></html>`, "")
+	if err != nil {
+		t.Fatalf("ParseHTML failed: %v", err)
+	}
+
+	bodies := 0
+	for _, child := range doc.DocumentElement.Children {
+		if element, ok := dom.AsVElement(child); ok && element.TagName == "body" {
+			bodies++
+		}
+	}
+	if bodies != 1 {
+		t.Errorf("Expected 1 body element, got %d", bodies)
+	}
+	if text := dom.GetInnerText(doc.Body, true); text != "First body. Second body." {
+		t.Errorf("Expected the content of both bodies in order, got %q", text)
+	}
+}
+
+func TestMergeBodyElements(t *testing.T) {
+	htmlElement := dom.NewVElement("html")
+	htmlElement.AppendChild(dom.NewVElement("head"))
+	for _, text := range []string{"one", "two", "three"} {
+		body := dom.NewVElement("body")
+		p := dom.NewVElement("p")
+		p.AppendChild(dom.NewVText(text))
+		body.AppendChild(p)
+		htmlElement.AppendChild(body)
+	}
+
+	body := mergeBodyElements(htmlElement)
+	if body == nil {
+		t.Fatal("Expected a body element")
+	}
+	if len(htmlElement.Children) != 2 {
+		t.Errorf("Expected head and a single body, got %d children", len(htmlElement.Children))
+	}
+	if text := dom.GetInnerText(body, true); text != "one two three" {
+		t.Errorf("Expected merged content in order, got %q", text)
+	}
+	for _, child := range body.Children {
+		if child.Parent() != body {
+			t.Error("Expected merged children to belong to the remaining body")
+		}
+	}
+}
+
+func TestParseHTMLWithComments(t *testing.T) {
+	html := `<html><body><p>Before</p><!-- <div class="story"><p>Hidden article</p></div> --><!-- TODO: fix <b>layout</b> --><p>After</p></body></html>`
+
+	doc, err := ParseHTMLWithComments(html, "")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+	expected := `<body><p>Before</p> <div class="story"><p>Hidden article</p></div> <p>After</p></body>`
+	if got := SerializeToHTML(doc.Body); got != expected {
+		t.Errorf("Expected commented block content in place, got %s", got)
+	}
+
+	doc, err = ParseHTML(html, "")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+	if got := SerializeToHTML(doc.Body); got != `<body><p>Before</p><p>After</p></body>` {
+		t.Errorf("Expected comments to be ignored by ParseHTML, got %s", got)
+	}
+}
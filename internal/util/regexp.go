@@ -8,6 +8,19 @@ const DefaultNTopCandidates = 5
 // DefaultCharThreshold は、結果を返すために記事が持つべき最小文字数です。
 const DefaultCharThreshold = 500
 
+// DefaultAncestorDepth は、候補のスコアを伝播させる祖先要素の階層数です。
+const DefaultAncestorDepth = 3
+
+// DefaultTitleMaxLength は、<title>の代わりに<h1>を使う判定の、タイトルの最大文字数です。
+const DefaultTitleMaxLength = 150
+
+// DefaultTitleMinLength は、<title>の代わりに<h1>を使う判定の、タイトルの最小文字数です。
+const DefaultTitleMinLength = 15
+
+// DefaultMaxDepth は、再帰処理（ARIAツリーやMarkdownへの変換）で降りる要素の最大の深さです。
+// これより深い要素は葉として扱います。
+const DefaultMaxDepth = 1000
+
 // DefaultTagsToScore はデフォルトでスコアリングする要素タグです。
 var DefaultTagsToScore = []string{
 	"section", "h2", "h3", "h4", "h5", "h6", "p", "td", "pre",
@@ -27,18 +40,26 @@ var Regexps = struct {
 	// Negative は、コンテンツとして不適切な要素を識別するための正規表現です。
 	Negative *regexp.Regexp
 
-	// Commas は、ラテン語、シンディ語、中国語、その他の様々なスクリプトで使用されるコンマを識別するための正規表現です。
+	// Commas は、ラテン語、シンディ語、中国語、日本語（半角の読点を含む）、その他の様々なスクリプトで使用されるコンマを識別するための正規表現です。
 	Commas *regexp.Regexp
 
 	// Normalize は、空白を正規化するための正規表現です。
 	Normalize *regexp.Regexp
+
+	// Byline は、著者名や日付を含む要素を識別するための正規表現です。
+	Byline *regexp.Regexp
+
+	// Boilerplate は、著作権表示などの法的な定型文を識別するための正規表現です。
+	Boilerplate *regexp.Regexp
 }{
 	UnlikelyCandidates: regexp.MustCompile(`-ad-|ai2html|banner|breadcrumbs|combx|comment|community|cover-wrap|disqus|extra|footer|gdpr|header|legends|menu|related|remark|replies|rss|shoutbox|sidebar|skyscraper|social|sponsor|supplemental|ad-break|agegate|pagination|pager|popup|yom-remote`),
 	OkMaybeItsACandidate: regexp.MustCompile(`and|article|body|column|content|main|shadow`),
 	Positive:             regexp.MustCompile(`article|body|content|entry|hentry|h-entry|main|page|pagination|post|text|blog|story`),
 	Negative:             regexp.MustCompile(`-ad-|hidden|^hid$| hid$| hid |^hid |banner|combx|comment|com-|contact|footer|gdpr|masthead|media|meta|outbrain|promo|related|scroll|share|shoutbox|sidebar|skyscraper|sponsor|shopping|tags|widget`),
-	Commas:               regexp.MustCompile(`,|،|﹐|︐|︑|⹁|⹔|⹒|，|、`),
+	Commas:               regexp.MustCompile(`,|،|﹐|︐|︑|⹁|⸴|⸲|⹔|⹒|，|、|､|፣|᠂|߸|꓾`),
 	Normalize:            regexp.MustCompile(`\s{2,}`),
+	Byline:               regexp.MustCompile(`(?i)byline|author|dateline|writtenby|p-author|pubdate|published`),
+	Boilerplate:          regexp.MustCompile(`(?i)©|\(c\)\s*\d{4}|copyright|all rights reserved|terms of (use|service)|privacy policy|無断転載|著作権`),
 }
 
 // DivToPElems は、hasChildBlockElementで使用される要素のセットです。
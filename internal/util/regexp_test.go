@@ -107,6 +107,13 @@ func TestCommas(t *testing.T) {
 		{"﹐", true},        // U+FE50: SMALL COMMA
 		{"，", true},        // U+FF0C: FULLWIDTH COMMA
 		{"、", true},        // U+3001: IDEOGRAPHIC COMMA
+		{"､", true},        // U+FF64: HALFWIDTH IDEOGRAPHIC COMMA
+		{"⸴", true},        // U+2E34: RAISED COMMA
+		{"፣", true},        // U+1363: ETHIOPIC COMMA
+		{"᠂", true},        // U+1802: MONGOLIAN COMMA
+		{"߸", true},        // U+07F8: NKO COMMA
+		{"꓾", true},        // U+A4FE: LISU PUNCTUATION COMMA
+		{"。", false},       // U+3002: IDEOGRAPHIC FULL STOP
 		{"abc,def", true},  // Contains comma
 		{"abc def", false}, // No comma
 	}
@@ -141,6 +148,51 @@ func TestNormalize(t *testing.T) {
 	}
 }
 
+func TestByline(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"byline", true},
+		{"post-author", true},
+		{"entry-dateline", true},
+		{"Published", true},
+		{"p-author h-card", true},
+		{"content", false},
+		{"navigation", false},
+	}
+
+	for _, test := range tests {
+		result := Regexps.Byline.MatchString(test.input)
+		if result != test.expected {
+			t.Errorf("Byline.MatchString(%q) = %v, expected %v", test.input, result, test.expected)
+		}
+	}
+}
+
+func TestBoilerplate(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"© 2024 Example Corp.", true},
+		{"(c) 2024 Example Corp.", true},
+		{"Copyright Example Corp.", true},
+		{"All Rights Reserved.", true},
+		{"Terms of Use | Privacy Policy", true},
+		{"記事の無断転載を禁じます。", true},
+		{"The court ruled on the copy editor's claim.", false},
+		{"Thanks for reading.", false},
+	}
+
+	for _, test := range tests {
+		result := Regexps.Boilerplate.MatchString(test.input)
+		if result != test.expected {
+			t.Errorf("Boilerplate.MatchString(%q) = %v, expected %v", test.input, result, test.expected)
+		}
+	}
+}
+
 func TestDefaultTagsToScore(t *testing.T) {
 	expected := []string{"section", "h2", "h3", "h4", "h5", "h6", "p", "td", "pre"}
 	if len(DefaultTagsToScore) != len(expected) {
@@ -6,14 +6,257 @@ package readability
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/mackee/go-readability/internal/dom"
+	"github.com/mackee/go-readability/internal/util"
+	"golang.org/x/text/unicode/norm"
 )
 
+// CitationStyle controls how the cite attribute of a blockquote is rendered in Markdown.
+type CitationStyle string
+
+const (
+	// CitationStyleInline appends the source URL as an attribution line inside the quote
+	CitationStyleInline CitationStyle = "inline"
+	// CitationStyleReference appends a reference link inside the quote and lists the URL at the end
+	CitationStyleReference CitationStyle = "reference"
+)
+
+// HighlightStyle controls how highlighted text (<mark>) is rendered in Markdown.
+type HighlightStyle string
+
+const (
+	// HighlightStyleEquals renders highlights as ==text==
+	HighlightStyleEquals HighlightStyle = "equals"
+	// HighlightStyleHTML passes highlights through as <mark>text</mark>
+	HighlightStyleHTML HighlightStyle = "html"
+)
+
+// SVGHandling controls how inline SVG elements are rendered in Markdown.
+type SVGHandling string
+
+const (
+	// SVGHandlingDrop removes all inline SVGs
+	SVGHandlingDrop SVGHandling = "drop"
+	// SVGHandlingAltText renders SVGs that have a <title> or aria-label as a [svg: title] placeholder
+	// and drops decorative ones
+	SVGHandlingAltText SVGHandling = "alttext"
+)
+
+// ImageSizeSyntax controls how the width and height of sized images are kept in Markdown.
+type ImageSizeSyntax string
+
+const (
+	// ImageSizeNone drops image dimensions and always uses ![alt](src)
+	ImageSizeNone ImageSizeSyntax = ""
+	// ImageSizeHTML renders sized images as an HTML <img> tag with width and height
+	ImageSizeHTML ImageSizeSyntax = "html"
+	// ImageSizeAttributes appends a {width=... height=...} attribute block (Pandoc/kramdown style)
+	ImageSizeAttributes ImageSizeSyntax = "attributes"
+)
+
+// KeyboardStyle controls how <kbd>, <samp>, and <var> elements are rendered in Markdown.
+type KeyboardStyle string
+
+const (
+	// KeyboardStyleCode renders the elements as inline code spans
+	KeyboardStyleCode KeyboardStyle = "code"
+	// KeyboardStyleHTML passes the elements through as HTML, e.g. <kbd>Ctrl</kbd>
+	KeyboardStyleHTML KeyboardStyle = "html"
+	// KeyboardStylePlain renders only the text of the elements
+	KeyboardStylePlain KeyboardStyle = "plain"
+)
+
+// QuoteStyle controls how inline quotations (<q>) are rendered in Markdown.
+type QuoteStyle string
+
+const (
+	// QuoteStyleTypographic wraps quotations in curly quotes: “text”, and ‘text’ when nested
+	QuoteStyleTypographic QuoteStyle = "typographic"
+	// QuoteStyleStraight wraps quotations in straight quotes: "text", and 'text' when nested
+	QuoteStyleStraight QuoteStyle = "straight"
+	// QuoteStylePlain renders only the text of quotations
+	QuoteStylePlain QuoteStyle = "plain"
+)
+
+// MarkdownOptions contains configuration options for Markdown conversion.
+type MarkdownOptions struct {
+	// CitationStyle controls how blockquote citations are rendered
+	CitationStyle CitationStyle
+	// HighlightStyle controls how <mark> elements are rendered
+	HighlightStyle HighlightStyle
+	// SVGHandling controls how inline SVG elements are rendered
+	SVGHandling SVGHandling
+	// ImageSizeSyntax controls how the dimensions of images with width/height are kept
+	ImageSizeSyntax ImageSizeSyntax
+	// WrapWidth soft-wraps paragraph text at word boundaries to the given column width.
+	// Headings, lists, quotes, tables and code blocks are left unwrapped. Zero disables wrapping.
+	WrapWidth int
+	// PreserveShortcodes leaves emoji shortcodes such as :thumbs_up: unescaped in text
+	PreserveShortcodes bool
+	// PreserveCodeHTML passes <pre> blocks with syntax highlighting markup (e.g. <span class="...">)
+	// through as raw HTML instead of flattening them to a plain fenced code block
+	PreserveCodeHTML bool
+	// BrToParagraph treats runs of two or more <br> elements as a paragraph break and a
+	// single <br> as a hard line break
+	BrToParagraph bool
+	// KeyboardStyle controls how <kbd>, <samp>, and <var> are rendered (inline code if empty)
+	KeyboardStyle KeyboardStyle
+	// QuoteStyle controls how <q> elements are rendered (typographic quotes if empty)
+	QuoteStyle QuoteStyle
+	// PlainCiteElements renders <cite> elements as their text instead of in italics
+	PlainCiteElements bool
+	// DropAbbrTitles renders <abbr> as its text only, instead of appending the title
+	// in parentheses on the first use of each abbreviation
+	DropAbbrTitles bool
+	// DropTableCaptions omits table <caption> elements instead of rendering them as a bold
+	// line above the table
+	DropTableCaptions bool
+	// StripLinks renders links as their content only, without the URL. Formatting inside
+	// links is kept, and linked images are rendered as their alt text.
+	StripLinks bool
+	// DecodeEntities decodes the HTML entities left in text, image alt and title, abbreviation
+	// titles, and SVG labels (such as &amp; from markup that was encoded twice) before escaping. When false, the entities are output as they are, for
+	// renderers that handle HTML. DefaultMarkdownOptions enables it.
+	DecodeEntities bool
+	// MaxDepth is the maximum element nesting depth descended by the conversion. Deeper
+	// elements are output as their plain text. Zero uses the default of 1000.
+	MaxDepth int
+	// CollapseSrcset uses the URL of the largest srcset candidate as the source of images
+	// that have a srcset, instead of their src
+	CollapseSrcset bool
+	// LineEnding is the line ending of the output: "\n" (default if empty) or "\r\n".
+	// It applies to the whole output, including code blocks.
+	LineEnding string
+	// NormalizeUnicode converts the output to Unicode normalization form C (NFC), composing
+	// characters written as a base and combining marks. ReadabilityOptions.NormalizeUnicode
+	// already normalizes the extracted content.
+	NormalizeUnicode bool
+	// UnwrapLayoutTables renders tables used for page layout (see isLayoutTable) as the
+	// content of their cells, one block after another, instead of as a Markdown table
+	UnwrapLayoutTables bool
+}
+
+// DefaultMarkdownOptions returns a MarkdownOptions struct with default values.
+//
+// Returns:
+//   - A MarkdownOptions struct initialized with default values
+func DefaultMarkdownOptions() MarkdownOptions {
+	return MarkdownOptions{
+		CitationStyle:  CitationStyleInline,   // Attribution line inside the quote
+		HighlightStyle: HighlightStyleEquals,  // ==text==
+		SVGHandling:    SVGHandlingDrop,       // Inline SVGs are removed
+		KeyboardStyle:  KeyboardStyleCode,     // `Ctrl`
+		QuoteStyle:     QuoteStyleTypographic, // “text”
+		DecodeEntities: true,                  // &amp; left in text becomes &
+	}
+}
+
+// markdownState holds the options and the state shared across a single Markdown conversion.
+type markdownState struct {
+	options       MarkdownOptions
+	references    []string        // Reference link URLs, numbered from 1
+	abbreviations map[string]bool // Abbreviations whose title has already been written
+	level         int             // Number of elements being converted, from the root down
+}
+
+// keyboardTags are the elements rendered according to MarkdownOptions.KeyboardStyle.
+var keyboardTags = map[string]bool{
+	"kbd":  true,
+	"samp": true,
+	"var":  true,
+}
+
+// maxDepth returns the configured MaxDepth, defaulting to util.DefaultMaxDepth.
+func (s *markdownState) maxDepth() int {
+	if s.options.MaxDepth <= 0 {
+		return util.DefaultMaxDepth
+	}
+	return s.options.MaxDepth
+}
+
+// escapeText escapes Markdown special characters in text. HTML entities left in the
+// text are decoded first if DecodeEntities is set, and emoji shortcodes are kept verbatim
+// if PreserveShortcodes is set.
+func (s *markdownState) escapeText(text string) string {
+	if s.options.DecodeEntities {
+		text = decodeMarkdownEntities(text)
+	}
+	if s.options.PreserveShortcodes {
+		return escapeMarkdownCharsPreservingShortcodes(text)
+	}
+	return escapeMarkdownChars(text)
+}
+
+// keyboardStyle returns the configured KeyboardStyle, defaulting to KeyboardStyleCode.
+func (s *markdownState) keyboardStyle() KeyboardStyle {
+	if s.options.KeyboardStyle == "" {
+		return KeyboardStyleCode
+	}
+	return s.options.KeyboardStyle
+}
+
+// quoteStyle returns the configured QuoteStyle, defaulting to QuoteStyleTypographic.
+func (s *markdownState) quoteStyle() QuoteStyle {
+	if s.options.QuoteStyle == "" {
+		return QuoteStyleTypographic
+	}
+	return s.options.QuoteStyle
+}
+
+// quoteMarks returns the opening and closing marks for a <q> element. Quotations nested in
+// an odd number of other quotations use single quotes, the others double quotes.
+//
+// Parameters:
+//   - q: The <q> element
+//
+// Returns:
+//   - The opening and closing quote marks, or empty strings with QuoteStylePlain
+func (s *markdownState) quoteMarks(q *dom.VElement) (string, string) {
+	nested := false
+	for ancestor := q.Parent(); ancestor != nil; ancestor = ancestor.Parent() {
+		if ancestor.TagName == "q" {
+			nested = !nested
+		}
+	}
+	switch s.quoteStyle() {
+	case QuoteStylePlain:
+		return "", ""
+	case QuoteStyleStraight:
+		if nested {
+			return "'", "'"
+		}
+		return "\"", "\""
+	}
+	if nested {
+		return "‘", "’"
+	}
+	return "“", "”"
+}
+
+// citation returns the attribution line for a blockquote cited from the given URL.
+//
+// Parameters:
+//   - url: The value of the blockquote's cite attribute
+//
+// Returns:
+//   - The attribution line, without the quote prefix
+func (s *markdownState) citation(url string) string {
+	if s.options.CitationStyle == CitationStyleReference {
+		s.references = append(s.references, url)
+		return fmt.Sprintf("— [source][%d]", len(s.references))
+	}
+	return fmt.Sprintf("— <%s>", url)
+}
+
 // escapeMarkdown escapes Markdown special characters in text.
 // This ensures that special characters like asterisks and underscores are
 // treated as literal characters rather than Markdown formatting.
+// The escaping works on whole runes, so multibyte characters such as emoji
+// (including ZWJ sequences) are never split.
 //
 // Parameters:
 //   - text: The text to escape
@@ -21,7 +264,37 @@ import (
 // Returns:
 //   - The escaped text with Markdown special characters escaped
 func escapeMarkdown(text string) string {
-	// Decode common HTML entities first
+	return escapeMarkdownChars(decodeMarkdownEntities(text))
+}
+
+// escapeMarkdownCharsPreservingShortcodes escapes Markdown special characters in text
+// without decoding entities, leaving emoji shortcodes untouched.
+//
+// Parameters:
+//   - text: The text to escape
+//
+// Returns:
+//   - The escaped text with shortcodes kept verbatim
+func escapeMarkdownCharsPreservingShortcodes(text string) string {
+	var result strings.Builder
+	last := 0
+	for _, loc := range regexp.MustCompile(`:[a-z0-9+\-][a-z0-9_+\-]*:`).FindAllStringIndex(text, -1) {
+		result.WriteString(escapeMarkdownChars(text[last:loc[0]]))
+		result.WriteString(text[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	result.WriteString(escapeMarkdownChars(text[last:]))
+	return result.String()
+}
+
+// decodeMarkdownEntities decodes the common HTML entities left in text.
+//
+// Parameters:
+//   - text: The text to decode
+//
+// Returns:
+//   - The text with the entities replaced by their characters
+func decodeMarkdownEntities(text string) string {
 	decodedText := text
 	decodedText = strings.ReplaceAll(decodedText, "&amp;", "&")
 	decodedText = strings.ReplaceAll(decodedText, "&lt;", "<")
@@ -29,10 +302,197 @@ func escapeMarkdown(text string) string {
 	decodedText = strings.ReplaceAll(decodedText, "&quot;", "\"")
 	decodedText = strings.ReplaceAll(decodedText, "&#039;", "'")
 	decodedText = strings.ReplaceAll(decodedText, "&nbsp;", " ")
+	return decodedText
+}
 
-	// Escape Markdown special characters
+// escapeMarkdownChars backslash-escapes the Markdown special characters *, _, [, ], \ and `.
+//
+// Parameters:
+//   - text: The decoded text to escape
+//
+// Returns:
+//   - The escaped text
+func escapeMarkdownChars(text string) string {
 	re := regexp.MustCompile(`([*_\[\]\\` + "`" + `])`)
-	return re.ReplaceAllString(decodedText, `\$1`)
+	return re.ReplaceAllString(text, `\$1`)
+}
+
+// formatTableCell fits the Markdown of a table cell on a single table row: runs of whitespace,
+// including line breaks, are collapsed to single spaces, the content is trimmed, and pipes
+// that would split the cell are escaped. Pipes already escaped by a backslash are kept as is.
+//
+// Parameters:
+//   - markdown: The Markdown of the cell content
+//
+// Returns:
+//   - The cell content for a Markdown table row
+func formatTableCell(markdown string) string {
+	cell := strings.Join(strings.Fields(markdown), " ")
+
+	var result strings.Builder
+	backslashes := 0
+	for _, r := range cell {
+		if r == '|' && backslashes%2 == 0 {
+			result.WriteRune('\\')
+		}
+		if r == '\\' {
+			backslashes++
+		} else {
+			backslashes = 0
+		}
+		result.WriteRune(r)
+	}
+	return result.String()
+}
+
+// isLayoutTable checks whether a table lays out the page rather than holding data. Tables
+// with role="presentation" or role="none" are layout tables, and tables with header cells,
+// a <thead>, or a caption are data tables. Otherwise a table is used for layout if it has a
+// single column, or if a cell holds block content such as paragraphs or lists.
+//
+// Parameters:
+//   - table: The <table> element
+//
+// Returns:
+//   - true if the table should be unwrapped into the content of its cells
+func isLayoutTable(table *dom.VElement) bool {
+	switch strings.ToLower(strings.TrimSpace(table.GetAttribute("role"))) {
+	case "presentation", "none":
+		return true
+	}
+
+	for _, child := range table.Children {
+		if childElement, ok := dom.AsVElement(child); ok && (childElement.TagName == "caption" || childElement.TagName == "thead") {
+			return false
+		}
+	}
+
+	cells := tableCells(table)
+	singleColumn := true
+	hasBlockContent := false
+	for _, row := range cells {
+		if len(row) > 1 {
+			singleColumn = false
+		}
+		for _, cell := range row {
+			if cell.TagName == "th" {
+				return false
+			}
+			for _, descendant := range GetElementsByTagName(cell, "*") {
+				if markdownBlockTags[descendant.TagName] {
+					hasBlockContent = true
+				}
+			}
+		}
+	}
+	return len(cells) > 0 && (singleColumn || hasBlockContent)
+}
+
+// tableCells returns the cells of a table by row, including the rows in <thead>, <tbody>,
+// and <tfoot> but not those of nested tables.
+func tableCells(table *dom.VElement) [][]*dom.VElement {
+	var rows [][]*dom.VElement
+	var collect func(element *dom.VElement)
+	collect = func(element *dom.VElement) {
+		for _, child := range element.Children {
+			childElement, ok := dom.AsVElement(child)
+			if !ok {
+				continue
+			}
+			switch childElement.TagName {
+			case "thead", "tbody", "tfoot":
+				collect(childElement)
+			case "tr":
+				var row []*dom.VElement
+				for _, cell := range childElement.Children {
+					if cellElement, ok := dom.AsVElement(cell); ok && (cellElement.TagName == "td" || cellElement.TagName == "th") {
+						row = append(row, cellElement)
+					}
+				}
+				rows = append(rows, row)
+			}
+		}
+	}
+	collect(table)
+	return rows
+}
+
+// layoutTableMarkdown renders a layout table as the content of its caption and cells, in
+// document order, each as a separate block.
+//
+// Parameters:
+//   - table: The layout table
+//   - depth: The list nesting depth of the table
+//   - state: The state of the conversion
+//
+// Returns:
+//   - The Markdown of the cells, or an empty string if they have no content
+func layoutTableMarkdown(table *dom.VElement, depth int, state *markdownState) string {
+	var cells []*dom.VElement
+	for _, child := range table.Children {
+		if childElement, ok := dom.AsVElement(child); ok && childElement.TagName == "caption" {
+			cells = append(cells, childElement)
+		}
+	}
+	for _, row := range tableCells(table) {
+		cells = append(cells, row...)
+	}
+
+	var blocks []string
+	for _, cell := range cells {
+		if block := strings.TrimSpace(convertNodeToMarkdown(cell, "table", depth, false, state)); block != "" {
+			blocks = append(blocks, block)
+		}
+	}
+	if len(blocks) == 0 {
+		return ""
+	}
+	return strings.Join(blocks, "\n\n") + "\n\n"
+}
+
+// appendLineBreak ends the last non-empty Markdown part with the break for a run of <br> elements:
+// a hard line break for a single <br>, and a paragraph break for two or more.
+// Runs without a preceding part are dropped.
+//
+// Parameters:
+//   - parts: The Markdown parts converted so far; the last non-empty one is modified in place
+//   - count: The number of consecutive <br> elements
+func appendLineBreak(parts []string, count int) {
+	lineBreak := "  \n"
+	if count >= 2 {
+		lineBreak = "\n\n"
+	}
+	for i := len(parts) - 1; i >= 0; i-- {
+		if strings.TrimSpace(parts[i]) != "" {
+			parts[i] = strings.TrimRight(parts[i], " \t\n") + lineBreak
+			return
+		}
+	}
+}
+
+// appendToLastPart appends Markdown to the last non-empty part without a separating space,
+// unless either side has whitespace at the joint.
+//
+// Parameters:
+//   - parts: The Markdown parts converted so far; the last non-empty one is modified in place
+//   - markdown: The Markdown to append
+//
+// Returns:
+//   - true if the Markdown was appended, false if it should be added as a separate part
+func appendToLastPart(parts []string, markdown string) bool {
+	if strings.TrimSpace(markdown) == "" || strings.TrimLeft(markdown, " \t\n") != markdown {
+		return false
+	}
+	for i := len(parts) - 1; i >= 0; i-- {
+		if strings.TrimSpace(parts[i]) != "" {
+			if strings.TrimRight(parts[i], " \t\n") != parts[i] {
+				return false
+			}
+			parts[i] += markdown
+			return true
+		}
+	}
+	return false
 }
 
 // joinMarkdownParts joins an array of markdown strings, adding spaces where needed between inline elements/text.
@@ -114,20 +574,24 @@ func getAllTextContent(node dom.VNode) string {
 //   - parentTagName: The tag name of the parent node
 //   - depth: The current depth in the document tree
 //   - isFirstChild: Whether this node is the first child of its parent
+//   - state: The conversion options and state shared across the whole conversion
 //
 // Returns:
 //   - A Markdown string representation of the node
-func convertNodeToMarkdown(node dom.VNode, parentTagName string, depth int, isFirstChild bool) string {
+func convertNodeToMarkdown(node dom.VNode, parentTagName string, depth int, isFirstChild bool, state *markdownState) string {
 	if textNode, ok := dom.AsVText(node); ok {
 		if parentTagName == "pre" || parentTagName == "code" {
 			return textNode.TextContent // Keep raw text
 		}
+		if keyboardTags[parentTagName] && state.keyboardStyle() == KeyboardStyleCode {
+			return textNode.TextContent // Raw text inside a code span
+		}
 		// Replace sequences of space/tab with a single space
 		text := regexp.MustCompile(`[ \t]+`).ReplaceAllString(textNode.TextContent, " ")
 		if text == "" {
 			return ""
 		}
-		return escapeMarkdown(text)
+		return state.escapeText(text)
 	}
 
 	elementNode, ok := dom.AsVElement(node)
@@ -135,25 +599,76 @@ func convertNodeToMarkdown(node dom.VNode, parentTagName string, depth int, isFi
 		return ""
 	}
 
+	// Beyond the maximum depth, the element is a leaf: only its text is kept
+	if state.level >= state.maxDepth() {
+		return state.escapeText(GetInnerText(elementNode, true))
+	}
+	state.level++
+	defer func() { state.level-- }()
+
 	tagName := strings.ToLower(elementNode.TagName)
 
+	// Footnote definitions collected by ReadabilityOptions.ResolveFootnotes
+	if tagName == "ol" && elementNode.HasAttribute("data-footnotes") {
+		return footnoteDefinitionsMarkdown(elementNode, state)
+	}
+
 	// Check if element is block
-	isBlock := map[string]bool{
-		"p": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
-		"ul": true, "ol": true, "li": true, "pre": true, "blockquote": true, "hr": true,
-		"table": true, "div": true,
-	}[tagName]
+	isBlock := markdownBlockTags[tagName]
 
 	// Process children, store results in an array
 	childrenResults := []string{}
+	brRun := 0 // Number of consecutive <br> elements before the current child
+	afterBreak := false
+	afterWordBreak := false // The previous child was a <wbr>
 	for i, child := range elementNode.Children {
+		// <wbr> is only a line break opportunity: join the text around it without a space
+		if childElement, ok := dom.AsVElement(child); ok && childElement.TagName == "wbr" {
+			afterWordBreak = true
+			continue
+		}
+
+		if state.options.BrToParagraph {
+			if childElement, ok := dom.AsVElement(child); ok && strings.ToLower(childElement.TagName) == "br" {
+				brRun++
+				continue
+			}
+			if textNode, ok := dom.AsVText(child); ok && brRun > 0 && strings.TrimSpace(textNode.TextContent) == "" {
+				continue
+			}
+			if brRun > 0 {
+				appendLineBreak(childrenResults, brRun)
+				brRun = 0
+				afterBreak = true
+			}
+		}
+
 		isCurrentChildFirst := i == 0
 		childResult := convertNodeToMarkdown(child, tagName, func() int {
 			if tagName == "ul" || tagName == "ol" || tagName == "blockquote" {
 				return depth + 1
 			}
 			return depth
-		}(), isCurrentChildFirst)
+		}(), isCurrentChildFirst, state)
+		if afterBreak {
+			// The new line starts right after the break, without the source indentation
+			childResult = strings.TrimLeft(childResult, " \t\n")
+			afterBreak = false
+		}
+		if afterWordBreak {
+			afterWordBreak = false
+			if appendToLastPart(childrenResults, childResult) {
+				continue
+			}
+		}
+		// Footnote references follow the word they annotate without a space
+		if isFootnoteRefNode(child) && appendToLastPart(childrenResults, childResult) {
+			continue
+		}
+		// A block after inline content, such as a paragraph after an image, starts a new block
+		if !listTags[tagName] && isMarkdownBlockNode(child) {
+			endLastPartWithBlankLine(childrenResults)
+		}
 		childrenResults = append(childrenResults, childResult)
 	}
 
@@ -163,6 +678,11 @@ func convertNodeToMarkdown(node dom.VNode, parentTagName string, depth int, isFi
 	// Trim children's markdown for block elements
 	trimmedChildren := strings.TrimSpace(childrenMarkdown)
 
+	// A heading without content would render as a bare "## "
+	if trimmedChildren == "" && slices.Contains(headingTags, tagName) {
+		return ""
+	}
+
 	switch tagName {
 	// Headings
 	case "h1":
@@ -184,46 +704,72 @@ func convertNodeToMarkdown(node dom.VNode, parentTagName string, depth int, isFi
 		}
 		return fmt.Sprintf("%s\n\n", trimmedChildren)
 
+	// Collapsible sections: the summary becomes a bold lead-in for the details body
+	case "summary":
+		if trimmedChildren == "" {
+			return ""
+		}
+		return fmt.Sprintf("**%s**\n\n", trimmedChildren)
+
 	// Inline elements
 	case "strong", "b":
 		return fmt.Sprintf("**%s**", childrenMarkdown)
 	case "em", "i":
 		return fmt.Sprintf("*%s*", childrenMarkdown)
+	case "mark":
+		if strings.TrimSpace(childrenMarkdown) == "" {
+			return childrenMarkdown
+		}
+		if state.options.HighlightStyle == HighlightStyleHTML {
+			return fmt.Sprintf("<mark>%s</mark>", childrenMarkdown)
+		}
+		return fmt.Sprintf("==%s==", childrenMarkdown)
+	case "kbd", "samp", "var":
+		if strings.TrimSpace(childrenMarkdown) == "" {
+			return childrenMarkdown
+		}
+		switch state.keyboardStyle() {
+		case KeyboardStyleHTML:
+			return fmt.Sprintf("<%s>%s</%s>", tagName, childrenMarkdown, tagName)
+		case KeyboardStylePlain:
+			return childrenMarkdown
+		}
+		// Key combinations such as <kbd><kbd>Ctrl</kbd>+<kbd>C</kbd></kbd> keep one span per key
+		// (the element itself is included in the result, hence > 1)
+		if len(dom.GetElementsByTagNames(elementNode, []string{"kbd", "samp", "var"})) > 1 {
+			return childrenMarkdown
+		}
+		return inlineCode(childrenMarkdown)
+	case "q":
+		if strings.TrimSpace(childrenMarkdown) == "" {
+			return childrenMarkdown
+		}
+		opening, closing := state.quoteMarks(elementNode)
+		return opening + strings.TrimSpace(childrenMarkdown) + closing
+	case "cite":
+		// A citation inside italic text is not emphasized again: ** would turn it bold
+		if state.options.PlainCiteElements || parentTagName == "em" || parentTagName == "i" ||
+			strings.TrimSpace(childrenMarkdown) == "" {
+			return childrenMarkdown
+		}
+		return fmt.Sprintf("*%s*", strings.TrimSpace(childrenMarkdown))
+	case "abbr":
+		title := strings.Join(strings.Fields(elementNode.GetAttribute("title")), " ")
+		text := strings.TrimSpace(childrenMarkdown)
+		if state.options.DropAbbrTitles || title == "" || text == "" || strings.EqualFold(title, text) {
+			return childrenMarkdown
+		}
+		if state.abbreviations[text] {
+			return childrenMarkdown
+		}
+		if state.abbreviations == nil {
+			state.abbreviations = make(map[string]bool)
+		}
+		state.abbreviations[text] = true
+		return fmt.Sprintf("%s (%s)", childrenMarkdown, state.escapeText(title))
 	case "code":
 		if parentTagName != "pre" {
-			// Inline code
-			codeContent := childrenMarkdown
-
-			// Find all backtick sequences to determine delimiter
-			backtickRe := regexp.MustCompile("`+")
-			backtickMatches := backtickRe.FindAllString(codeContent, -1)
-			longestSequence := 0
-			for _, match := range backtickMatches {
-				if len(match) > longestSequence {
-					longestSequence = len(match)
-				}
-			}
-			delimiter := strings.Repeat("`", longestSequence+1)
-
-			// Check if content consists only of backticks
-			onlyBackticksRe := regexp.MustCompile("^`+$")
-			if onlyBackticksRe.MatchString(codeContent) && len(codeContent) >= len(delimiter) {
-				delimiter = strings.Repeat("`", len(codeContent)+1)
-			}
-
-			// Determine if padding is needed
-			startsOrEndsWithBacktick := strings.HasPrefix(codeContent, "`") || strings.HasSuffix(codeContent, "`")
-			consistsOnlyOfBackticks := onlyBackticksRe.MatchString(codeContent)
-			isEmptyOrWhitespace := strings.TrimSpace(codeContent) == ""
-			needsPadding := startsOrEndsWithBacktick || consistsOnlyOfBackticks || isEmptyOrWhitespace
-
-			// Apply padding if needed
-			finalContent := codeContent
-			if needsPadding {
-				finalContent = " " + codeContent + " "
-			}
-
-			return delimiter + finalContent + delimiter
+			return bidiIsolate(elementNode, inlineCode(childrenMarkdown))
 		}
 		// Code inside pre: Return raw content
 		return childrenMarkdown
@@ -238,6 +784,19 @@ func convertNodeToMarkdown(node dom.VNode, parentTagName string, depth int, isFi
 			return nil
 		}()
 
+		// Keep syntax highlighting markup as raw HTML when requested
+		if state.options.PreserveCodeHTML {
+			codeRoot := elementNode
+			if codeChild != nil {
+				codeRoot = codeChild
+			}
+			for _, child := range codeRoot.Children {
+				if _, ok := dom.AsVElement(child); ok {
+					return SerializeToHTML(elementNode)
+				}
+			}
+		}
+
 		// Get all text content recursively
 		rawCodeContent := ""
 		if codeChild != nil {
@@ -272,6 +831,11 @@ func convertNodeToMarkdown(node dom.VNode, parentTagName string, depth int, isFi
 		if content == "" {
 			return ""
 		}
+		if cite := strings.TrimSpace(elementNode.GetAttribute("cite")); cite != "" {
+			content += "\n\n" + state.citation(cite)
+		}
+		// Collapse block spacing before quoting, as it cannot be normalized afterwards
+		content = regexp.MustCompile(`\n{3,}`).ReplaceAllString(content, "\n\n")
 		lines := strings.Split(content, "\n")
 		quotedLines := []string{}
 		for _, line := range lines {
@@ -288,7 +852,7 @@ func convertNodeToMarkdown(node dom.VNode, parentTagName string, depth int, isFi
 		listItems := []string{}
 		for _, child := range elementNode.Children {
 			if childElement, ok := dom.AsVElement(child); ok && strings.ToLower(childElement.TagName) == "li" {
-				childResult := convertNodeToMarkdown(childElement, tagName, depth+1, false)
+				childResult := convertNodeToMarkdown(childElement, tagName, depth+1, false, state)
 				if strings.TrimSpace(childResult) != "" {
 					listItems = append(listItems, childResult)
 				}
@@ -333,20 +897,22 @@ func convertNodeToMarkdown(node dom.VNode, parentTagName string, depth int, isFi
 			if childElement, ok := dom.AsVElement(child); ok {
 				childTagName := strings.ToLower(childElement.TagName)
 				if childTagName == "ul" || childTagName == "ol" {
-					nestedListMd := convertNodeToMarkdown(childElement, tagName, depth+1, false)
+					nestedListMd := convertNodeToMarkdown(childElement, tagName, depth+1, false, state)
 					if nestedListMd != "" {
 						nestedListParts = append(nestedListParts, regexp.MustCompile(`\n+$`).ReplaceAllString(nestedListMd, ""))
 					}
 				} else {
-					mainContentParts = append(mainContentParts, convertNodeToMarkdown(childElement, tagName, depth, false))
+					mainContentParts = append(mainContentParts, convertNodeToMarkdown(childElement, tagName, depth, false, state))
 				}
 			} else {
-				mainContentParts = append(mainContentParts, convertNodeToMarkdown(child, tagName, depth, false))
+				mainContentParts = append(mainContentParts, convertNodeToMarkdown(child, tagName, depth, false, state))
 			}
 		}
 
 		// Join main content parts and trim
 		mainContent := strings.TrimSpace(joinMarkdownParts(mainContentParts))
+		// Indent continuation lines (paragraphs, rules) so that they stay inside the item
+		mainContent = indentContinuationLines(mainContent, strings.Repeat(" ", len(marker)+1))
 
 		// Format: Marker + Space + Content
 		result := fmt.Sprintf("%s %s", marker, mainContent)
@@ -366,6 +932,15 @@ func convertNodeToMarkdown(node dom.VNode, parentTagName string, depth int, isFi
 		// Clean link content
 		linkContent := strings.TrimSpace(strings.ReplaceAll(childrenMarkdown, "\n", " "))
 
+		if label := elementNode.GetAttribute("data-footnote-ref"); label != "" {
+			return fmt.Sprintf("[^%s]", label)
+		}
+
+		// Plain text links keep their content (with its formatting and image alt texts) only
+		if state.options.StripLinks {
+			return linkContent
+		}
+
 		// Special handling for image links
 		if len(elementNode.Children) == 1 {
 			if childElement, ok := dom.AsVElement(elementNode.Children[0]); ok && strings.ToLower(childElement.TagName) == "img" {
@@ -383,44 +958,79 @@ func convertNodeToMarkdown(node dom.VNode, parentTagName string, depth int, isFi
 		}
 
 		// Regular link
-		return fmt.Sprintf("[%s](%s)", linkContent, href)
+		return bidiIsolate(elementNode, fmt.Sprintf("[%s](%s)", linkContent, href))
+
+	case "bdi":
+		return bidiIsolate(elementNode, childrenMarkdown)
 
 	case "img":
-		alt := escapeMarkdown(elementNode.Attributes["alt"])
+		alt := state.escapeText(elementNode.Attributes["alt"])
 		src := elementNode.Attributes["src"]
+		if state.options.CollapseSrcset {
+			if best := bestSrcsetURL(elementNode.Attributes["srcset"]); best != "" {
+				src = best
+			}
+		}
 		title := ""
 		if titleAttr, ok := elementNode.Attributes["title"]; ok && titleAttr != "" {
-			title = fmt.Sprintf(` "%s"`, escapeMarkdown(titleAttr))
+			title = fmt.Sprintf(` "%s"`, state.escapeText(titleAttr))
 		}
 
-		// If parent is an anchor, just return alt or src
+		// If parent is an anchor, just return alt or src (or nothing when links are stripped)
 		if parentTagName == "a" {
-			if strings.TrimSpace(alt) != "" {
-				return alt
+			if strings.TrimSpace(alt) != "" || state.options.StripLinks {
+				return strings.TrimSpace(alt)
 			}
 			return src
 		}
 
+		// Sized image
+		width := strings.TrimSpace(elementNode.Attributes["width"])
+		height := strings.TrimSpace(elementNode.Attributes["height"])
+		if width != "" || height != "" {
+			switch state.options.ImageSizeSyntax {
+			case ImageSizeHTML:
+				return imageHTML(elementNode, src, width, height)
+			case ImageSizeAttributes:
+				var attrs []string
+				if width != "" {
+					attrs = append(attrs, "width="+width)
+				}
+				if height != "" {
+					attrs = append(attrs, "height="+height)
+				}
+				return fmt.Sprintf("![%s](%s%s){%s}", alt, src, title, strings.Join(attrs, " "))
+			}
+		}
+
 		// Regular image
 		return fmt.Sprintf("![%s](%s%s)", alt, src, title)
 
 	case "hr":
-		return "---\n\n"
+		// Always a block of its own: a rule right after a text line would make it a setext heading.
+		// Inside blockquotes and list items, the quote prefix and item indentation keep it in context.
+		return "\n\n---\n\n"
 
 	case "br":
 		return "  \n"
 
 	case "table":
+		if state.options.UnwrapLayoutTables && isLayoutTable(elementNode) {
+			return layoutTableMarkdown(elementNode, depth, state)
+		}
+
 		var headerRow []string
 		var bodyRows [][]string
 		maxColumns := 0
 
-		// Find thead and tbody
-		var thead, tbody *dom.VElement
+		// Find caption, thead and tbody
+		var caption, thead, tbody *dom.VElement
 		for _, child := range elementNode.Children {
 			if childElement, ok := dom.AsVElement(child); ok {
 				childTagName := strings.ToLower(childElement.TagName)
 				switch childTagName {
+				case "caption":
+					caption = childElement
 				case "thead":
 					thead = childElement
 				case "tbody":
@@ -431,7 +1041,7 @@ func convertNodeToMarkdown(node dom.VNode, parentTagName string, depth int, isFi
 
 		// Process cell content
 		processCell := func(cell *dom.VElement) string {
-			return strings.TrimSpace(convertNodeToMarkdown(cell, strings.ToLower(cell.TagName), depth+1, false))
+			return formatTableCell(convertNodeToMarkdown(cell, strings.ToLower(cell.TagName), depth+1, false, state))
 		}
 
 		// Process header row
@@ -501,14 +1111,29 @@ func convertNodeToMarkdown(node dom.VNode, parentTagName string, depth int, isFi
 		}
 
 		if tableMd.Len() > 0 {
+			// The caption goes on a bold line above the table
+			if caption != nil && !state.options.DropTableCaptions {
+				if captionMd := processCell(caption); captionMd != "" {
+					return "**" + captionMd + "**\n\n" + strings.TrimSpace(tableMd.String()) + "\n\n"
+				}
+			}
 			return strings.TrimSpace(tableMd.String()) + "\n\n"
 		}
 		return ""
 
+	case "svg":
+		if state.options.SVGHandling != SVGHandlingAltText {
+			return ""
+		}
+		if label := svgLabel(elementNode); label != "" {
+			return fmt.Sprintf("[svg: %s]", state.escapeText(label))
+		}
+		return ""
+
 	// Ignored tags
 	case "script", "style", "nav", "aside", "header", "footer", "form",
 		"button", "iframe", "object", "embed", "applet", "link", "meta",
-		"title", "svg":
+		"title":
 		return ""
 
 	// Default: Render children for unknown/other tags
@@ -524,6 +1149,414 @@ func convertNodeToMarkdown(node dom.VNode, parentTagName string, depth int, isFi
 	}
 }
 
+// markdownBlockTags are the elements rendered as blocks separated by blank lines.
+var markdownBlockTags = map[string]bool{
+	"p": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"ul": true, "ol": true, "li": true, "pre": true, "blockquote": true, "hr": true,
+	"table": true, "div": true, "details": true, "figure": true, "figcaption": true,
+}
+
+// listTags are the elements whose blocks (list items and sublists) are laid out by their
+// own case rather than separated by blank lines.
+var listTags = map[string]bool{"ul": true, "ol": true, "li": true, "dl": true, "dd": true, "dt": true}
+
+// isMarkdownBlockNode checks whether a node is an element rendered as a block.
+func isMarkdownBlockNode(node dom.VNode) bool {
+	element, ok := dom.AsVElement(node)
+	return ok && markdownBlockTags[strings.ToLower(element.TagName)]
+}
+
+// endLastPartWithBlankLine ends the last non-blank part with a blank line, unless it already
+// ends with one, so that the block appended next does not continue its line.
+// This keeps media and text that precede a block, in source order, on a line of their own.
+//
+// Parameters:
+//   - parts: The Markdown parts converted so far, modified in place
+func endLastPartWithBlankLine(parts []string) {
+	for i := len(parts) - 1; i >= 0; i-- {
+		if strings.TrimSpace(parts[i]) == "" {
+			continue
+		}
+		if !strings.HasSuffix(strings.TrimRight(parts[i], " \t"), "\n\n") {
+			parts[i] = strings.TrimRight(parts[i], " \t\n") + "\n\n"
+		}
+		return
+	}
+}
+
+// isFootnoteRefNode checks whether a node is a footnote reference marked by
+// ReadabilityOptions.ResolveFootnotes: a link with data-footnote-ref, or a <sup> around one.
+func isFootnoteRefNode(node dom.VNode) bool {
+	element, ok := dom.AsVElement(node)
+	if !ok {
+		return false
+	}
+	if element.TagName == "sup" && len(element.Children) == 1 {
+		element, ok = dom.AsVElement(element.Children[0])
+		if !ok {
+			return false
+		}
+	}
+	return element.TagName == "a" && element.HasAttribute("data-footnote-ref")
+}
+
+// footnoteDefinitionsMarkdown renders the footnote definitions collected by
+// ReadabilityOptions.ResolveFootnotes as [^1]: text lines. The continuation lines of
+// definitions with several paragraphs are indented by four spaces.
+//
+// Parameters:
+//   - list: The <ol data-footnotes> element
+//   - state: The conversion state
+//
+// Returns:
+//   - The Markdown of the definitions, as a block
+func footnoteDefinitionsMarkdown(list *dom.VElement, state *markdownState) string {
+	var definitions []string
+	for _, child := range list.Children {
+		item, ok := dom.AsVElement(child)
+		if !ok || item.TagName != "li" {
+			continue
+		}
+		parts := make([]string, 0, len(item.Children))
+		for i, itemChild := range item.Children {
+			parts = append(parts, convertNodeToMarkdown(itemChild, "li", 0, i == 0, state))
+		}
+		content := strings.TrimSpace(joinMarkdownParts(parts))
+		definitions = append(definitions, fmt.Sprintf("[^%s]: %s", item.GetAttribute("data-footnote"),
+			indentContinuationLines(content, "    ")))
+	}
+	if len(definitions) == 0 {
+		return ""
+	}
+	return strings.Join(definitions, "\n") + "\n\n"
+}
+
+// indentContinuationLines indents every non-empty line of a Markdown block except the first.
+//
+// Parameters:
+//   - content: The Markdown block
+//   - indent: The indentation to prepend
+//
+// Returns:
+//   - The indented block
+func indentContinuationLines(content, indent string) string {
+	lines := strings.Split(content, "\n")
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) != "" {
+			lines[i] = indent + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// bidiIsolate wraps the Markdown of an inline element with a dir attribute, or of a <bdi>,
+// in the Unicode isolate characters matching its direction (LRI, RLI, or FSI, closed by
+// PDI). Markdown has no dir attribute, so this keeps a URL or code span inside
+// right-to-left text from being reordered by it.
+//
+// Parameters:
+//   - element: The inline element
+//   - markdown: The Markdown of the element
+//
+// Returns:
+//   - The isolated Markdown, or markdown as is for elements without a direction
+func bidiIsolate(element *dom.VElement, markdown string) string {
+	if strings.TrimSpace(markdown) == "" {
+		return markdown
+	}
+	switch declaredDirection(element) {
+	case dirLTR:
+		return "\u2066" + markdown + "\u2069"
+	case dirRTL:
+		return "\u2067" + markdown + "\u2069"
+	}
+	if element.TagName == "bdi" {
+		return "\u2068" + markdown + "\u2069"
+	}
+	return markdown
+}
+
+// inlineCode wraps text in a Markdown code span, choosing a backtick delimiter
+// longer than any backtick run in the text and padding it where needed.
+//
+// Parameters:
+//   - codeContent: The raw code text
+//
+// Returns:
+//   - The code span
+func inlineCode(codeContent string) string {
+	// Find all backtick sequences to determine delimiter
+	backtickRe := regexp.MustCompile("`+")
+	backtickMatches := backtickRe.FindAllString(codeContent, -1)
+	longestSequence := 0
+	for _, match := range backtickMatches {
+		if len(match) > longestSequence {
+			longestSequence = len(match)
+		}
+	}
+	delimiter := strings.Repeat("`", longestSequence+1)
+
+	// Check if content consists only of backticks
+	onlyBackticksRe := regexp.MustCompile("^`+$")
+	if onlyBackticksRe.MatchString(codeContent) && len(codeContent) >= len(delimiter) {
+		delimiter = strings.Repeat("`", len(codeContent)+1)
+	}
+
+	// Determine if padding is needed
+	startsOrEndsWithBacktick := strings.HasPrefix(codeContent, "`") || strings.HasSuffix(codeContent, "`")
+	consistsOnlyOfBackticks := onlyBackticksRe.MatchString(codeContent)
+	isEmptyOrWhitespace := strings.TrimSpace(codeContent) == ""
+	needsPadding := startsOrEndsWithBacktick || consistsOnlyOfBackticks || isEmptyOrWhitespace
+
+	// Apply padding if needed
+	finalContent := codeContent
+	if needsPadding {
+		finalContent = " " + codeContent + " "
+	}
+
+	return delimiter + finalContent + delimiter
+}
+
+// svgLabel returns the accessible name of an inline SVG element, taken from its
+// aria-label attribute or its <title> child. Decorative SVGs (aria-hidden="true")
+// have no label.
+//
+// Parameters:
+//   - svg: The svg element
+//
+// Returns:
+//   - The normalized label, or an empty string if the SVG has none
+func svgLabel(svg *dom.VElement) string {
+	if svg.GetAttribute("aria-hidden") == "true" {
+		return ""
+	}
+	if label := strings.Join(strings.Fields(svg.GetAttribute("aria-label")), " "); label != "" {
+		return label
+	}
+	for _, child := range svg.Children {
+		if title, ok := dom.AsVElement(child); ok && strings.ToLower(title.TagName) == "title" {
+			return strings.Join(strings.Fields(getAllTextContent(title)), " ")
+		}
+	}
+	return ""
+}
+
+// imageHTML renders an image as an HTML <img> tag that keeps its dimensions.
+//
+// Parameters:
+//   - img: The img element
+//   - src: The source URL of the image
+//   - width: The width attribute, or an empty string
+//   - height: The height attribute, or an empty string
+//
+// Returns:
+//   - The HTML <img> tag
+func imageHTML(img *dom.VElement, src, width, height string) string {
+	var tag strings.Builder
+	tag.WriteString("<img")
+	for _, attr := range []struct{ name, value string }{
+		{"src", src},
+		{"alt", img.Attributes["alt"]},
+		{"title", img.Attributes["title"]},
+		{"width", width},
+		{"height", height},
+	} {
+		if attr.value != "" || attr.name == "alt" {
+			fmt.Fprintf(&tag, ` %s="%s"`, attr.name, escapeHTML(attr.value))
+		}
+	}
+	tag.WriteString(">")
+	return tag.String()
+}
+
+// splitMarkdownAtoms splits a line of Markdown into words that must not be broken apart.
+// Spaces inside links, images, inline code, and autolinks do not split words.
+//
+// Parameters:
+//   - line: The line to split
+//
+// Returns:
+//   - The words of the line, without the separating spaces
+func splitMarkdownAtoms(line string) []string {
+	var atoms []string
+	var current strings.Builder
+	runes := []rune(line)
+	bracketDepth := 0 // Nesting depth of [link text]
+	parenDepth := 0   // Nesting depth of (link destination)
+	inAutolink := false
+	codeDelimiter := 0 // Length of the backtick run that opened inline code
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		if codeDelimiter > 0 {
+			current.WriteRune(r)
+			if r == '`' {
+				run := 1
+				for i+run < len(runes) && runes[i+run] == '`' {
+					current.WriteRune('`')
+					run++
+				}
+				i += run - 1
+				if run == codeDelimiter {
+					codeDelimiter = 0
+				}
+			}
+			continue
+		}
+
+		switch {
+		case r == '\\' && i+1 < len(runes):
+			current.WriteRune(r)
+			current.WriteRune(runes[i+1])
+			i++
+			continue
+		case r == '`':
+			run := 1
+			for i+run < len(runes) && runes[i+run] == '`' {
+				run++
+			}
+			current.WriteString(strings.Repeat("`", run))
+			i += run - 1
+			codeDelimiter = run
+			continue
+		case r == '[':
+			bracketDepth++
+		case r == ']' && bracketDepth > 0:
+			bracketDepth--
+			if i+1 < len(runes) && runes[i+1] == '(' {
+				current.WriteRune(r)
+				current.WriteRune('(')
+				parenDepth++
+				i++
+				continue
+			}
+		case r == '(' && parenDepth > 0:
+			parenDepth++
+		case r == ')' && parenDepth > 0:
+			parenDepth--
+		case r == '<' && parenDepth == 0:
+			inAutolink = strings.HasPrefix(string(runes[i+1:]), "http")
+		case r == '>' && inAutolink:
+			inAutolink = false
+		case r == ' ' && bracketDepth == 0 && parenDepth == 0 && !inAutolink:
+			if current.Len() > 0 {
+				atoms = append(atoms, current.String())
+				current.Reset()
+			}
+			continue
+		}
+		current.WriteRune(r)
+	}
+
+	if current.Len() > 0 {
+		atoms = append(atoms, current.String())
+	}
+	return atoms
+}
+
+// isWrappableMarkdownLine checks whether a line of Markdown is paragraph text.
+//
+// Parameters:
+//   - line: The line to check
+//
+// Returns:
+//   - true if the line may be soft-wrapped
+func isWrappableMarkdownLine(line string) bool {
+	// Indented lines belong to lists or code
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+		return false
+	}
+	for _, prefix := range []string{"#", ">", "|", "- ", "* ", "+ ", "---", "```", "<"} {
+		if strings.HasPrefix(trimmed, prefix) {
+			return false
+		}
+	}
+	// Ordered list items
+	return !regexp.MustCompile(`^\d+\. `).MatchString(trimmed)
+}
+
+// blockMarkerAtomRegex matches the words that would open a list item, heading, blockquote,
+// or thematic break if a wrapped line started with them.
+var blockMarkerAtomRegex = regexp.MustCompile(`^(?:[-+*]|#{1,6}|>|\d{1,9}[.)]|[-*_=]{2,})$`)
+
+// rawPreOpenRegex and rawPreCloseRegex match the tags of a raw <pre> block written by
+// MarkdownOptions.PreserveCodeHTML.
+var (
+	rawPreOpenRegex  = regexp.MustCompile(`(?i)<pre[\s>]`)
+	rawPreCloseRegex = regexp.MustCompile(`(?i)</pre\s*>`)
+)
+
+// wrapMarkdown soft-wraps paragraph lines of a Markdown document at the given width.
+// Lines are only broken between words, so links, inline code, and URLs stay intact;
+// a single word longer than the width is kept on its own line. A line is never broken
+// before a word such as "-" or "1." that would turn the next line into a block, so that
+// word stays on the line of the previous one, even past the width. Fenced code blocks and
+// raw <pre> blocks are left as they are.
+//
+// Parameters:
+//   - markdown: The Markdown document to wrap
+//   - width: The maximum line width in characters
+//
+// Returns:
+//   - The wrapped Markdown document
+func wrapMarkdown(markdown string, width int) string {
+	lines := strings.Split(markdown, "\n")
+	result := make([]string, 0, len(lines))
+	inCodeBlock := false
+	inRawPre := false
+
+	for _, line := range lines {
+		if inRawPre {
+			inRawPre = !rawPreCloseRegex.MatchString(line)
+			result = append(result, line)
+			continue
+		}
+		if open := rawPreOpenRegex.FindAllStringIndex(line, -1); open != nil {
+			// The block stays open unless the last <pre> is closed on the same line
+			inRawPre = !rawPreCloseRegex.MatchString(line[open[len(open)-1][0]:])
+			result = append(result, line)
+			continue
+		}
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCodeBlock = !inCodeBlock
+			result = append(result, line)
+			continue
+		}
+		if inCodeBlock || !isWrappableMarkdownLine(line) || utf8.RuneCountInString(line) <= width {
+			result = append(result, line)
+			continue
+		}
+
+		// Keep hard line breaks ("  " at the end of the line)
+		hardBreak := ""
+		if strings.HasSuffix(line, "  ") {
+			hardBreak = "  "
+		}
+
+		var current strings.Builder
+		currentWidth := 0
+		for _, atom := range splitMarkdownAtoms(line) {
+			atomWidth := utf8.RuneCountInString(atom)
+			if currentWidth > 0 && currentWidth+1+atomWidth > width && !blockMarkerAtomRegex.MatchString(atom) {
+				result = append(result, current.String())
+				current.Reset()
+				currentWidth = 0
+			}
+			if currentWidth > 0 {
+				current.WriteString(" ")
+				currentWidth++
+			}
+			current.WriteString(atom)
+			currentWidth += atomWidth
+		}
+		result = append(result, current.String()+hardBreak)
+	}
+
+	return strings.Join(result, "\n")
+}
+
 // ToMarkdown converts a VElement to a Markdown string.
 // This is the main entry point for HTML to Markdown conversion,
 // which produces a well-formatted Markdown document from an HTML element.
@@ -534,18 +1567,48 @@ func convertNodeToMarkdown(node dom.VNode, parentTagName string, depth int, isFi
 // Returns:
 //   - A Markdown string representation of the element
 func ToMarkdown(element *dom.VElement) string {
+	return ToMarkdownWithOptions(element, DefaultMarkdownOptions())
+}
+
+// ToMarkdownWithOptions converts a VElement to a Markdown string using the given options.
+//
+// Parameters:
+//   - element: The HTML element to convert to Markdown
+//   - options: Options controlling the Markdown output
+//
+// Returns:
+//   - A Markdown string representation of the element
+func ToMarkdownWithOptions(element *dom.VElement, options MarkdownOptions) string {
 	if element == nil {
 		return ""
 	}
 
+	state := &markdownState{options: options}
+
 	// Start conversion from the root element
-	markdown := convertNodeToMarkdown(element, "", 0, true)
+	markdown := convertNodeToMarkdown(element, "", 0, true, state)
 
 	// Final cleanup
 	markdown = strings.TrimSpace(markdown)
+	if options.NormalizeUnicode {
+		markdown = norm.NFC.String(markdown)
+	}
 
 	// Normalize block spacing: Replace 3 or more newlines with exactly two
 	markdown = regexp.MustCompile(`\n{3,}`).ReplaceAllString(markdown, "\n\n")
 
-	return markdown
+	if options.WrapWidth > 0 {
+		markdown = wrapMarkdown(markdown, options.WrapWidth)
+	}
+
+	// Append reference link definitions collected during conversion
+	if len(state.references) > 0 {
+		definitions := make([]string, len(state.references))
+		for i, url := range state.references {
+			definitions[i] = fmt.Sprintf("[%d]: %s", i+1, url)
+		}
+		markdown += "\n\n" + strings.Join(definitions, "\n")
+	}
+
+	return ConvertLineEndings(markdown, options.LineEnding)
 }
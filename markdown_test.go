@@ -26,6 +26,25 @@ This is a paragraph with **bold** and *italic* text.
 
 Another paragraph with a [link](http://example.com).`,
 		},
+		{
+			name:     "wbr joins words without a space",
+			html:     `<p>Visit https://example.com/very<wbr>/long<wbr>/path and read <b>Super<wbr>cali</b><wbr>fragilistic.</p>`,
+			expected: "Visit https://example.com/very/long/path and read **Supercali**fragilistic.",
+		},
+		{
+			name: "media between blocks",
+			html: `
+				<div>
+					<p>First paragraph.</p>
+					<img src="/one.jpg">
+					<p>Second paragraph.</p>
+					<figure><img src="/two.jpg"><figcaption>Caption</figcaption></figure>
+					<h2>Heading</h2>
+					<p>Last paragraph.</p>
+				</div>
+			`,
+			expected: "First paragraph.\n\n![](/one.jpg)\n\nSecond paragraph.\n\n![](/two.jpg)\n\nCaption\n\n## Heading\n\nLast paragraph.",
+		},
 		{
 			name: "headings",
 			html: `
@@ -107,6 +126,11 @@ Another paragraph with a [link](http://example.com).`,
 			html:     `<img src="image.png" alt="Alt text">`,
 			expected: `![Alt text](image.png)`,
 		},
+		{
+			name:     "empty headings",
+			html:     `<h2></h2><p>Text</p><h3><span> </span></h3>`,
+			expected: "Text",
+		},
 		{
 			name:     "horizontal rules",
 			html:     `<hr>`,
@@ -235,6 +259,39 @@ More Content`,
 | --- | --- | --- |
 | 1 | 2 |  |
 | 3 | 4 | 5 |`,
+		},
+		{
+			name: "table cells with pipes and line breaks",
+			html: `
+				<table>
+					<tr><th>Operator</th><th>Meaning</th></tr>
+					<tr><td>a | b</td><td>Either a<br>or   b</td></tr>
+					<tr><td><code>x || y</code></td><td><p>Logical</p><p>or</p></td></tr>
+				</table>
+			`,
+			expected: `| --- | --- |
+| Operator | Meaning |
+| a \| b | Either a or b |
+| ` + "`x \\|\\| y`" + ` | Logical or |`,
+		},
+		{
+			name: "table with caption",
+			html: `
+				<table>
+					<caption>Table 1: <em>Results</em></caption>
+					<thead>
+						<tr><th>Name</th><th>Score</th></tr>
+					</thead>
+					<tbody>
+						<tr><td>Alice</td><td>10</td></tr>
+					</tbody>
+				</table>
+			`,
+			expected: `**Table 1: *Results***
+
+| Name | Score |
+| --- | --- |
+| Alice | 10 |`,
 		},
 		{
 			name: "nested blockquotes",
@@ -253,6 +310,55 @@ More Content`,
 >
 > Outer quote continued.`,
 		},
+		{
+			name:     "highlighted text",
+			html:     `<p>This is <mark>important</mark> and <strong><mark>very <em>important</em></mark></strong>.</p>`,
+			expected: `This is ==important== and **==very *important*==**.`,
+		},
+		{
+			name:     "cited blockquote",
+			html:     `<blockquote cite="https://example.com/source"><p>This is a quote.</p></blockquote>`,
+			expected: "> This is a quote.\n>\n> — <https://example.com/source>",
+		},
+		{
+			name: "nested blockquotes with citation",
+			html: `
+				<blockquote cite="https://example.com/outer">
+					<p>Outer quote.</p>
+					<blockquote>
+						<p>Inner quote.</p>
+					</blockquote>
+				</blockquote>
+			`,
+			expected: `> Outer quote.
+>
+> > Inner quote.
+>
+> — <https://example.com/outer>`,
+		},
+		{
+			name: "details with summary",
+			html: `
+				<details>
+					<summary>Show <em>more</em></summary>
+					<p>Hidden paragraph.</p>
+					<p>Another hidden paragraph.</p>
+				</details>
+				<p>After.</p>
+			`,
+			expected: `**Show *more***
+
+Hidden paragraph.
+
+Another hidden paragraph.
+
+After.`,
+		},
+		{
+			name:     "details without summary",
+			html:     `<details><p>Only the body.</p></details>`,
+			expected: `Only the body.`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -276,6 +382,386 @@ More Content`,
 	}
 }
 
+func TestToMarkdownWithOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		options  MarkdownOptions
+		expected string
+	}{
+		{
+			name:     "zero options use inline citations",
+			html:     `<blockquote cite="https://example.com/a">Quote.</blockquote>`,
+			options:  MarkdownOptions{},
+			expected: "> Quote.\n>\n> — <https://example.com/a>",
+		},
+		{
+			name:     "entities decoded",
+			html:     `<p>Fish &amp;amp; chips *today*</p>`,
+			options:  MarkdownOptions{DecodeEntities: true},
+			expected: `Fish & chips \*today\*`,
+		},
+		{
+			name:     "entities kept",
+			html:     `<p>Fish &amp;amp; chips *today*</p>`,
+			options:  MarkdownOptions{DecodeEntities: false},
+			expected: `Fish &amp; chips \*today\*`,
+		},
+		{
+			name:     "entities decoded in image alt and title",
+			html:     `<p><img src="fish.png" alt="Fish &amp;amp; chips" title="Salt &amp;amp; vinegar"></p>`,
+			options:  MarkdownOptions{DecodeEntities: true},
+			expected: `![Fish & chips](fish.png "Salt & vinegar")`,
+		},
+		{
+			name:     "entities kept in image alt and title",
+			html:     `<p><img src="fish.png" alt="Fish &amp;amp; chips" title="Salt &amp;amp; vinegar"></p>`,
+			options:  MarkdownOptions{DecodeEntities: false},
+			expected: `![Fish &amp; chips](fish.png "Salt &amp; vinegar")`,
+		},
+		{
+			name:     "entities decoded in abbr title",
+			html:     `<p><abbr title="Research &amp;amp; Development">R&amp;D</abbr> team</p>`,
+			options:  MarkdownOptions{DecodeEntities: true},
+			expected: `R&D (Research & Development) team`,
+		},
+		{
+			name:     "entities kept in abbr title",
+			html:     `<p><abbr title="Research &amp;amp; Development">R&amp;D</abbr> team</p>`,
+			options:  MarkdownOptions{DecodeEntities: false},
+			expected: `R&D (Research &amp; Development) team`,
+		},
+		{
+			name:     "entities kept with shortcodes",
+			html:     `<p>&amp;lt;3 :heart:</p>`,
+			options:  MarkdownOptions{PreserveShortcodes: true},
+			expected: `&lt;3 :heart:`,
+		},
+		{
+			name:     "stripped links",
+			html:     `<p>Read <a href="https://example.com/a">the <strong>full</strong> story</a> or <a href="/b"><img src="b.png" alt="Cover"></a><a href="/c"><img src="c.png"></a>.</p><p><img src="d.png" alt="Photo"></p>`,
+			options:  MarkdownOptions{StripLinks: true},
+			expected: "Read the **full** story or Cover.\n\n![Photo](d.png)",
+		},
+		{
+			name:     "dropped table caption",
+			html:     `<table><caption>Results</caption><tr><td>1</td><td>2</td></tr></table>`,
+			options:  MarkdownOptions{DropTableCaptions: true},
+			expected: "| --- | --- |\n| 1 | 2 |",
+		},
+		{
+			name:     "unwrapped layout table",
+			html:     `<table><tr><td><p>First paragraph.</p><p>Second paragraph.</p></td><td><ul><li>Sidebar item</li></ul></td></tr></table>`,
+			options:  MarkdownOptions{UnwrapLayoutTables: true},
+			expected: "First paragraph.\n\nSecond paragraph.\n\n- Sidebar item",
+		},
+		{
+			name:     "unwrapped single column and presentation tables",
+			html:     `<table><tr><td>Header</td></tr><tr><td><table role="presentation"><tr><td>Left</td><td>Right</td></tr></table></td></tr></table>`,
+			options:  MarkdownOptions{UnwrapLayoutTables: true},
+			expected: "Header\n\nLeft\n\nRight",
+		},
+		{
+			name:     "data table kept with UnwrapLayoutTables",
+			html:     `<table><tr><th>Name</th><th>Score</th></tr><tr><td><p>Alice</p></td><td>10</td></tr></table>`,
+			options:  MarkdownOptions{UnwrapLayoutTables: true},
+			expected: "| --- | --- |\n| Name | Score |\n| Alice | 10 |",
+		},
+		{
+			name:     "layout table kept as a table by default",
+			html:     `<table><tr><td><p>First paragraph.</p></td><td><p>Second paragraph.</p></td></tr></table>`,
+			options:  MarkdownOptions{},
+			expected: "| --- | --- |\n| First paragraph. | Second paragraph. |",
+		},
+		{
+			name:     "kbd, samp, and var as inline code",
+			html:     `<p>Press <kbd>Ctrl</kbd>, read <samp>*error*</samp>, set <var>x_1</var>.</p>`,
+			options:  MarkdownOptions{},
+			expected: "Press `Ctrl`, read `*error*`, set `x_1`.",
+		},
+		{
+			name:     "nested kbd keeps one span per key",
+			html:     `<p>Copy with <kbd><kbd>Ctrl</kbd>+<kbd>C</kbd></kbd>.</p>`,
+			options:  MarkdownOptions{},
+			expected: "Copy with `Ctrl` + `C`.",
+		},
+		{
+			name:     "kbd inside other inline elements",
+			html:     `<p><strong>Save: <kbd>Ctrl</kbd></strong> or <a href="/help"><kbd>F1</kbd></a></p>`,
+			options:  MarkdownOptions{},
+			expected: "**Save: `Ctrl`** or [`F1`](/help)",
+		},
+		{
+			name:     "kbd as HTML",
+			html:     `<p>Press <kbd>Ctrl</kbd> and <var>n</var>.</p>`,
+			options:  MarkdownOptions{KeyboardStyle: KeyboardStyleHTML},
+			expected: "Press <kbd>Ctrl</kbd> and <var>n</var>.",
+		},
+		{
+			name:     "kbd as plain text",
+			html:     `<p>Press <kbd>Ctrl</kbd> and <samp>*ok*</samp>.</p>`,
+			options:  MarkdownOptions{KeyboardStyle: KeyboardStylePlain},
+			expected: "Press Ctrl and \\*ok\\*.",
+		},
+		{
+			name:     "inline quotes",
+			html:     `<p>She said <q>read <q>Dune</q> first</q> and left.</p>`,
+			options:  MarkdownOptions{},
+			expected: "She said “read ‘Dune’ first” and left.",
+		},
+		{
+			name:     "inline quotes with straight marks",
+			html:     `<p>She said <q> read <q>Dune</q> first </q>and left.</p>`,
+			options:  MarkdownOptions{QuoteStyle: QuoteStyleStraight},
+			expected: "She said \"read 'Dune' first\" and left.",
+		},
+		{
+			name:     "inline quotes as plain text",
+			html:     `<p>She said <q>hello</q>.</p>`,
+			options:  MarkdownOptions{QuoteStyle: QuoteStylePlain},
+			expected: "She said hello.",
+		},
+		{
+			name:     "cite in italics",
+			html:     `<p>From <cite>The <strong>Old</strong> Man</cite>, and <em>as in <cite>Dune</cite></em>: <q><cite>Title</cite></q></p>`,
+			options:  MarkdownOptions{},
+			expected: "From *The **Old** Man*, and *as in Dune*: “*Title*”",
+		},
+		{
+			name:     "plain cite elements",
+			html:     `<p>From <cite>Dune</cite>.</p>`,
+			options:  MarkdownOptions{PlainCiteElements: true},
+			expected: "From Dune.",
+		},
+		{
+			name:     "abbr title on first use",
+			html:     `<p><abbr title="HyperText Markup Language">HTML</abbr> is old. <em><abbr title="HyperText Markup Language">HTML</abbr></em> is everywhere.</p>`,
+			options:  MarkdownOptions{},
+			expected: "HTML (HyperText Markup Language) is old. *HTML* is everywhere.",
+		},
+		{
+			name:     "abbr without title",
+			html:     `<p><abbr>CSS</abbr> styles pages.</p>`,
+			options:  MarkdownOptions{},
+			expected: "CSS styles pages.",
+		},
+		{
+			name:     "abbr titles dropped",
+			html:     `<p><abbr title="World Wide Web">WWW</abbr></p>`,
+			options:  MarkdownOptions{DropAbbrTitles: true},
+			expected: "WWW",
+		},
+		{
+			name:     "hr inside a blockquote",
+			html:     `<blockquote><p>One</p><hr><p>Two</p></blockquote>`,
+			options:  MarkdownOptions{},
+			expected: "> One\n>\n> ---\n>\n> Two",
+		},
+		{
+			name:     "hr between inline content in a blockquote",
+			html:     `<blockquote>One<hr>Two</blockquote>`,
+			options:  MarkdownOptions{},
+			expected: "> One\n>\n> ---\n>\n> Two",
+		},
+		{
+			name:     "hr inside a list item",
+			html:     `<ul><li><p>One</p><hr><p>Two</p></li><li>Three</li></ul>`,
+			options:  MarkdownOptions{},
+			expected: "- One\n\n  ---\n\n  Two\n- Three",
+		},
+		{
+			name:     "hr inside an ordered list item",
+			html:     `<ol><li>One<hr>Two</li></ol>`,
+			options:  MarkdownOptions{},
+			expected: "1. One\n\n   ---\n\n   Two",
+		},
+		{
+			name:     "hr after a paragraph",
+			html:     `<p>Para</p><hr><p>After</p>`,
+			options:  MarkdownOptions{},
+			expected: "Para\n\n---\n\nAfter",
+		},
+		{
+			name:     "highlight as equals",
+			html:     `<p>Some <mark>marked <code>code</code></mark> text.</p>`,
+			options:  MarkdownOptions{HighlightStyle: HighlightStyleEquals},
+			expected: "Some ==marked `code`== text.",
+		},
+		{
+			name:     "br runs become paragraph breaks",
+			html:     "<div>First paragraph<br><br>\n  Second paragraph, line one<br>line two<br> <br><br>Third <em>paragraph</em></div>",
+			options:  MarkdownOptions{BrToParagraph: true},
+			expected: "First paragraph\n\nSecond paragraph, line one  \nline two\n\nThird *paragraph*",
+		},
+		{
+			name:     "leading and trailing br runs are dropped",
+			html:     `<p><br><br>Only text<br><br></p>`,
+			options:  MarkdownOptions{BrToParagraph: true},
+			expected: "Only text",
+		},
+		{
+			name:     "highlighted code is flattened by default",
+			html:     `<pre><code class="language-go"><span class="kw">func</span> <span class="fn">main</span>() {}</code></pre>`,
+			options:  DefaultMarkdownOptions(),
+			expected: "```go\nfunc main() {}\n```",
+		},
+		{
+			name:     "highlighted code passthrough",
+			html:     `<pre><code class="language-go"><span class="kw">func</span> <span class="fn">main</span>() {}</code></pre>`,
+			options:  MarkdownOptions{PreserveCodeHTML: true},
+			expected: `<pre><code class="language-go"><span class="kw">func</span> <span class="fn">main</span>() {}</code></pre>`,
+		},
+		{
+			name:     "plain code stays fenced with code passthrough",
+			html:     `<pre><code>x := 1</code></pre>`,
+			options:  MarkdownOptions{PreserveCodeHTML: true},
+			expected: "```\nx := 1\n```",
+		},
+		{
+			name:     "highlight passthrough",
+			html:     `<p>Some <em><mark>marked</mark></em> text.</p>`,
+			options:  MarkdownOptions{HighlightStyle: HighlightStyleHTML},
+			expected: "Some *<mark>marked</mark>* text.",
+		},
+		{
+			name: "wrap paragraphs at 40 columns",
+			html: `
+				<h2>A heading that is much longer than forty columns</h2>
+				<p>Readability extracts the main content of a page. See <a href="https://example.com/a/very/long/path">the full documentation</a> and run <code>go test ./...</code> before sending changes.</p>
+				<pre><code>a code block line that is much longer than forty columns</code></pre>
+			`,
+			options: MarkdownOptions{WrapWidth: 40},
+			expected: "## A heading that is much longer than forty columns\n\n" +
+				"Readability extracts the main content of\n" +
+				"a page. See\n" +
+				"[the full documentation](https://example.com/a/very/long/path)\n" +
+				"and run `go test ./...` before sending\n" +
+				"changes.\n\n" +
+				"```\na code block line that is much longer than forty columns\n```",
+		},
+		{
+			name:    "wrap never starts a line with a block marker",
+			html:    `<p>Scores were nineteen - 3 for the home side, and # 1 in the league in 1. place.</p>`,
+			options: MarkdownOptions{WrapWidth: 20},
+			expected: "Scores were nineteen -\n" +
+				"3 for the home side,\n" +
+				"and # 1 in the\n" +
+				"league in 1. place.",
+		},
+		{
+			name: "wrap leaves raw pre blocks intact",
+			html: `<pre><code class="language-go"><span class="kw">first</span> line
+second alpha beta gamma delta epsilon zeta eta theta iota kappa lambda mu nu xi omicron pi rho sigma
+third</code></pre><p>A paragraph after the code block that is longer than forty columns.</p>`,
+			options: MarkdownOptions{WrapWidth: 40, PreserveCodeHTML: true},
+			expected: `<pre><code class="language-go"><span class="kw">first</span> line
+second alpha beta gamma delta epsilon zeta eta theta iota kappa lambda mu nu xi omicron pi rho sigma
+third</code></pre>` + "\n\nA paragraph after the code block that is\nlonger than forty columns.",
+		},
+		{
+			name:     "zero width keeps single-line paragraphs",
+			html:     `<p>Readability extracts the main content of a page and drops the clutter around it.</p>`,
+			options:  MarkdownOptions{},
+			expected: "Readability extracts the main content of a page and drops the clutter around it.",
+		},
+		{
+			name:     "svg dropped by default",
+			html:     `<p>Chart: <svg aria-label="Sales by quarter"><rect width="10" height="10"></rect></svg></p>`,
+			options:  MarkdownOptions{},
+			expected: "Chart:",
+		},
+		{
+			name:     "svg with title as alt text",
+			html:     `<p>Chart: <svg role="img"><title>Sales by quarter</title><rect width="10" height="10"></rect></svg></p>`,
+			options:  MarkdownOptions{SVGHandling: SVGHandlingAltText},
+			expected: "Chart: [svg: Sales by quarter]",
+		},
+		{
+			name:     "svg with aria-label as alt text",
+			html:     `<p><svg aria-label="Company logo"><path d="M0 0"></path></svg></p>`,
+			options:  MarkdownOptions{SVGHandling: SVGHandlingAltText},
+			expected: "[svg: Company logo]",
+		},
+		{
+			name:     "untitled and decorative svgs are dropped",
+			html:     `<p>Next <svg><path d="M0 0"></path></svg><svg aria-hidden="true"><title>Arrow</title></svg></p>`,
+			options:  MarkdownOptions{SVGHandling: SVGHandlingAltText},
+			expected: "Next",
+		},
+		{
+			name:     "sized image as markdown by default",
+			html:     `<img src="chart.png" alt="Chart" width="640" height="480">`,
+			options:  MarkdownOptions{},
+			expected: "![Chart](chart.png)",
+		},
+		{
+			name:     "sized image as html",
+			html:     `<p><img src="chart.png?a=1&b=2" alt="Sales &quot;2024&quot;" width="640" height="480"></p>`,
+			options:  MarkdownOptions{ImageSizeSyntax: ImageSizeHTML},
+			expected: `<img src="chart.png?a=1&amp;b=2" alt="Sales &quot;2024&quot;" width="640" height="480">`,
+		},
+		{
+			name:     "sized image with attribute block",
+			html:     `<img src="chart.png" alt="Chart" width="640">`,
+			options:  MarkdownOptions{ImageSizeSyntax: ImageSizeAttributes},
+			expected: "![Chart](chart.png){width=640}",
+		},
+		{
+			name:     "srcset collapsed to largest candidate",
+			html:     `<img src="small.jpg" srcset="small.jpg 480w, large.jpg 1200w, medium.jpg 800w" alt="Photo">`,
+			options:  MarkdownOptions{CollapseSrcset: true},
+			expected: "![Photo](large.jpg)",
+		},
+		{
+			name:     "srcset ignored by default",
+			html:     `<img src="small.jpg" srcset="large.jpg 2x" alt="Photo">`,
+			options:  MarkdownOptions{},
+			expected: "![Photo](small.jpg)",
+		},
+		{
+			name:     "unsized image keeps markdown syntax",
+			html:     `<img src="photo.jpg" alt="Photo">`,
+			options:  MarkdownOptions{ImageSizeSyntax: ImageSizeHTML},
+			expected: "![Photo](photo.jpg)",
+		},
+		{
+			name: "reference citations",
+			html: `
+				<blockquote cite="https://example.com/a"><p>First.</p></blockquote>
+				<blockquote><p>Uncited.</p></blockquote>
+				<blockquote cite="https://example.com/b"><p>Second.</p></blockquote>
+			`,
+			options: MarkdownOptions{CitationStyle: CitationStyleReference},
+			expected: `> First.
+>
+> — [source][1]
+
+> Uncited.
+
+> Second.
+>
+> — [source][2]
+
+[1]: https://example.com/a
+[2]: https://example.com/b`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.ParseHTML(tt.html, "")
+			if err != nil {
+				t.Fatalf("Failed to parse HTML: %v", err)
+			}
+
+			result := normalizeWhitespace(ToMarkdownWithOptions(doc.Body, tt.options))
+			if result != tt.expected {
+				t.Errorf("ToMarkdownWithOptions() =\n%s\n\nwant:\n%s", result, tt.expected)
+			}
+		})
+	}
+}
+
 // normalizeWhitespace normalizes whitespace for comparison
 func normalizeWhitespace(s string) string {
 	// Trim leading/trailing whitespace
@@ -321,6 +807,16 @@ func TestEscapeMarkdown(t *testing.T) {
 			input:    "This &amp; that &lt; this &gt; that",
 			expected: `This & that < this > that`,
 		},
+		{
+			name:     "emoji next to special characters",
+			input:    "🎉*party*🎉 and 👩‍💻_dev_",
+			expected: `🎉\*party\*🎉 and 👩‍💻\_dev\_`,
+		},
+		{
+			name:     "shortcodes are escaped by default",
+			input:    "Nice :thumbs_up: :+1:",
+			expected: `Nice :thumbs\_up: :+1:`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -333,6 +829,44 @@ func TestEscapeMarkdown(t *testing.T) {
 	}
 }
 
+func TestSplitMarkdownAtoms(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "plain words",
+			input:    "one two  three",
+			expected: []string{"one", "two", "three"},
+		},
+		{
+			name:     "links and images stay whole",
+			input:    "see [the docs](https://example.com/a (title)) and ![an image](img.png) now",
+			expected: []string{"see", "[the docs](https://example.com/a (title))", "and", "![an image](img.png)", "now"},
+		},
+		{
+			name:     "inline code stays whole",
+			input:    "run ``go test `./...` `` now",
+			expected: []string{"run", "``go test `./...` ``", "now"},
+		},
+		{
+			name:     "escaped brackets do not start links",
+			input:    `a \[b c`,
+			expected: []string{"a", `\[b`, "c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := splitMarkdownAtoms(tt.input)
+			if strings.Join(result, "|") != strings.Join(tt.expected, "|") {
+				t.Errorf("splitMarkdownAtoms() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestJoinMarkdownParts(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -380,3 +914,22 @@ func TestJoinMarkdownParts(t *testing.T) {
 		})
 	}
 }
+
+func TestToMarkdownMaxDepth(t *testing.T) {
+	doc, err := ParseHTML(deeplyNestedHTML(5000), "")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+	if markdown := ToMarkdown(doc.Body); markdown != "Deep text" {
+		t.Errorf("Expected the text of deeply nested elements, got %q", markdown)
+	}
+
+	doc, err = ParseHTML(`<html><body><div><p>Intro <em>text</em></p><ul><li>One <strong>item</strong></li></ul></div></body></html>`, "")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+	expected := "Intro *text*\n\n- One item"
+	if markdown := ToMarkdownWithOptions(doc.Body, MarkdownOptions{MaxDepth: 4}); markdown != expected {
+		t.Errorf("Expected elements beyond MaxDepth as plain text, got %q", markdown)
+	}
+}
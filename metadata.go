@@ -5,7 +5,9 @@ package readability
 
 import (
 	"encoding/json"
+	"math"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -34,6 +36,7 @@ var (
 
 	// For JSON-LD processing
 	jsonLdArticleTypesRegex = regexp.MustCompile(`^Article|AdvertiserContentArticle|NewsArticle|AnalysisNewsArticle|AskPublicNewsArticle|BackgroundNewsArticle|OpinionNewsArticle|ReportageNewsArticle|ReviewNewsArticle|Report|SatiricalArticle|ScholarlyArticle|MedicalScholarlyArticle|SocialMediaPosting|BlogPosting|LiveBlogPosting|DiscussionForumPosting|TechArticle|APIReference$`)
+	cdataRegex              = regexp.MustCompile(`^\s*<!\[CDATA\[|\]\]>\s*$`)
 	schemaDotOrgRegex       = regexp.MustCompile(`^https?\:\/\/schema\.org\/?$`)
 
 	// For HTML entity unescaping
@@ -50,12 +53,14 @@ type ReadabilityMetadata struct {
 	Excerpt       string
 	SiteName      string
 	PublishedTime string
+	Section       string
 }
 
 // GetArticleTitle extracts the article title from the document.
 // It tries various strategies to find the most appropriate title, including
 // examining the <title> element, heading elements, and handling common title
-// patterns like site name separators.
+// patterns like site name separators. The og:title (or twitter:title) meta tag is
+// preferred when <title> is missing or when it closely matches the cleaned <title>.
 //
 // Parameters:
 //   - doc: The parsed HTML document
@@ -63,6 +68,67 @@ type ReadabilityMetadata struct {
 // Returns:
 //   - The extracted article title as a string
 func GetArticleTitle(doc *dom.VDocument) string {
+	return getArticleTitle(doc, util.DefaultTitleMinLength, util.DefaultTitleMaxLength, TitlePrecedenceAuto)
+}
+
+// getArticleTitle is GetArticleTitle with configurable length bounds and precedence of the
+// og:title and twitter:title meta tags over the title found by getDocumentTitle.
+//
+// Parameters:
+//   - doc: The parsed HTML document
+//   - minLength: The minimum title length in bytes
+//   - maxLength: The maximum title length in bytes
+//   - precedence: When to use the meta title (see TitlePrecedence)
+//
+// Returns:
+//   - The extracted article title as a string
+func getArticleTitle(doc *dom.VDocument, minLength, maxLength int, precedence TitlePrecedence) string {
+	title := getDocumentTitle(doc, minLength, maxLength)
+	if doc == nil || doc.DocumentElement == nil || precedence == TitlePrecedenceDocument {
+		return title
+	}
+
+	metaTitle := getMetaContent(doc, "og:title", "twitter:title")
+	metaTitle = strings.TrimSpace(util.Regexps.Normalize.ReplaceAllString(metaTitle, " "))
+	if metaTitle == "" {
+		return title
+	}
+	if precedence == TitlePrecedenceMeta || title == "" || isCloseTitleMatch(title, metaTitle) {
+		return metaTitle
+	}
+	return title
+}
+
+// isCloseTitleMatch checks whether a meta title is the document title without clutter:
+// nearly all its words appear in the title, and it covers at least half of the title.
+// A site name or an unrelated meta title does not match.
+//
+// Parameters:
+//   - title: The title derived from <title> and the headings
+//   - metaTitle: The og:title or twitter:title
+//
+// Returns:
+//   - true if the meta title closely matches the title
+func isCloseTitleMatch(title, metaTitle string) bool {
+	return TextSimilarity(title, metaTitle) >= 0.75 && TextSimilarity(metaTitle, title) >= 0.5
+}
+
+// getDocumentTitle finds the title in <title>, removing the site name around separators,
+// or in the headings: a <title> shorter than minLength or longer than maxLength is replaced
+// by the only <h1> of the page.
+//
+// Parameters:
+//   - doc: The parsed HTML document
+//   - minLength: The minimum title length in bytes
+//   - maxLength: The maximum title length in bytes
+//
+// Returns:
+//   - The title as a string
+func getDocumentTitle(doc *dom.VDocument, minLength, maxLength int) string {
+	if doc == nil || doc.DocumentElement == nil {
+		return ""
+	}
+
 	var curTitle string
 	var origTitle string
 
@@ -133,7 +199,7 @@ func GetArticleTitle(doc *dom.VDocument) string {
 				}
 			}
 		}
-	} else if len(curTitle) > 150 || len(curTitle) < 15 {
+	} else if len(curTitle) > maxLength || len(curTitle) < minLength {
 		hOnes := GetElementsByTagName(doc.DocumentElement, "h1")
 		if len(hOnes) == 1 {
 			curTitle = GetInnerText(hOnes[0], false)
@@ -164,7 +230,8 @@ func GetArticleTitle(doc *dom.VDocument) string {
 
 // GetArticleByline extracts the author information from the document.
 // It uses various strategies including meta tags and JSON-LD data to find
-// the author or byline information associated with the content.
+// the author or byline information associated with the content, and falls back
+// to schema.org microdata (see GetMicrodata).
 //
 // Parameters:
 //   - doc: The parsed HTML document
@@ -231,6 +298,11 @@ func GetArticleByline(doc *dom.VDocument) string {
 		byline = articleAuthor
 	}
 
+	// Fall back to microdata such as <span itemprop="author">
+	if byline == "" {
+		byline = GetMicrodata(doc).Byline
+	}
+
 	// Unescape HTML entities
 	if byline != "" {
 		byline = UnescapeHTMLEntities(byline)
@@ -239,139 +311,531 @@ func GetArticleByline(doc *dom.VDocument) string {
 	return byline
 }
 
-// GetJSONLD extracts metadata from JSON-LD objects in the document.
-// It currently only supports Schema.org objects of type Article or its subtypes.
-// JSON-LD is a structured data format that provides rich metadata about web content.
+// GetArticleKeywords extracts the keywords or tags of the article.
+// Keywords are collected from <meta name="keywords"> and <meta name="news_keywords">
+// (comma-separated), <meta property="article:tag"> (one tag per element), and
+// rel="tag" links, in that order. They are trimmed and deduplicated case-insensitively.
 //
 // Parameters:
 //   - doc: The parsed HTML document
 //
 // Returns:
-//   - ReadabilityMetadata containing information extracted from JSON-LD
-func GetJSONLD(doc *dom.VDocument) ReadabilityMetadata {
-	scripts := GetElementsByTagName(doc.DocumentElement, "script")
-	metadata := ReadabilityMetadata{}
+//   - The keywords in order of first appearance, or nil if none are found
+func GetArticleKeywords(doc *dom.VDocument) []string {
+	if doc == nil {
+		return nil
+	}
 
-	for _, jsonLdElement := range scripts {
-		if jsonLdElement.GetAttribute("type") == "application/ld+json" {
-			// Strip CDATA markers if present
-			content := GetInnerText(jsonLdElement, false)
-			content = regexp.MustCompile(`^\s*<!\[CDATA\[|\]\]>\s*$`).ReplaceAllString(content, "")
-
-			var parsed map[string]interface{}
-			err := json.Unmarshal([]byte(content), &parsed)
-			if err != nil {
-				// Try parsing as array
-				var parsedArray []map[string]interface{}
-				err = json.Unmarshal([]byte(content), &parsedArray)
-				if err != nil {
-					continue
-				}
+	var keywords []string
+	seen := make(map[string]bool)
+	add := func(keyword string) {
+		keyword = strings.Join(strings.Fields(UnescapeHTMLEntities(keyword)), " ")
+		key := strings.ToLower(keyword)
+		if keyword == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		keywords = append(keywords, keyword)
+	}
 
-				// Find the first item that matches the article type
-				found := false
-				for _, item := range parsedArray {
-					if itemType, ok := item["@type"].(string); ok && jsonLdArticleTypesRegex.MatchString(itemType) {
-						parsed = item
-						found = true
-						break
-					}
-				}
+	metaElements := GetElementsByTagName(doc.DocumentElement, "meta")
+	for _, element := range metaElements {
+		name := strings.ToLower(strings.TrimSpace(element.GetAttribute("name")))
+		if name == "keywords" || name == "news_keywords" {
+			for _, keyword := range strings.FieldsFunc(element.GetAttribute("content"), func(r rune) bool {
+				return r == ',' || r == '，' || r == '、'
+			}) {
+				add(keyword)
+			}
+		}
+	}
+	for _, element := range metaElements {
+		if strings.ToLower(strings.TrimSpace(element.GetAttribute("property"))) == "article:tag" {
+			add(element.GetAttribute("content"))
+		}
+	}
 
-				if !found {
-					continue
+	for _, link := range GetElementsByTagName(doc.DocumentElement, "a") {
+		for _, rel := range strings.Fields(strings.ToLower(link.GetAttribute("rel"))) {
+			if rel == "tag" {
+				add(GetInnerText(link, true))
+				break
+			}
+		}
+	}
+
+	return keywords
+}
+
+// GetDocumentLanguage returns the language declared by the document in the lang
+// (or xml:lang) attribute of the <html> element.
+//
+// Parameters:
+//   - doc: The parsed HTML document
+//
+// Returns:
+//   - The language tag (e.g. "ja" or "en-US"), or an empty string if none is declared
+func GetDocumentLanguage(doc *dom.VDocument) string {
+	if doc == nil || doc.DocumentElement == nil {
+		return ""
+	}
+	if lang := strings.TrimSpace(doc.DocumentElement.GetAttribute("lang")); lang != "" {
+		return lang
+	}
+	return strings.TrimSpace(doc.DocumentElement.GetAttribute("xml:lang"))
+}
+
+// getExcerptAndSiteName returns the excerpt and site name of the document, preferring the
+// JSON-LD values over the og:description/description/twitter:description and
+// og:site_name meta tags.
+//
+// Parameters:
+//   - doc: The parsed HTML document
+//   - jsonLD: The JSON-LD metadata of the document (see GetJSONLD)
+//
+// Returns:
+//   - The excerpt, or an empty string if none is found
+//   - The site name, or an empty string if none is found
+func getExcerptAndSiteName(doc *dom.VDocument, jsonLD ReadabilityMetadata) (string, string) {
+	excerpt, siteName := jsonLD.Excerpt, jsonLD.SiteName
+	if excerpt == "" {
+		excerpt = getMetaContent(doc, "og:description", "description", "twitter:description")
+	}
+	if siteName == "" {
+		siteName = getMetaContent(doc, "og:site_name")
+	}
+	return excerpt, siteName
+}
+
+// GetArticleSection finds the section or category of the article. It checks, in order,
+// <meta property="article:section">, JSON-LD articleSection, the last linked item of a
+// JSON-LD BreadcrumbList, and the last item of a breadcrumb (nav[aria-label=breadcrumb]
+// or ol.breadcrumb).
+// Breadcrumbs are usually inside <nav>, so this must run before preprocessing to use them.
+//
+// Parameters:
+//   - doc: The parsed HTML document
+//
+// Returns:
+//   - The section name, or an empty string if none is found
+func GetArticleSection(doc *dom.VDocument) string {
+	if doc == nil || doc.DocumentElement == nil {
+		return ""
+	}
+
+	if section := getMetaContent(doc, "article:section"); section != "" {
+		return section
+	}
+
+	for _, object := range GetStructuredData(doc) {
+		switch section := object["articleSection"].(type) {
+		case string:
+			if section = strings.TrimSpace(section); section != "" {
+				return section
+			}
+		case []interface{}:
+			for _, item := range section {
+				if name, ok := item.(string); ok && strings.TrimSpace(name) != "" {
+					return strings.TrimSpace(name)
 				}
 			}
+		}
+	}
 
-			// Check for @context to verify it's schema.org
-			contextMatches := false
-			if context, ok := parsed["@context"].(string); ok {
-				contextMatches = schemaDotOrgRegex.MatchString(context)
-			} else if contextObj, ok := parsed["@context"].(map[string]interface{}); ok {
-				if vocab, ok := contextObj["@vocab"].(string); ok {
-					contextMatches = schemaDotOrgRegex.MatchString(vocab)
+	// The last item of a BreadcrumbList without a URL is the page itself
+	items := jsonLDBreadcrumbItems(GetStructuredData(doc))
+	for i := len(items) - 1; i >= 0; i-- {
+		if items[i].hasURL {
+			return items[i].name
+		}
+	}
+
+	if !hasBody(doc) {
+		return ""
+	}
+	for _, element := range GetElementsByTagNames(doc.Body, []string{"nav", "ol", "ul"}) {
+		isBreadcrumb := false
+		if element.TagName == "nav" {
+			isBreadcrumb = strings.Contains(strings.ToLower(element.GetAttribute("aria-label")), "breadcrumb")
+		} else {
+			for _, className := range strings.Fields(strings.ToLower(element.ClassName())) {
+				if className == "breadcrumb" || className == "breadcrumbs" {
+					isBreadcrumb = true
+					break
 				}
 			}
+		}
+		if !isBreadcrumb {
+			continue
+		}
 
-			if !contextMatches {
-				continue
+		items := GetElementsByTagName(element, "li")
+		if len(items) == 0 {
+			items = GetElementsByTagName(element, "a")
+		}
+		for i := len(items) - 1; i >= 0; i-- {
+			if text := GetInnerText(items[i], true); text != "" {
+				return text
 			}
+		}
+	}
+	return ""
+}
 
-			// Check for @graph if @type is not present
-			if _, ok := parsed["@type"]; !ok {
-				if graph, ok := parsed["@graph"].([]interface{}); ok {
-					found := false
-					for _, item := range graph {
-						if itemMap, ok := item.(map[string]interface{}); ok {
-							if itemType, ok := itemMap["@type"].(string); ok && jsonLdArticleTypesRegex.MatchString(itemType) {
-								parsed = itemMap
-								found = true
-								break
-							}
-						}
-					}
-					if !found {
-						continue
-					}
+// GetBreadcrumbs returns the category path of the article from the first JSON-LD
+// BreadcrumbList in the document, from the top level down. Items are ordered by their
+// position, and their names come from the item's name or the name of its linked page.
+// This must run before preprocessing, which removes script elements.
+//
+// Parameters:
+//   - doc: The parsed HTML document
+//
+// Returns:
+//   - The breadcrumb names in order, or nil if the document has no BreadcrumbList
+func GetBreadcrumbs(doc *dom.VDocument) []string {
+	return breadcrumbNames(GetStructuredData(doc))
+}
+
+// breadcrumbNames returns the names of the items of the first BreadcrumbList among
+// JSON-LD objects (see GetBreadcrumbs).
+func breadcrumbNames(objects []map[string]interface{}) []string {
+	var names []string
+	for _, item := range jsonLDBreadcrumbItems(objects) {
+		names = append(names, item.name)
+	}
+	return names
+}
+
+// breadcrumbItem is an item of a JSON-LD BreadcrumbList.
+type breadcrumbItem struct {
+	name     string
+	position float64
+	hasURL   bool // Whether the item links to a page
+}
+
+// jsonLDBreadcrumbItems reads the itemListElement of the first BreadcrumbList among
+// JSON-LD objects. Items without a name are skipped, and items without a position keep
+// their place after the positioned ones.
+//
+// Parameters:
+//   - objects: The JSON-LD objects (see GetStructuredData)
+//
+// Returns:
+//   - The items sorted by position, or nil if there is no BreadcrumbList
+func jsonLDBreadcrumbItems(objects []map[string]interface{}) []breadcrumbItem {
+	for _, object := range objects {
+		if !hasJSONLDType(object, "BreadcrumbList") {
+			continue
+		}
+		elements, ok := object["itemListElement"].([]interface{})
+		if !ok {
+			continue
+		}
+
+		var items []breadcrumbItem
+		for _, element := range elements {
+			listItem, ok := element.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			item := breadcrumbItem{position: math.Inf(1)}
+			if name, ok := listItem["name"].(string); ok {
+				item.name = strings.TrimSpace(name)
+			}
+			switch linked := listItem["item"].(type) {
+			case string:
+				item.hasURL = strings.TrimSpace(linked) != ""
+			case map[string]interface{}:
+				if name, ok := linked["name"].(string); ok && item.name == "" {
+					item.name = strings.TrimSpace(name)
 				}
+				id, _ := linked["@id"].(string)
+				url, _ := linked["url"].(string)
+				item.hasURL = strings.TrimSpace(id+url) != ""
 			}
+			switch position := listItem["position"].(type) {
+			case float64:
+				item.position = position
+			case string:
+				if parsed, err := strconv.ParseFloat(strings.TrimSpace(position), 64); err == nil {
+					item.position = parsed
+				}
+			}
+			if item.name != "" {
+				items = append(items, item)
+			}
+		}
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].position < items[j].position
+		})
+		return items
+	}
+	return nil
+}
 
-			// Check if it's an article type
-			itemType, ok := parsed["@type"].(string)
-			if !ok || !jsonLdArticleTypesRegex.MatchString(itemType) {
-				continue
+// hasJSONLDType checks whether the @type of a JSON-LD object, a string or an array of
+// strings, includes the given type.
+func hasJSONLDType(object map[string]interface{}, typeName string) bool {
+	switch objectType := object["@type"].(type) {
+	case string:
+		return objectType == typeName
+	case []interface{}:
+		for _, t := range objectType {
+			if t == typeName {
+				return true
 			}
+		}
+	}
+	return false
+}
 
-			// Extract metadata
-			if name, ok := parsed["name"].(string); ok && name != "" {
-				metadata.Title = strings.TrimSpace(name)
-			} else if headline, ok := parsed["headline"].(string); ok && headline != "" {
-				metadata.Title = strings.TrimSpace(headline)
+// GetFaviconURL finds the icon of the site from <link rel="icon">, rel="shortcut icon",
+// and rel="apple-touch-icon" elements, choosing the one with the largest sizes attribute
+// ("any" counts as largest). The first icon wins ties. The URL is resolved against the
+// document's base URI, and /favicon.ico is returned when the page declares no icon.
+//
+// Parameters:
+//   - doc: The parsed HTML document
+//
+// Returns:
+//   - The absolute icon URL (relative if the document has no base URI)
+func GetFaviconURL(doc *dom.VDocument) string {
+	if doc == nil {
+		return ""
+	}
+
+	href := ""
+	bestSize := -1
+	for _, link := range GetElementsByTagName(doc.DocumentElement, "link") {
+		isIcon := false
+		for _, rel := range strings.Fields(strings.ToLower(link.GetAttribute("rel"))) {
+			if rel == "icon" || rel == "apple-touch-icon" || rel == "apple-touch-icon-precomposed" {
+				isIcon = true
+				break
 			}
+		}
+		linkHref := strings.TrimSpace(link.GetAttribute("href"))
+		if !isIcon || linkHref == "" {
+			continue
+		}
+		if size := iconSize(link.GetAttribute("sizes")); size > bestSize {
+			href = linkHref
+			bestSize = size
+		}
+	}
 
-			// Extract author information
-			if author, ok := parsed["author"].(map[string]interface{}); ok {
-				if authorName, ok := author["name"].(string); ok {
-					metadata.Byline = strings.TrimSpace(authorName)
-				}
-			} else if authorArray, ok := parsed["author"].([]interface{}); ok && len(authorArray) > 0 {
-				authorNames := []string{}
-				for _, a := range authorArray {
-					if authorMap, ok := a.(map[string]interface{}); ok {
-						if authorName, ok := authorMap["name"].(string); ok {
-							authorNames = append(authorNames, strings.TrimSpace(authorName))
-						}
-					}
-				}
-				if len(authorNames) > 0 {
-					metadata.Byline = strings.Join(authorNames, ", ")
-				}
+	if href == "" {
+		href = "/favicon.ico"
+	}
+	return resolveURL(doc.BaseURI, href)
+}
+
+// iconSize returns the largest dimension listed in an icon's sizes attribute (e.g. "16x16 32x32").
+//
+// Parameters:
+//   - sizes: The value of the sizes attribute
+//
+// Returns:
+//   - The largest width or height, math.MaxInt for "any", or 0 if no size is given
+func iconSize(sizes string) int {
+	largest := 0
+	for _, size := range strings.Fields(strings.ToLower(sizes)) {
+		if size == "any" {
+			return math.MaxInt
+		}
+		width, height, ok := strings.Cut(size, "x")
+		if !ok {
+			continue
+		}
+		for _, dimension := range []string{width, height} {
+			if n, err := strconv.Atoi(dimension); err == nil && n > largest {
+				largest = n
 			}
+		}
+	}
+	return largest
+}
+
+// GetStructuredData parses every JSON-LD block in the document and returns the objects
+// it contains, of any Schema.org type (Article, FAQPage, Recipe, Product, ...).
+// Top-level arrays and @graph containers are expanded into their items, and graph
+// items without their own @context inherit the container's. Invalid JSON is skipped.
+// This must run before preprocessing, which removes script elements.
+//
+// Parameters:
+//   - doc: The parsed HTML document
+//
+// Returns:
+//   - The JSON-LD objects in document order
+func GetStructuredData(doc *dom.VDocument) []map[string]interface{} {
+	if doc == nil || doc.DocumentElement == nil {
+		return nil
+	}
+
+	var objects []map[string]interface{}
+	for _, jsonLdElement := range GetElementsByTagName(doc.DocumentElement, "script") {
+		if jsonLdElement.GetAttribute("type") != "application/ld+json" {
+			continue
+		}
+
+		// Strip CDATA markers if present
+		content := GetInnerText(jsonLdElement, false)
+		content = cdataRegex.ReplaceAllString(content, "")
 
-			// Extract description
-			if description, ok := parsed["description"].(string); ok {
-				metadata.Excerpt = strings.TrimSpace(description)
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(content), &parsed); err != nil {
+			continue
+		}
+		objects = appendJSONLDObjects(objects, parsed, nil)
+	}
+	return objects
+}
+
+// appendJSONLDObjects appends the objects in a parsed JSON-LD value, expanding arrays and @graph.
+//
+// Parameters:
+//   - objects: The objects collected so far
+//   - value: The parsed JSON value
+//   - context: The @context inherited from an enclosing @graph container, or nil
+//
+// Returns:
+//   - The objects with those found in value appended
+func appendJSONLDObjects(objects []map[string]interface{}, value interface{}, context interface{}) []map[string]interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			objects = appendJSONLDObjects(objects, item, context)
+		}
+	case map[string]interface{}:
+		if _, ok := v["@context"]; !ok && context != nil {
+			v["@context"] = context
+		}
+		graph, hasGraph := v["@graph"].([]interface{})
+		// A bare @graph container is not an object of its own
+		if _, hasType := v["@type"]; hasType || !hasGraph {
+			objects = append(objects, v)
+		}
+		if hasGraph {
+			objects = appendJSONLDObjects(objects, graph, v["@context"])
+		}
+	}
+	return objects
+}
+
+// GetJSONLD extracts metadata from JSON-LD objects in the document.
+// It currently only supports Schema.org objects of type Article or its subtypes,
+// taking the first one found by GetStructuredData.
+// JSON-LD is a structured data format that provides rich metadata about web content.
+//
+// Parameters:
+//   - doc: The parsed HTML document
+//
+// Returns:
+//   - ReadabilityMetadata containing information extracted from JSON-LD
+func GetJSONLD(doc *dom.VDocument) ReadabilityMetadata {
+	metadata := ReadabilityMetadata{}
+
+	for _, parsed := range GetStructuredData(doc) {
+		// Check for @context to verify it's schema.org
+		contextMatches := false
+		if context, ok := parsed["@context"].(string); ok {
+			contextMatches = schemaDotOrgRegex.MatchString(context)
+		} else if contextObj, ok := parsed["@context"].(map[string]interface{}); ok {
+			if vocab, ok := contextObj["@vocab"].(string); ok {
+				contextMatches = schemaDotOrgRegex.MatchString(vocab)
 			}
+		}
 
-			// Extract publisher
-			if publisher, ok := parsed["publisher"].(map[string]interface{}); ok {
-				if publisherName, ok := publisher["name"].(string); ok {
-					metadata.SiteName = strings.TrimSpace(publisherName)
+		if !contextMatches {
+			continue
+		}
+
+		// Check if it's an article type
+		itemType, ok := parsed["@type"].(string)
+		if !ok || !jsonLdArticleTypesRegex.MatchString(itemType) {
+			continue
+		}
+
+		// Extract metadata
+		if name, ok := parsed["name"].(string); ok && name != "" {
+			metadata.Title = strings.TrimSpace(name)
+		} else if headline, ok := parsed["headline"].(string); ok && headline != "" {
+			metadata.Title = strings.TrimSpace(headline)
+		}
+
+		// Extract author information
+		if author, ok := parsed["author"].(map[string]interface{}); ok {
+			if authorName, ok := author["name"].(string); ok {
+				metadata.Byline = strings.TrimSpace(authorName)
+			}
+		} else if authorArray, ok := parsed["author"].([]interface{}); ok && len(authorArray) > 0 {
+			authorNames := []string{}
+			for _, a := range authorArray {
+				if authorMap, ok := a.(map[string]interface{}); ok {
+					if authorName, ok := authorMap["name"].(string); ok {
+						authorNames = append(authorNames, strings.TrimSpace(authorName))
+					}
 				}
 			}
+			if len(authorNames) > 0 {
+				metadata.Byline = strings.Join(authorNames, ", ")
+			}
+		}
+
+		// Extract description
+		if description, ok := parsed["description"].(string); ok {
+			metadata.Excerpt = strings.TrimSpace(description)
+		}
 
-			// Extract published date
-			if datePublished, ok := parsed["datePublished"].(string); ok {
-				metadata.PublishedTime = strings.TrimSpace(datePublished)
+		// Extract publisher
+		if publisher, ok := parsed["publisher"].(map[string]interface{}); ok {
+			if publisherName, ok := publisher["name"].(string); ok {
+				metadata.SiteName = strings.TrimSpace(publisherName)
 			}
+		}
 
-			return metadata
+		// Extract published date
+		if datePublished, ok := parsed["datePublished"].(string); ok {
+			metadata.PublishedTime = strings.TrimSpace(datePublished)
 		}
+
+		return metadata
 	}
 
 	return metadata
 }
 
+// getMetaContent returns the content of the first meta element whose name, property,
+// or itemprop matches one of the given names, in order of the names.
+//
+// Parameters:
+//   - doc: The document to search
+//   - names: The lowercase meta names, properties, or itemprops to look for
+//
+// Returns:
+//   - The trimmed and unescaped content, or an empty string if none is found
+func getMetaContent(doc *dom.VDocument, names ...string) string {
+	values := make(map[string]string)
+	for _, element := range GetElementsByTagName(doc.DocumentElement, "meta") {
+		content := strings.TrimSpace(element.GetAttribute("content"))
+		if content == "" {
+			continue
+		}
+		for _, attribute := range []string{"property", "name", "itemprop"} {
+			key := strings.ToLower(strings.TrimSpace(element.GetAttribute(attribute)))
+			if _, exists := values[key]; key != "" && !exists {
+				values[key] = content
+			}
+		}
+	}
+	for _, name := range names {
+		if value := values[name]; value != "" {
+			return UnescapeHTMLEntities(value)
+		}
+	}
+	return ""
+}
+
 // UnescapeHTMLEntities converts HTML entities to their corresponding characters.
 // This handles both named entities like &amp; and numeric entities like &#39;.
 //
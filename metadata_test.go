@@ -1,9 +1,12 @@
 package readability
 
 import (
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/mackee/go-readability/internal/dom"
+	"github.com/mackee/go-readability/internal/parser"
 )
 
 func TestGetArticleTitle(t *testing.T) {
@@ -124,6 +127,62 @@ func TestGetArticleTitle(t *testing.T) {
 	}
 }
 
+func TestGetArticleTitleMetaTitle(t *testing.T) {
+	const clutteredTitle = `<title>Breaking: How the city rebuilt its harbor after the storm - Local News - Example Times</title>`
+	tests := []struct {
+		name       string
+		head       string
+		precedence TitlePrecedence
+		expected   string
+	}{
+		{
+			name:     "og:title cleaner than cluttered title",
+			head:     clutteredTitle + `<meta property="og:title" content="How the city rebuilt its harbor after the storm">`,
+			expected: "How the city rebuilt its harbor after the storm",
+		},
+		{
+			name:     "twitter:title without og:title",
+			head:     clutteredTitle + `<meta name="twitter:title" content="How the city rebuilt its harbor after the storm">`,
+			expected: "How the city rebuilt its harbor after the storm",
+		},
+		{
+			name:     "missing title",
+			head:     `<meta property="og:title" content="How the city rebuilt its harbor">`,
+			expected: "How the city rebuilt its harbor",
+		},
+		{
+			name:     "site name in og:title",
+			head:     `<title>How the city rebuilt its harbor after the storm - Example Times</title><meta property="og:title" content="Example Times">`,
+			expected: "How the city rebuilt its harbor after the storm",
+		},
+		{
+			name:       "document precedence",
+			head:       clutteredTitle + `<meta property="og:title" content="How the city rebuilt its harbor after the storm">`,
+			precedence: TitlePrecedenceDocument,
+			expected:   "Breaking: How the city rebuilt its harbor after the storm - Local News",
+		},
+		{
+			name:       "meta precedence",
+			head:       `<title>How the city rebuilt its harbor after the storm - Example Times</title><meta property="og:title" content="Example Times">`,
+			precedence: TitlePrecedenceMeta,
+			expected:   "Example Times",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.ParseHTML("<html><head>"+tt.head+"</head><body><p>Text</p></body></html>", "")
+			if err != nil {
+				t.Fatalf("ParseHTML failed: %v", err)
+			}
+			title := getArticleTitle(doc, 15, 150, tt.precedence)
+			if title != tt.expected {
+				t.Errorf("Expected title %q, got %q", tt.expected, title)
+			}
+		})
+	}
+}
+
 func TestGetArticleByline(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -245,6 +304,156 @@ func TestGetArticleByline(t *testing.T) {
 	}
 }
 
+func TestGetArticleKeywords(t *testing.T) {
+	testCases := []struct {
+		name     string
+		html     string
+		expected []string
+	}{
+		{
+			name: "meta keywords and article:tag",
+			html: `<html><head>
+				<meta name="keywords" content="go, readability , html parsing,,">
+				<meta property="article:tag" content="Go">
+				<meta property="article:tag" content="Web Scraping">
+				<meta property="article:tag" content="Markdown &amp; HTML">
+			</head><body></body></html>`,
+			expected: []string{"go", "readability", "html parsing", "Web Scraping", "Markdown & HTML"},
+		},
+		{
+			name: "rel=tag links",
+			html: `<html><head><meta name="news_keywords" content="news"></head><body>
+				<p>Tags: <a rel="tag" href="/tag/go">Go</a>, <a rel="nofollow tag" href="/tag/web">Web</a>, <a href="/about">About</a></p>
+			</body></html>`,
+			expected: []string{"news", "Go", "Web"},
+		},
+		{
+			name:     "no keywords",
+			html:     `<html><head><meta name="description" content="Nothing"></head><body></body></html>`,
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := ParseHTML(tc.html, "")
+			if err != nil {
+				t.Fatalf("Failed to parse HTML: %v", err)
+			}
+
+			keywords := GetArticleKeywords(doc)
+			if strings.Join(keywords, "|") != strings.Join(tc.expected, "|") || len(keywords) != len(tc.expected) {
+				t.Errorf("Expected keywords %q, got %q", tc.expected, keywords)
+			}
+		})
+	}
+}
+
+func TestGetFaviconURL(t *testing.T) {
+	testCases := []struct {
+		name     string
+		html     string
+		baseURI  string
+		expected string
+	}{
+		{
+			name: "largest of several icons",
+			html: `<html><head>
+				<link rel="icon" href="/favicon-16.png" sizes="16x16">
+				<link rel="apple-touch-icon" href="/touch-180.png" sizes="180x180">
+				<link rel="icon" href="/favicon-32.png" sizes="32x32 48x48">
+			</head><body></body></html>`,
+			baseURI:  "https://example.com/news/article",
+			expected: "https://example.com/touch-180.png",
+		},
+		{
+			name:     "shortcut icon without sizes",
+			html:     `<html><head><link rel="shortcut icon" href="img/icon.ico"></head><body></body></html>`,
+			baseURI:  "https://example.com/news/article",
+			expected: "https://example.com/news/img/icon.ico",
+		},
+		{
+			name:     "scalable icon",
+			html:     `<html><head><link rel="icon" href="/icon-64.png" sizes="64x64"><link rel="icon" href="/icon.svg" sizes="any"></head><body></body></html>`,
+			baseURI:  "https://example.com/",
+			expected: "https://example.com/icon.svg",
+		},
+		{
+			name:     "fallback to favicon.ico",
+			html:     `<html><head><link rel="stylesheet" href="/style.css"></head><body></body></html>`,
+			baseURI:  "https://example.com/news/article",
+			expected: "https://example.com/favicon.ico",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := ParseHTML(tc.html, tc.baseURI)
+			if err != nil {
+				t.Fatalf("Failed to parse HTML: %v", err)
+			}
+
+			if faviconURL := GetFaviconURL(doc); faviconURL != tc.expected {
+				t.Errorf("Expected favicon URL %q, got %q", tc.expected, faviconURL)
+			}
+		})
+	}
+}
+
+func TestGetStructuredData(t *testing.T) {
+	html := `<html><head>
+		<script type="application/ld+json">{
+			"@context": "https://schema.org",
+			"@type": "NewsArticle",
+			"headline": "Readability in Go",
+			"author": {"@type": "Person", "name": "Jane Doe"}
+		}</script>
+		<script type="application/ld+json">{
+			"@context": "https://schema.org",
+			"@graph": [
+				{"@type": "FAQPage", "mainEntity": [{"@type": "Question", "name": "What is it?"}]},
+				{"@type": "BreadcrumbList", "itemListElement": []}
+			]
+		}</script>
+		<script type="application/ld+json">[{"@context": "https://schema.org", "@type": "Product", "name": "Widget"}]</script>
+		<script type="application/ld+json">{ not json }</script>
+	</head><body><p>Body.</p></body></html>`
+
+	doc, err := ParseHTML(html, "")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	objects := GetStructuredData(doc)
+	var types []string
+	for _, object := range objects {
+		itemType, _ := object["@type"].(string)
+		types = append(types, itemType)
+	}
+	expected := []string{"NewsArticle", "FAQPage", "BreadcrumbList", "Product"}
+	if strings.Join(types, "|") != strings.Join(expected, "|") {
+		t.Fatalf("Expected types %q, got %q", expected, types)
+	}
+	if context, _ := objects[1]["@context"].(string); context != "https://schema.org" {
+		t.Errorf("Expected graph items to inherit @context, got %q", context)
+	}
+
+	// The Article-focused metadata is read from the same objects
+	metadata := GetJSONLD(doc)
+	if metadata.Title != "Readability in Go" || metadata.Byline != "Jane Doe" {
+		t.Errorf("Expected article metadata from JSON-LD, got title %q and byline %q", metadata.Title, metadata.Byline)
+	}
+
+	// Extract reads JSON-LD before preprocessing removes the scripts
+	article, err := Extract(html, DefaultOptions())
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if len(article.StructuredData) != len(expected) {
+		t.Errorf("Expected %d structured data objects, got %d", len(expected), len(article.StructuredData))
+	}
+}
+
 func TestUnescapeHTMLEntities(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -361,3 +570,218 @@ func TestTextSimilarity(t *testing.T) {
 		})
 	}
 }
+
+func TestGetArticleSection(t *testing.T) {
+	breadcrumb := `<nav aria-label="Breadcrumb"><ol><li><a href="/">Home</a></li><li><a href="/tech">Technology</a></li></ol></nav>`
+
+	testCases := []struct {
+		name     string
+		html     string
+		expected string
+	}{
+		{
+			name:     "meta tag",
+			html:     `<html><head><meta property="article:section" content="Politics"></head><body>` + breadcrumb + `</body></html>`,
+			expected: "Politics",
+		},
+		{
+			name: "JSON-LD",
+			html: `<html><head><script type="application/ld+json">{"@type":"NewsArticle","articleSection":["Sports","Football"]}</script></head>` +
+				`<body>` + breadcrumb + `</body></html>`,
+			expected: "Sports",
+		},
+		{
+			name:     "breadcrumb nav",
+			html:     `<html><body>` + breadcrumb + `<p>Text</p></body></html>`,
+			expected: "Technology",
+		},
+		{
+			name:     "breadcrumb list",
+			html:     `<html><body><ol class="breadcrumb"><li><a href="/">Home</a></li><li><a href="/food">Food &amp; Drink</a></li></ol></body></html>`,
+			expected: "Food & Drink",
+		},
+		{
+			name:     "ordinary navigation",
+			html:     `<html><body><nav><ul><li><a href="/">Home</a></li><li><a href="/about">About</a></li></ul></nav></body></html>`,
+			expected: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := ParseHTML(tc.html, "")
+			if err != nil {
+				t.Fatalf("Failed to parse HTML: %v", err)
+			}
+			if section := GetArticleSection(doc); section != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, section)
+			}
+		})
+	}
+}
+
+func TestExtractSection(t *testing.T) {
+	html := `<html><head><title>Section</title></head><body>` +
+		`<nav aria-label="breadcrumb"><ol><li><a href="/">Home</a></li><li><a href="/science">Science</a></li></ol></nav><article>` +
+		strings.Repeat(`<p>Readability extracts the main content of a page and drops navigation, advertisements and other clutter from it.</p>`, 5) +
+		`</article></body></html>`
+
+	options := DefaultOptions()
+	options.CharThreshold = 100
+	article, err := Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if article.Section != "Science" {
+		t.Errorf("Expected the breadcrumb section read before preprocessing, got %q", article.Section)
+	}
+}
+
+func TestGetBreadcrumbs(t *testing.T) {
+	// Three levels listed out of order, the last one being the page itself
+	html := `<html><head><script type="application/ld+json">{"@context":"https://schema.org","@graph":[` +
+		`{"@type":"NewsArticle","headline":"Rates rise again"},` +
+		`{"@type":"BreadcrumbList","itemListElement":[` +
+		`{"@type":"ListItem","position":2,"name":"Business","item":"https://example.com/business"},` +
+		`{"@type":"ListItem","position":"1","item":{"@id":"https://example.com/","name":"News"}},` +
+		`{"@type":"ListItem","position":3,"name":"Rates rise again"}]}]}</script></head>` +
+		`<body><article>` +
+		strings.Repeat(`<p>Readability extracts the main content of a page and drops navigation, advertisements and other clutter from it.</p>`, 5) +
+		`</article></body></html>`
+
+	doc, err := ParseHTML(html, "")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+	expected := []string{"News", "Business", "Rates rise again"}
+	if breadcrumbs := GetBreadcrumbs(doc); strings.Join(breadcrumbs, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected breadcrumbs %q, got %q", expected, breadcrumbs)
+	}
+	if section := GetArticleSection(doc); section != "Business" {
+		t.Errorf("Expected the last linked breadcrumb as the section, got %q", section)
+	}
+
+	options := DefaultOptions()
+	options.CharThreshold = 100
+	article, err := Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if strings.Join(article.Breadcrumbs, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected breadcrumbs read before preprocessing %q, got %q", expected, article.Breadcrumbs)
+	}
+
+	doc, err = ParseHTML(`<html><body><p>No structured data</p></body></html>`, "")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+	if breadcrumbs := GetBreadcrumbs(doc); breadcrumbs != nil {
+		t.Errorf("Expected no breadcrumbs, got %q", breadcrumbs)
+	}
+}
+
+func TestExtractTitleLengthBounds(t *testing.T) {
+	longTitle := strings.Repeat("A conventionally long headline ", 7)[:200]
+	html := `<html><head><title>` + longTitle + `</title></head><body><article>` +
+		`<h1>A shorter heading for the same article</h1>` +
+		strings.Repeat(`<p>Readability extracts the main content of a page and drops
+			navigation, advertisements and other clutter from it.</p>`, 5) +
+		`</article></body></html>`
+
+	options := DefaultOptions()
+	article, err := Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if article.Title != "A shorter heading for the same article" {
+		t.Errorf("Expected the <h1> to replace a 200-byte title by default, got %q", article.Title)
+	}
+
+	options.TitleMaxLength = 250
+	article, err = Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if article.Title != strings.TrimSpace(longTitle) {
+		t.Errorf("Expected the 200-byte title to be kept, got %q", article.Title)
+	}
+
+	options.TitleMaxLength = 0
+	article, err = Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if article.Title != "A shorter heading for the same article" {
+		t.Errorf("Expected zero to use the default maximum, got %q", article.Title)
+	}
+}
+
+func TestGetMicrodata(t *testing.T) {
+	testCases := []struct {
+		name     string
+		html     string
+		expected ReadabilityMetadata
+	}{
+		{
+			name: "article with nested author",
+			html: `<html><body><article itemscope itemtype="http://schema.org/BlogPosting">` +
+				`<h1 itemprop="headline">Microdata Title</h1>` +
+				`<span itemprop="author" itemscope itemtype="https://schema.org/Person">By <span itemprop="name">Jane Doe</span></span>` +
+				`<time datetime="2024-03-01" itemprop="datePublished">March 1</time>` +
+				`<meta itemprop="description" content="A summary">` +
+				`<p>Body text</p></article></body></html>`,
+			expected: ReadabilityMetadata{
+				Title:         "Microdata Title",
+				Byline:        "Jane Doe",
+				Excerpt:       "A summary",
+				PublishedTime: "2024-03-01",
+			},
+		},
+		{
+			name: "multiple authors skipping URLs",
+			html: `<html><body><div itemscope itemtype="http://schema.org/NewsArticle">` +
+				`<span itemprop="author">Alice</span><span itemprop="author">Bob</span>` +
+				`<a itemprop="author" href="https://example.com/carol">https://example.com/carol</a>` +
+				`</div></body></html>`,
+			expected: ReadabilityMetadata{Byline: "Alice, Bob"},
+		},
+		{
+			name: "standalone person author",
+			html: `<html><body><p class="byline"><span itemprop="author" itemscope itemtype="http://schema.org/Person">` +
+				`<span itemprop="name">John Smith</span></span></p></body></html>`,
+			expected: ReadabilityMetadata{Byline: "John Smith"},
+		},
+		{
+			name: "other vocabulary ignored",
+			html: `<html><body><div itemscope itemtype="http://example.com/Article">` +
+				`<span itemprop="author">Nobody</span></div></body></html>`,
+			expected: ReadabilityMetadata{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := parser.ParseHTML(tc.html, "")
+			if err != nil {
+				t.Fatalf("Failed to parse HTML: %v", err)
+			}
+			if got := GetMicrodata(doc); got != tc.expected {
+				t.Errorf("Expected %+v, got %+v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestExtractMicrodataByline(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/fixtures/001/source.html")
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	article, err := Extract(string(fixture), DefaultOptions())
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if article.Byline != "Nicolas Perriault" {
+		t.Errorf("Expected the microdata author as byline, got %q", article.Byline)
+	}
+}
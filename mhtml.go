@@ -0,0 +1,220 @@
+// Package readability provides functionality to extract readable content from HTML documents.
+// It implements an algorithm similar to Mozilla's Readability.js to identify and extract
+// the main content from web pages, removing clutter, navigation, ads, and other non-content elements.
+package readability
+
+import (
+	"bufio"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"slices"
+	"strings"
+)
+
+// errNoHTMLPart is returned, wrapped in ErrParseFailed, for MHTML files without a text/html part.
+var errNoHTMLPart = errors.New("no text/html part in MHTML")
+
+// mhtmlPart is a decoded part of an MHTML file.
+type mhtmlPart struct {
+	contentType string // The media type, e.g. "text/html"
+	contentID   string // The Content-ID without angle brackets
+	location    string // The Content-Location, usually the original URL
+	body        []byte // The content, with the transfer encoding removed
+}
+
+// ExtractMHTML extracts the article content of a web page saved as MHTML (.mhtml or .mht),
+// a MIME multipart/related message holding the page and its resources. The primary
+// text/html part is extracted: the part named by the start parameter of the message, or the
+// part at the Snapshot-Content-Location, or else the first text/html part. A single-part
+// text/html message is extracted as is. Other parts are ignored, except for cid: references
+// with options.ResolveMHTMLImages.
+//
+// Parameters:
+//   - r: The MHTML data
+//   - options: Configuration options for the extraction process. Without options.BaseURL,
+//     the Content-Location of the HTML part is the base URL.
+//
+// Returns:
+//   - A ReadabilityArticle containing the extracted content and metadata
+//   - An error wrapping ErrParseFailed if the data is not a MIME message with an HTML part,
+//     or any error returned by Extract
+func ExtractMHTML(r io.Reader, options ReadabilityOptions) (ReadabilityArticle, error) {
+	parts, start, err := readMHTMLParts(r)
+	if err != nil {
+		return ReadabilityArticle{}, parseError(err)
+	}
+	page := primaryMHTMLPart(parts, start)
+	if page == nil {
+		return ReadabilityArticle{}, parseError(errNoHTMLPart)
+	}
+
+	html := string(page.body)
+	if options.ResolveMHTMLImages {
+		html = resolveMHTMLReferences(html, parts)
+	}
+	if options.BaseURL == "" {
+		options.BaseURL = page.location
+	}
+	return Extract(html, options)
+}
+
+// readMHTMLParts reads the header of an MHTML message and decodes its parts.
+//
+// Parameters:
+//   - r: The MHTML data
+//
+// Returns:
+//   - The parts in order; a single part for a message that is not multipart
+//   - The location or Content-ID of the primary part named by the message, or ""
+//   - An error if the header or the MIME structure is malformed
+func readMHTMLParts(r io.Reader) ([]*mhtmlPart, string, error) {
+	br := bufio.NewReader(r)
+	header, err := textproto.NewReader(br).ReadMIMEHeader()
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid MHTML header: %w", err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid MHTML content type: %w", err)
+	}
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		part, err := decodeMHTMLPart(header, br)
+		if err != nil {
+			return nil, "", err
+		}
+		return []*mhtmlPart{part}, "", nil
+	}
+
+	start := strings.Trim(params["start"], "<>")
+	if start == "" {
+		start = header.Get("Snapshot-Content-Location")
+	}
+	var parts []*mhtmlPart
+	reader := multipart.NewReader(br, params["boundary"])
+	for {
+		p, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid MHTML part: %w", err)
+		}
+		// NextPart removes quoted-printable encoding itself
+		part, err := decodeMHTMLPart(p.Header, p)
+		if err != nil {
+			return nil, "", err
+		}
+		parts = append(parts, part)
+	}
+	return parts, start, nil
+}
+
+// decodeMHTMLPart reads the content of a part and removes its base64 transfer encoding.
+//
+// Parameters:
+//   - header: The header of the part
+//   - body: The content of the part
+//
+// Returns:
+//   - The decoded part
+//   - An error if the content cannot be read or decoded
+func decodeMHTMLPart(header textproto.MIMEHeader, body io.Reader) (*mhtmlPart, error) {
+	if strings.EqualFold(strings.TrimSpace(header.Get("Content-Transfer-Encoding")), "base64") {
+		body = base64.NewDecoder(base64.StdEncoding, &base64Cleaner{r: body})
+	}
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MHTML part content: %w", err)
+	}
+
+	contentType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		contentType = ""
+	}
+	return &mhtmlPart{
+		contentType: contentType,
+		contentID:   strings.Trim(strings.TrimSpace(header.Get("Content-ID")), "<>"),
+		location:    strings.TrimSpace(header.Get("Content-Location")),
+		body:        content,
+	}, nil
+}
+
+// base64Cleaner drops the line breaks and spaces of base64 content, which the decoder rejects.
+type base64Cleaner struct {
+	r io.Reader
+}
+
+// Read implements io.Reader.
+func (c *base64Cleaner) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	kept := 0
+	for _, b := range p[:n] {
+		if b != '\r' && b != '\n' && b != ' ' && b != '\t' {
+			p[kept] = b
+			kept++
+		}
+	}
+	return kept, err
+}
+
+// primaryMHTMLPart finds the page among the parts of an MHTML message.
+//
+// Parameters:
+//   - parts: The parts of the message
+//   - start: The location or Content-ID of the primary part named by the message, or ""
+//
+// Returns:
+//   - The text/html part named by start, or else the first text/html part, or nil if there is none
+func primaryMHTMLPart(parts []*mhtmlPart, start string) *mhtmlPart {
+	var first *mhtmlPart
+	for _, part := range parts {
+		if part.contentType != "text/html" {
+			continue
+		}
+		if start != "" && (part.location == start || part.contentID == start) {
+			return part
+		}
+		if first == nil {
+			first = part
+		}
+	}
+	return first
+}
+
+// resolveMHTMLReferences rewrites the cid: URLs in the HTML of an MHTML page to the
+// Content-Location of the referenced parts, or to data: URLs holding them.
+//
+// Parameters:
+//   - html: The HTML of the page
+//   - parts: The parts of the MHTML message
+//
+// Returns:
+//   - The HTML with the references to embedded parts resolved
+func resolveMHTMLReferences(html string, parts []*mhtmlPart) string {
+	if !strings.Contains(html, "cid:") {
+		return html
+	}
+
+	// Longer IDs first, so that an ID is never replaced by a prefix of it
+	withID := slices.DeleteFunc(slices.Clone(parts), func(part *mhtmlPart) bool { return part.contentID == "" })
+	slices.SortStableFunc(withID, func(a, b *mhtmlPart) int { return len(b.contentID) - len(a.contentID) })
+
+	var replacements []string
+	for _, part := range withID {
+		target := part.location
+		if target == "" || strings.HasPrefix(target, "cid:") {
+			target = "data:" + part.contentType + ";base64," + base64.StdEncoding.EncodeToString(part.body)
+		}
+		replacements = append(replacements, "cid:"+part.contentID, target)
+	}
+	if len(replacements) == 0 {
+		return html
+	}
+	return strings.NewReplacer(replacements...).Replace(html)
+}
@@ -0,0 +1,87 @@
+package readability
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestExtractMHTML(t *testing.T) {
+	source, err := os.ReadFile("testdata/mhtml/source.mhtml")
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+
+	options := DefaultOptions()
+	options.CharThreshold = 100
+	article, err := ExtractMHTML(strings.NewReader(string(source)), options)
+	if err != nil {
+		t.Fatalf("ExtractMHTML failed: %v", err)
+	}
+	if article.Title != "Field notes from the coastal survey" {
+		t.Errorf("Unexpected title %q", article.Title)
+	}
+	if article.Root == nil {
+		t.Fatal("Expected content to be extracted")
+	}
+	text := GetInnerText(article.Root, true)
+	if !strings.Contains(text, "sent to the laboratory for analysis.") {
+		t.Errorf("Expected the quoted-printable page to be decoded, got %q", text)
+	}
+	if strings.Contains(text, "Advertisement") {
+		t.Errorf("Expected only the primary HTML part to be extracted, got %q", text)
+	}
+	if len(article.Images) != 1 || article.Images[0].Src != "cid:chart@mhtml.example" {
+		t.Errorf("Expected the cid: image to be kept by default, got %+v", article.Images)
+	}
+	// The Content-Location of the page is the base URL
+	if article.FaviconURL != "https://example.com/favicon.ico" {
+		t.Errorf("Expected the favicon to be resolved against the page location, got %q", article.FaviconURL)
+	}
+
+	options.ResolveMHTMLImages = true
+	article, err = ExtractMHTML(strings.NewReader(string(source)), options)
+	if err != nil {
+		t.Fatalf("ExtractMHTML failed: %v", err)
+	}
+	if len(article.Images) != 1 || !strings.HasPrefix(article.Images[0].Src, "data:image/png;base64,iVBORw0KGgo") {
+		t.Errorf("Expected the cid: image to be resolved to a data: URL, got %+v", article.Images)
+	}
+}
+
+func TestExtractMHTMLSinglePart(t *testing.T) {
+	message := "Content-Type: text/html; charset=utf-8\r\n" +
+		"Content-Location: https://example.com/page\r\n\r\n" +
+		"<html><head><title>Single part page</title></head><body><article>" +
+		strings.Repeat("<p>A single-part MHTML file holds only the page, without its resources.</p>", 5) +
+		"</article></body></html>"
+
+	options := DefaultOptions()
+	options.CharThreshold = 100
+	article, err := ExtractMHTML(strings.NewReader(message), options)
+	if err != nil {
+		t.Fatalf("ExtractMHTML failed: %v", err)
+	}
+	if article.Root == nil || !strings.Contains(GetInnerText(article.Root, true), "single-part MHTML") {
+		t.Errorf("Expected the page to be extracted, got %+v", article.Root)
+	}
+}
+
+func TestExtractMHTMLErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+	}{
+		{name: "plain HTML", message: "<html><body><p>Not MHTML</p></body></html>"},
+		{name: "no HTML part", message: "Content-Type: multipart/related; boundary=b\r\n\r\n" +
+			"--b\r\nContent-Type: image/png\r\nContent-Location: https://example.com/a.png\r\n\r\nPNG\r\n--b--\r\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ExtractMHTML(strings.NewReader(tt.message), DefaultOptions()); !errors.Is(err, ErrParseFailed) {
+				t.Errorf("Expected ErrParseFailed, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+// Package readability provides functionality to extract readable content from HTML documents.
+// It implements an algorithm similar to Mozilla's Readability.js to identify and extract
+// the main content from web pages, removing clutter, navigation, ads, and other non-content elements.
+package readability
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/mackee/go-readability/internal/dom"
+)
+
+// microdataTypeRegex matches a schema.org itemtype URL and captures the type name
+var microdataTypeRegex = regexp.MustCompile(`^https?://schema\.org/([A-Za-z]+)/?$`)
+
+// GetMicrodata extracts metadata from schema.org microdata (itemscope/itemtype/itemprop
+// attributes) in the document. The first item of an article type (as for JSON-LD) provides
+// the title (headline or name), author, description, and publication date. Without an
+// article item, the name of any Person item used as itemprop="author" is taken as the author.
+//
+// Parameters:
+//   - doc: The parsed HTML document
+//
+// Returns:
+//   - ReadabilityMetadata containing the information found in the microdata
+func GetMicrodata(doc *dom.VDocument) ReadabilityMetadata {
+	metadata := ReadabilityMetadata{}
+	if doc == nil || doc.DocumentElement == nil {
+		return metadata
+	}
+
+	var authors []string
+	for _, element := range GetElementsByTagName(doc.DocumentElement, "*") {
+		if !element.HasAttribute("itemscope") {
+			continue
+		}
+		if jsonLdArticleTypesRegex.MatchString(microdataType(element)) {
+			properties := microdataProperties(element)
+			if headline := firstMicrodataValue(properties, "headline", "name"); headline != "" {
+				metadata.Title = headline
+			}
+			metadata.Byline = strings.Join(microdataAuthors(properties["author"]), ", ")
+			metadata.Excerpt = firstMicrodataValue(properties, "description")
+			metadata.PublishedTime = firstMicrodataValue(properties, "datePublished")
+			return metadata
+		}
+		if microdataType(element) == "Person" && hasItemprop(element, "author") {
+			authors = append(authors, microdataAuthors([]*dom.VElement{element})...)
+		}
+	}
+
+	metadata.Byline = strings.Join(authors, ", ")
+	return metadata
+}
+
+// microdataType returns the schema.org type name of an item, e.g. "BlogPosting" for
+// itemtype="http://schema.org/BlogPosting", or an empty string for other vocabularies.
+func microdataType(item *dom.VElement) string {
+	for _, itemType := range strings.Fields(item.GetAttribute("itemtype")) {
+		if match := microdataTypeRegex.FindStringSubmatch(itemType); match != nil {
+			return match[1]
+		}
+	}
+	return ""
+}
+
+// hasItemprop checks whether an element is the value of the given item property.
+func hasItemprop(element *dom.VElement, name string) bool {
+	for _, property := range strings.Fields(element.GetAttribute("itemprop")) {
+		if property == name {
+			return true
+		}
+	}
+	return false
+}
+
+// microdataProperties collects the properties of an item: the descendants with an itemprop
+// attribute, by property name in document order. The properties of nested items belong to
+// those items and are not collected.
+//
+// Parameters:
+//   - item: The element with the itemscope attribute
+//
+// Returns:
+//   - The property elements by property name
+func microdataProperties(item *dom.VElement) map[string][]*dom.VElement {
+	properties := make(map[string][]*dom.VElement)
+	var walk func(element *dom.VElement)
+	walk = func(element *dom.VElement) {
+		for _, child := range element.Children {
+			childElement, ok := dom.AsVElement(child)
+			if !ok {
+				continue
+			}
+			for _, name := range strings.Fields(childElement.GetAttribute("itemprop")) {
+				properties[name] = append(properties[name], childElement)
+			}
+			if !childElement.HasAttribute("itemscope") {
+				walk(childElement)
+			}
+		}
+	}
+	walk(item)
+	return properties
+}
+
+// microdataValue returns the text value of a property element: the content of <meta>,
+// the datetime of <time>, the value of <data> and <meter>, or the text of any other element.
+// Unlike the microdata specification, links and media yield their text rather than their
+// URL, since only textual properties are read.
+func microdataValue(element *dom.VElement) string {
+	var value string
+	switch element.TagName {
+	case "meta":
+		value = element.GetAttribute("content")
+	case "data", "meter":
+		value = element.GetAttribute("value")
+	case "time":
+		value = element.GetAttribute("datetime")
+	}
+	if strings.TrimSpace(value) == "" {
+		value = GetInnerText(element, true)
+	}
+	return strings.TrimSpace(value)
+}
+
+// firstMicrodataValue returns the value of the first property found among the given names.
+func firstMicrodataValue(properties map[string][]*dom.VElement, names ...string) string {
+	for _, name := range names {
+		for _, element := range properties[name] {
+			if value := microdataValue(element); value != "" {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+// microdataAuthors returns the names of author property elements: the name of a nested
+// Person or Organization item, or the text of a plain element. URLs are skipped.
+//
+// Parameters:
+//   - elements: The author property elements
+//
+// Returns:
+//   - The author names in document order
+func microdataAuthors(elements []*dom.VElement) []string {
+	var names []string
+	for _, element := range elements {
+		name := microdataValue(element)
+		if element.HasAttribute("itemscope") {
+			name = firstMicrodataValue(microdataProperties(element), "name")
+		}
+		if name != "" && !IsURL(name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
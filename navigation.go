@@ -0,0 +1,118 @@
+// Package readability provides functionality to extract readable content from HTML documents.
+// It implements an algorithm similar to Mozilla's Readability.js to identify and extract
+// the main content from web pages, removing clutter, navigation, ads, and other non-content elements.
+package readability
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/mackee/go-readability/internal/dom"
+)
+
+// Regular expressions for previous/next article links
+var (
+	// Class and ID names of previous post links, such as nav-previous or prev-post
+	prevLinkRegex = regexp.MustCompile(`(?i)(^|[^a-z])prev(ious)?([^a-z]|$)`)
+	// Class and ID names of next post links, such as nav-next or next-post
+	nextLinkRegex = regexp.MustCompile(`(?i)(^|[^a-z])next([^a-z]|$)`)
+	// Class and ID names of page number navigation, whose links are not adjacent articles
+	paginationRegex = regexp.MustCompile(`(?i)paginat|pager|page-numbers`)
+)
+
+// Kinds of adjacent article links
+const (
+	adjacentPrev = "prev"
+	adjacentNext = "next"
+)
+
+// GetAdjacentArticleURLs finds the links to the previous and next articles of a series or
+// blog. It checks <link rel="prev"> and <link rel="next"> first, then links in the page with
+// rel="prev"/"next" or inside post navigation such as <div class="nav-previous">.
+// Links inside page number navigation (pagination) are ignored.
+// Post navigation is usually outside the content, so this must run before preprocessing.
+//
+// Parameters:
+//   - doc: The parsed HTML document
+//
+// Returns:
+//   - The URL of the previous article, resolved against the document's base URI, or ""
+//   - The URL of the next article, resolved against the document's base URI, or ""
+func GetAdjacentArticleURLs(doc *dom.VDocument) (string, string) {
+	if doc == nil || doc.DocumentElement == nil {
+		return "", ""
+	}
+
+	urls := map[string]string{}
+	for _, link := range GetElementsByTagName(doc.DocumentElement, "link") {
+		href := strings.TrimSpace(link.GetAttribute("href"))
+		if kind := relAdjacentKind(link); kind != "" && href != "" && urls[kind] == "" {
+			urls[kind] = href
+		}
+	}
+
+	if hasBody(doc) {
+		for _, a := range GetElementsByTagName(doc.Body, "a") {
+			href := strings.TrimSpace(a.GetAttribute("href"))
+			if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(strings.ToLower(href), "javascript:") {
+				continue
+			}
+			if kind := adjacentLinkKind(a); kind != "" && urls[kind] == "" {
+				urls[kind] = href
+			}
+		}
+	}
+
+	prevURL, nextURL := urls[adjacentPrev], urls[adjacentNext]
+	if prevURL != "" {
+		prevURL = resolveURL(doc.BaseURI, prevURL)
+	}
+	if nextURL != "" {
+		nextURL = resolveURL(doc.BaseURI, nextURL)
+	}
+	return prevURL, nextURL
+}
+
+// relAdjacentKind returns adjacentPrev or adjacentNext if the rel attribute of an element
+// contains prev, previous, or next, and an empty string otherwise.
+func relAdjacentKind(element *dom.VElement) string {
+	for _, rel := range strings.Fields(strings.ToLower(element.GetAttribute("rel"))) {
+		switch rel {
+		case "prev", "previous":
+			return adjacentPrev
+		case "next":
+			return adjacentNext
+		}
+	}
+	return ""
+}
+
+// adjacentLinkKind decides whether a link in the page points to the previous or next article:
+// by its rel attribute, or by the class and ID of the link and its two closest ancestors.
+//
+// Parameters:
+//   - a: The <a> element
+//
+// Returns:
+//   - adjacentPrev, adjacentNext, or an empty string for other links and pagination links
+func adjacentLinkKind(a *dom.VElement) string {
+	for depth, ancestor := 0, a.Parent(); ancestor != nil && depth < 3; depth, ancestor = depth+1, ancestor.Parent() {
+		if paginationRegex.MatchString(ancestor.ClassName() + " " + ancestor.ID()) {
+			return ""
+		}
+	}
+
+	if kind := relAdjacentKind(a); kind != "" {
+		return kind
+	}
+	for depth, element := 0, a; element != nil && depth < 3; depth, element = depth+1, element.Parent() {
+		matchString := element.ClassName() + " " + element.ID()
+		switch {
+		case prevLinkRegex.MatchString(matchString):
+			return adjacentPrev
+		case nextLinkRegex.MatchString(matchString):
+			return adjacentNext
+		}
+	}
+	return ""
+}
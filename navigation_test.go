@@ -0,0 +1,88 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetAdjacentArticleURLs(t *testing.T) {
+	testCases := []struct {
+		name         string
+		html         string
+		expectedPrev string
+		expectedNext string
+	}{
+		{
+			name: "link rel",
+			html: `<html><head><link rel="prev" href="/posts/1"><link rel="next" href="https://example.com/posts/3"></head>` +
+				`<body><p>Text</p></body></html>`,
+			expectedPrev: "https://example.com/posts/1",
+			expectedNext: "https://example.com/posts/3",
+		},
+		{
+			name: "post navigation",
+			html: `<html><body><article><p>Text</p></article>` +
+				`<nav class="post-navigation"><div class="nav-links">` +
+				`<div class="nav-previous"><a href="older-post">Older post</a></div>` +
+				`<div class="nav-next"><a href="newer-post">Newer post</a></div>` +
+				`</div></nav></body></html>`,
+			expectedPrev: "https://example.com/posts/older-post",
+			expectedNext: "https://example.com/posts/newer-post",
+		},
+		{
+			name: "link rel wins over anchors",
+			html: `<html><head><link rel="next" href="/posts/3"></head><body><p>Text</p>` +
+				`<a rel="prev" href="/posts/1">Previous</a><a rel="next" href="/posts/4">Next</a></body></html>`,
+			expectedPrev: "https://example.com/posts/1",
+			expectedNext: "https://example.com/posts/3",
+		},
+		{
+			name: "pagination ignored",
+			html: `<html><body><p>Text</p><div class="pagination">` +
+				`<a class="prev" href="?page=1">Prev</a><a href="?page=3" rel="next">Next</a></div></body></html>`,
+			expectedPrev: "",
+			expectedNext: "",
+		},
+		{
+			name:         "placeholder links ignored",
+			html:         `<html><body><p>Text</p><a class="next-post" href="#">Next</a></body></html>`,
+			expectedPrev: "",
+			expectedNext: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := ParseHTML(tc.html, "https://example.com/posts/2")
+			if err != nil {
+				t.Fatalf("Failed to parse HTML: %v", err)
+			}
+			prevURL, nextURL := GetAdjacentArticleURLs(doc)
+			if prevURL != tc.expectedPrev {
+				t.Errorf("Expected previous URL %q, got %q", tc.expectedPrev, prevURL)
+			}
+			if nextURL != tc.expectedNext {
+				t.Errorf("Expected next URL %q, got %q", tc.expectedNext, nextURL)
+			}
+		})
+	}
+}
+
+func TestExtractAdjacentArticleURLs(t *testing.T) {
+	html := `<html><head><title>Series</title><link rel="prev" href="/series/part-1"></head><body><article>` +
+		strings.Repeat(`<p>Readability extracts the main content of a page and drops navigation, advertisements and other clutter from it.</p>`, 5) +
+		`</article><nav><a class="next-post" href="/series/part-3">Part 3</a></nav></body></html>`
+
+	options := DefaultOptions()
+	options.BaseURL = "https://example.com/series/part-2"
+	article, err := Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if article.PrevURL != "https://example.com/series/part-1" {
+		t.Errorf("Expected the previous article URL, got %q", article.PrevURL)
+	}
+	if article.NextURL != "https://example.com/series/part-3" {
+		t.Errorf("Expected the next article URL read before preprocessing, got %q", article.NextURL)
+	}
+}
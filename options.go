@@ -3,6 +3,13 @@
 // the main content from web pages, removing clutter, navigation, ads, and other non-content elements.
 package readability
 
+import (
+	"log/slog"
+	"time"
+
+	"github.com/mackee/go-readability/internal/util"
+)
+
 // PageType represents the type of a page (article, other, etc.)
 // This is used to classify pages based on their content structure and characteristics.
 type PageType string
@@ -15,18 +22,184 @@ const (
 	// Future types like INDEX, LIST, ERROR can be added here
 )
 
+// TitlePrecedence controls whether the og:title and twitter:title meta tags are used as
+// the article title instead of the title derived from <title> and the headings.
+type TitlePrecedence string
+
+const (
+	// TitlePrecedenceAuto uses og:title (or twitter:title) when <title> is missing, or when
+	// it closely matches the <title> without the site name, since it is usually cleaner
+	TitlePrecedenceAuto TitlePrecedence = ""
+	// TitlePrecedenceDocument ignores the meta titles and only uses <title> and the headings
+	TitlePrecedenceDocument TitlePrecedence = "document"
+	// TitlePrecedenceMeta uses og:title (or twitter:title) whenever it is present
+	TitlePrecedenceMeta TitlePrecedence = "meta"
+)
+
+// OutputFormat is the format the extracted content is rendered to. It decides how
+// ReadabilityOptions.MaxOutputChars measures the length of the content.
+type OutputFormat string
+
+const (
+	// OutputFormatText measures the whitespace-normalized text of the content
+	OutputFormatText OutputFormat = ""
+	// OutputFormatHTML measures the HTML rendered by ToHTML, markup included
+	OutputFormatHTML OutputFormat = "html"
+	// OutputFormatMarkdown measures the Markdown rendered by ToMarkdown, syntax included
+	OutputFormatMarkdown OutputFormat = "markdown"
+)
+
 // ReadabilityOptions contains configuration options for the readability extraction process.
 // These options control various aspects of the content extraction algorithm, such as
 // thresholds, candidate selection, and output format.
 type ReadabilityOptions struct {
+	// BaseURL is the URL of the document, used to resolve relative URLs such as FaviconURL
+	BaseURL string
 	// CharThreshold is the minimum number of characters an article must have
 	CharThreshold int
+	// TitleMaxLength is the length (in bytes) above which the <title> is replaced by the
+	// page's only <h1>. Zero uses the default of 150.
+	TitleMaxLength int
+	// TitleMinLength is the length (in bytes) below which the <title> is replaced by the
+	// page's only <h1>. Zero uses the default of 15.
+	TitleMinLength int
+	// TitlePrecedence decides between the og:title/twitter:title meta tags and the <title>
+	// for the article title. The zero value is TitlePrecedenceAuto.
+	TitlePrecedence TitlePrecedence
+	// MaxDepth is the maximum element nesting depth descended when building the ARIA tree.
+	// Deeper elements are treated as leaves, so that pathologically nested pages do not
+	// exhaust the stack. Zero uses the default of 1000.
+	MaxDepth int
+	// AllowShortContent accepts a top candidate below CharThreshold when it is clearly the
+	// main content (an article/main element or a strongly positive class) with few links
+	AllowShortContent bool
+	// AutoThreshold scales CharThreshold to the dominant script of the content
+	// (e.g. lowers it for CJK text, where fewer characters make up an article)
+	AutoThreshold bool
+	// Lang is the language of the document (a tag such as "ja" or "en-US"), used when the
+	// document does not declare one with <html lang>; a declared language always wins. The
+	// resulting language lowers CharThreshold for Chinese, Japanese, and Korean like
+	// AutoThreshold does for CJK text, and is returned as ReadabilityArticle.Lang for
+	// splitting the content into sentences (see ToSentences).
+	Lang string
 	// NbTopCandidates is the number of top candidates to consider
 	NbTopCandidates int
+	// AncestorDepth is the number of ancestor levels that receive an element's score.
+	// The share each level receives is scaled to the depth. Zero uses the default of 3;
+	// a negative depth is invalid
+	AncestorDepth int
+	// TagsToScore overrides the tag names whose text is scored to find the content
+	// (see DefaultTagsToScore). Entries are lowercased and duplicates are ignored. Empty uses
+	// the default set.
+	TagsToScore []string
+	// IncludeOnlySelectors restricts extraction to the elements matching these CSS selectors
+	// (tag, *, #id, .class, [attr], and [attr=value], optionally comma-separated). The content
+	// is the matching subtrees in document order, without candidate scoring. Empty disables it.
+	IncludeOnlySelectors []string
+	// StopSelector is a CSS selector (same syntax as IncludeOnlySelectors) for an end-of-content
+	// marker such as .article-end. The first matching element inside the content and everything
+	// after it are removed, e.g. a comment section inside the content container. Empty disables it.
+	StopSelector string
+	// ExtraUnlikelyPatterns are regular expressions added to the unlikely-candidate class/ID patterns.
+	// Content inside elements matching one of them (and no likely pattern) is not scored.
+	ExtraUnlikelyPatterns []string
+	// ExtraLikelyPatterns are regular expressions that keep an element a candidate even if it
+	// matches an unlikely pattern
+	ExtraLikelyPatterns []string
+	// ExtraPositivePatterns are regular expressions for class/ID names that raise a candidate's score
+	ExtraPositivePatterns []string
+	// ExtraNegativePatterns are regular expressions for class/ID names that lower a candidate's score.
+	// GetClassWeightWithOptions and IsProbablyContentWithOptions apply the extra patterns
+	// outside of extraction.
+	ExtraNegativePatterns []string
+	// TagScores overrides the base scores of candidates by tag name, merged over the defaults
+	// (div +5; pre, td, blockquote +3; lists, address, form -3; headings, th -5). Keys must be
+	// lowercase tag names. For example, {"h1": 0} stops penalizing <h1> containers.
+	TagScores map[string]float64
+	// AriaMaxGroupSize caps the number of similar siblings (articles, regions, list items, and
+	// images) merged into one group of the ARIA tree built by Document.AriaTree. The others are
+	// summarized by a text node counting them. Zero means no limit.
+	AriaMaxGroupSize int
 	// GenerateAriaTree indicates whether to generate ARIA tree representation
 	GenerateAriaTree bool
 	// ForcedPageType allows forcing a specific page type classification
 	ForcedPageType PageType
+	// UnwrapTemplates promotes the content of <template> and <noscript> elements into the
+	// document before preprocessing, for sites that only put the real markup there
+	UnwrapTemplates bool
+	// ParseCommentedContent parses HTML comments that contain block-level markup and puts the
+	// result in place of the comment, for pages that ship their article commented out
+	ParseCommentedContent bool
+	// ResolveMHTMLImages makes ExtractMHTML rewrite cid: references to the embedded parts of
+	// the MHTML file, such as <img src="cid:image1">, to the parts' Content-Location, or to
+	// data: URLs holding the parts when they have no location
+	ResolveMHTMLImages bool
+	// StripInvisibleChars removes zero-width spaces, soft hyphens, byte order marks, and
+	// control characters from the document text before extraction
+	StripInvisibleChars bool
+	// NormalizeUnicode converts the document text, the title, and the byline to Unicode
+	// normalization form C (NFC), so that decomposed characters such as "e" followed by a
+	// combining accent become the composed "é" in every output (Markdown, HTML, Stringify)
+	NormalizeUnicode bool
+	// DropScreenReaderOnly removes text meant for screen readers only (.sr-only,
+	// .visually-hidden, or hidden off-screen or by clipping) before scoring, when it repeats
+	// visible text. Screen-reader-only text found nowhere else is kept
+	DropScreenReaderOnly bool
+	// StrictErrors makes Extract return ErrEmptyDocument for documents without text and
+	// ErrNoContent when an article page yields no content, instead of a nil Root and no error
+	StrictErrors bool
+	// MaxOutputChars limits the length of the extracted content rendered in OutputFormat,
+	// in characters (runes). Content beyond the limit is cut at a word boundary and an
+	// ellipsis is appended. Zero means no limit. This option is only available to library
+	// callers; the readability command has no flag for it.
+	MaxOutputChars int
+	// OutputFormat is the format MaxOutputChars is measured in. The default measures the
+	// text; OutputFormatHTML and OutputFormatMarkdown also count the markup, so that the
+	// output of ToHTML or ToMarkdown fits within the limit
+	OutputFormat OutputFormat
+	// MaxParagraphs keeps only the first paragraphs of the extracted content (lists, quotes,
+	// and tables count as one paragraph), with any headings and images before them.
+	// Zero means no limit.
+	MaxParagraphs int
+	// IncludeByline moves the author/date line found just above the content into Root,
+	// so that standalone output keeps its attribution
+	IncludeByline bool
+	// DedupeBylineInContent removes the author line (e.g. "By Jane Doe") from the start of
+	// Root when it closely matches the extracted Byline, so that output does not repeat it.
+	// It has no effect with IncludeByline.
+	DedupeBylineInContent bool
+	// TrimBoilerplate removes copyright notices, legal lines, and short link-only lines
+	// from the end of Root, stopping at the first substantial paragraph
+	TrimBoilerplate bool
+	// MergeInlineElements joins adjacent identical inline elements in Root
+	// (e.g. <b>Hel</b><b>lo</b> becomes <b>Hello</b>) so that output is not fragmented
+	MergeInlineElements bool
+	// RemoveNavLike removes menus, tag clouds, and other link-heavy, text-poor elements
+	// that survived inside Root
+	RemoveNavLike bool
+	// RemoveEmptyLinks unwraps links without text or image and links to "#" or "" in Root,
+	// keeping their content, so that they do not render as []() in Markdown
+	RemoveEmptyLinks bool
+	// ResolveFootnotes links footnote references in Root (e.g. <sup><a href="#fn1">1</a></sup>)
+	// to their definitions anywhere in the document, by ID. The references are renumbered
+	// in order, and the definitions are moved into an <ol data-footnotes> at the end of Root,
+	// which Markdown renders as [^1] footnotes
+	ResolveFootnotes bool
+	// IncludeRawHTML sets ReadabilityArticle.RawHTML to the original markup of the selected
+	// content, with all attributes, before any postprocessing options are applied
+	IncludeRawHTML bool
+	// PreferCleanVariant makes ExtractFromURL extract the linked AMP or print version
+	// of a page when available, falling back to the page itself
+	PreferCleanVariant bool
+	// Now is the reference time for resolving relative publication dates ("3 days ago").
+	// The zero value means the current time.
+	Now time.Time
+	// Debug sets ReadabilityArticle.Candidates to a summary of the top NbTopCandidates
+	// candidates and their scores
+	Debug bool
+	// Logger receives debug events about the extraction (removed elements, chosen candidate,
+	// page type decision). Nil disables logging.
+	Logger *slog.Logger
 	// Parser is a custom HTML parser function (not used in the Go implementation as we use golang.org/x/net/html)
 	// This is kept as a placeholder to match the TypeScript API
 	// Parser func(string) (*dom.VDocument, error)
@@ -41,7 +214,39 @@ type ReadabilityOptions struct {
 func DefaultOptions() ReadabilityOptions {
 	return ReadabilityOptions{
 		CharThreshold:    500,   // Default minimum character threshold
+		TitleMaxLength:   150,   // Default maximum title length before falling back to <h1>
+		TitleMinLength:   15,    // Default minimum title length before falling back to <h1>
+		MaxDepth:         1000,  // Default maximum nesting depth of the recursive tree walks
 		NbTopCandidates:  5,     // Default number of top candidates
+		AncestorDepth:    3,     // Default number of ancestor levels to score
 		GenerateAriaTree: false, // By default, don't generate ARIA tree
 	}
 }
+
+// now returns the reference time for relative dates: Now, or the current time if unset.
+func (o ReadabilityOptions) now() time.Time {
+	if o.Now.IsZero() {
+		return time.Now()
+	}
+	return o.Now
+}
+
+// maxDepth returns the maximum nesting depth, with the default for an unset value.
+func (o ReadabilityOptions) maxDepth() int {
+	if o.MaxDepth <= 0 {
+		return util.DefaultMaxDepth
+	}
+	return o.MaxDepth
+}
+
+// titleBounds returns the title length bounds, with defaults for unset values.
+func (o ReadabilityOptions) titleBounds() (minLength, maxLength int) {
+	minLength, maxLength = o.TitleMinLength, o.TitleMaxLength
+	if minLength <= 0 {
+		minLength = util.DefaultTitleMinLength
+	}
+	if maxLength <= 0 {
+		maxLength = util.DefaultTitleMaxLength
+	}
+	return minLength, maxLength
+}
@@ -19,6 +19,18 @@ func TestDefaultOptions(t *testing.T) {
 		t.Errorf("Expected NbTopCandidates to be %d, got %d", 5, opts.NbTopCandidates)
 	}
 
+	if opts.AncestorDepth != 3 {
+		t.Errorf("Expected AncestorDepth to be %d, got %d", 3, opts.AncestorDepth)
+	}
+
+	if opts.TitleMaxLength != 150 || opts.TitleMinLength != 15 {
+		t.Errorf("Expected title bounds to be 15-150, got %d-%d", opts.TitleMinLength, opts.TitleMaxLength)
+	}
+
+	if opts.MaxDepth != 1000 {
+		t.Errorf("Expected MaxDepth to be %d, got %d", 1000, opts.MaxDepth)
+	}
+
 	if opts.GenerateAriaTree != false {
 		t.Errorf("Expected GenerateAriaTree to be %v, got %v", false, opts.GenerateAriaTree)
 	}
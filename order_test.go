@@ -0,0 +1,98 @@
+package readability
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mackee/go-readability/internal/dom"
+	"github.com/mackee/go-readability/internal/parser"
+)
+
+// contentSequence lists the text blocks, images, figures, and headings under root in
+// document order, each identified by its text or image source.
+func contentSequence(root *dom.VElement) []string {
+	var sequence []string
+	for _, element := range GetElementsByTagName(root, "*") {
+		switch element.TagName {
+		case "p", "figcaption", "h1", "h2", "h3", "h4", "h5", "h6":
+			sequence = append(sequence, element.TagName+": "+GetInnerText(element, true))
+		case "img":
+			sequence = append(sequence, "img: "+element.GetAttribute("src"))
+		case "figure":
+			sequence = append(sequence, "figure")
+		}
+	}
+	return sequence
+}
+
+func TestExtractPreservesSourceOrder(t *testing.T) {
+	source, err := os.ReadFile("testdata/mixed-media/source.html")
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	doc, err := parser.ParseHTML(string(source), "")
+	if err != nil {
+		t.Fatalf("ParseHTML failed: %v", err)
+	}
+	articles := GetElementsByTagName(doc.Body, "article")
+	if len(articles) != 1 {
+		t.Fatalf("Expected one <article> in the fixture, got %d", len(articles))
+	}
+	expected := contentSequence(articles[0])
+
+	tests := []struct {
+		name      string
+		configure func(*ReadabilityOptions)
+	}{
+		{name: "default", configure: func(*ReadabilityOptions) {}},
+		{name: "cleanup passes", configure: func(options *ReadabilityOptions) {
+			options.MergeInlineElements = true
+			options.RemoveNavLike = true
+			options.RemoveEmptyLinks = true
+			options.TrimBoilerplate = true
+			options.DedupeBylineInContent = true
+		}},
+		{name: "include only selectors", configure: func(options *ReadabilityOptions) {
+			options.IncludeOnlySelectors = []string{"img, figure", "h1, h2, p"}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultOptions()
+			options.CharThreshold = 100
+			tt.configure(&options)
+
+			article, err := Extract(string(source), options)
+			if err != nil {
+				t.Fatalf("Extract failed: %v", err)
+			}
+			if article.Root == nil {
+				t.Fatal("Expected content to be extracted")
+			}
+			if got := contentSequence(article.Root); !slices.Equal(got, expected) {
+				t.Errorf("Content order differs from the source:\ngot:  %q\nwant: %q", got, expected)
+			}
+
+			// The Markdown keeps the same order, with each block on its own lines
+			markdown := ToMarkdown(article.Root)
+			last := -1
+			for _, marker := range []string{
+				"# Field notes", "Paragraph one.", "![Image one]", "Paragraph two.", "![Image two]",
+				"Figure two caption", "## Heading three", "Paragraph three.", "Paragraph four.",
+				"![Image five]", "Paragraph five.",
+			} {
+				index := strings.Index(markdown, marker)
+				if index <= last {
+					t.Fatalf("Expected %q after the previous block in Markdown:\n%s", marker, markdown)
+				}
+				if index > 0 && markdown[index-1] != '\n' {
+					t.Errorf("Expected %q to start a line in Markdown:\n%s", marker, markdown)
+				}
+				last = index
+			}
+		})
+	}
+}
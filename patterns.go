@@ -0,0 +1,175 @@
+// Package readability provides functionality to extract readable content from HTML documents.
+// It implements an algorithm similar to Mozilla's Readability.js to identify and extract
+// the main content from web pages, removing clutter, navigation, ads, and other non-content elements.
+package readability
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mackee/go-readability/internal/dom"
+	"github.com/mackee/go-readability/internal/util"
+)
+
+// classPatterns holds the class/ID patterns used to judge candidates: the defaults
+// from util.Regexps merged with the extra patterns from ReadabilityOptions.
+type classPatterns struct {
+	unlikely      *regexp.Regexp
+	likely        *regexp.Regexp
+	positive      *regexp.Regexp
+	negative      *regexp.Regexp
+	extraUnlikely *regexp.Regexp     // Only the extra unlikely patterns, nil if there are none
+	tagScores     map[string]float64 // Base scores by tag name (see defaultTagScores)
+}
+
+// defaultTagScores are the base scores of candidates by tag name, before the class/ID
+// adjustment. Tags not listed start at zero.
+var defaultTagScores = map[string]float64{
+	"div": 5,
+	"pre": 3, "td": 3, "blockquote": 3,
+	"address": -3, "ol": -3, "ul": -3, "dl": -3, "dd": -3, "dt": -3, "li": -3, "form": -3,
+	"h1": -5, "h2": -5, "h3": -5, "h4": -5, "h5": -5, "h6": -5, "th": -5,
+}
+
+// defaultClassPatterns are the patterns used when no extra patterns are configured.
+var defaultClassPatterns = classPatterns{
+	unlikely:  util.Regexps.UnlikelyCandidates,
+	likely:    util.Regexps.OkMaybeItsACandidate,
+	positive:  util.Regexps.Positive,
+	negative:  util.Regexps.Negative,
+	tagScores: defaultTagScores,
+}
+
+// newClassPatterns merges the extra patterns in options into the default patterns,
+// and options.TagScores into the default tag scores.
+//
+// Parameters:
+//   - options: The options holding the extra patterns
+//
+// Returns:
+//   - The merged patterns
+//   - An error wrapping ErrInvalidOptions if an extra pattern is not a valid regular expression,
+//     or a TagScores key is not a lowercase tag name
+func newClassPatterns(options ReadabilityOptions) (classPatterns, error) {
+	patterns := defaultClassPatterns
+	var err error
+	if patterns.unlikely, err = mergePatterns(patterns.unlikely, options.ExtraUnlikelyPatterns); err != nil {
+		return defaultClassPatterns, err
+	}
+	if patterns.likely, err = mergePatterns(patterns.likely, options.ExtraLikelyPatterns); err != nil {
+		return defaultClassPatterns, err
+	}
+	if patterns.positive, err = mergePatterns(patterns.positive, options.ExtraPositivePatterns); err != nil {
+		return defaultClassPatterns, err
+	}
+	if patterns.negative, err = mergePatterns(patterns.negative, options.ExtraNegativePatterns); err != nil {
+		return defaultClassPatterns, err
+	}
+	if len(options.ExtraUnlikelyPatterns) > 0 {
+		if patterns.extraUnlikely, err = mergePatterns(nil, options.ExtraUnlikelyPatterns); err != nil {
+			return defaultClassPatterns, err
+		}
+	}
+	if len(options.TagScores) > 0 {
+		patterns.tagScores = make(map[string]float64, len(defaultTagScores)+len(options.TagScores))
+		for tag, score := range defaultTagScores {
+			patterns.tagScores[tag] = score
+		}
+		for tag, score := range options.TagScores {
+			if !isValidTagName(tag) {
+				return defaultClassPatterns, fmt.Errorf("%w: TagScores key %q is not a lowercase tag name", ErrInvalidOptions, tag)
+			}
+			patterns.tagScores[tag] = score
+		}
+	}
+	return patterns, nil
+}
+
+// mergePatterns combines a base pattern with extra patterns into a single alternation.
+//
+// Parameters:
+//   - base: The base pattern, or nil to combine only the extra patterns
+//   - extras: The extra patterns to append
+//
+// Returns:
+//   - The combined pattern, or base itself if there are no extra patterns
+//   - An error wrapping ErrInvalidOptions if an extra pattern does not compile
+func mergePatterns(base *regexp.Regexp, extras []string) (*regexp.Regexp, error) {
+	if len(extras) == 0 {
+		return base, nil
+	}
+
+	var alternatives []string
+	if base != nil {
+		alternatives = append(alternatives, base.String())
+	}
+	for _, extra := range extras {
+		if _, err := regexp.Compile(extra); err != nil {
+			return nil, fmt.Errorf("%w: invalid pattern %q: %w", ErrInvalidOptions, extra, err)
+		}
+		alternatives = append(alternatives, extra)
+	}
+	return regexp.MustCompile("(?:" + strings.Join(alternatives, ")|(?:") + ")"), nil
+}
+
+// classWeight calculates the class/ID score adjustment of an element (see GetClassWeight)
+// with the given patterns.
+//
+// Parameters:
+//   - node: The element to calculate a class weight for
+//
+// Returns:
+//   - A float64 score adjustment (positive for likely content, negative for likely noise)
+func (p classPatterns) classWeight(node *dom.VElement) float64 {
+	var weight float64 = 0
+	for _, value := range []string{node.ClassName(), node.ID()} {
+		if value == "" {
+			continue
+		}
+		if p.negative.MatchString(value) {
+			weight -= 25
+		}
+		if p.positive.MatchString(value) {
+			weight += 25
+		}
+	}
+	return weight
+}
+
+// isUnlikely checks whether an element's class or ID marks it as an unlikely candidate.
+//
+// Parameters:
+//   - element: The element to check
+//
+// Returns:
+//   - true if the class or ID matches an unlikely pattern and no likely pattern
+func (p classPatterns) isUnlikely(element *dom.VElement) bool {
+	matchString := element.ClassName() + " " + element.ID()
+	return p.unlikely.MatchString(matchString) && !p.likely.MatchString(matchString)
+}
+
+// inExtraUnlikely checks whether an element or one of its ancestors matches an extra
+// unlikely pattern (and no likely pattern). Content in such elements is not scored.
+// The default unlikely patterns are not checked here, to keep the default scoring unchanged.
+//
+// Parameters:
+//   - element: The element to check
+//
+// Returns:
+//   - true if the element is inside an element demoted by the extra unlikely patterns
+func (p classPatterns) inExtraUnlikely(element *dom.VElement) bool {
+	if p.extraUnlikely == nil {
+		return false
+	}
+	for node := element; node != nil; node = node.Parent() {
+		if node.TagName == "body" {
+			return false
+		}
+		matchString := node.ClassName() + " " + node.ID()
+		if p.extraUnlikely.MatchString(matchString) && !p.likely.MatchString(matchString) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+// Package readability provides functionality to extract readable content from HTML documents.
+// It implements an algorithm similar to Mozilla's Readability.js to identify and extract
+// the main content from web pages, removing clutter, navigation, ads, and other non-content elements.
+package readability
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/mackee/go-readability/internal/dom"
+)
+
+// Regular expressions for paywall detection
+var (
+	// Class and ID names of paywall overlays and subscriber-only sections
+	paywallClassRegex = regexp.MustCompile(`(?i)paywall|pay-wall|regwall|subscriber-only|subscribers-only|subscription-wall|subscribe-wall|premium-content|metered-content|piano-(?:offer|paywall)`)
+	// Link texts inviting the reader to continue behind the wall
+	continueReadingRegex = regexp.MustCompile(`(?i)^(?:continue reading|keep reading|read the full (?:article|story)|subscribe to (?:continue|read|keep reading)|sign in to (?:continue|read)|続きを読む|続きは有料会員)`)
+)
+
+// isPaywalledDocument checks whether a document carries paywall markers: elements with
+// paywall class or ID names, or JSON-LD declaring the page not accessible for free.
+// This must run before preprocessing, which may remove the paywall overlay.
+//
+// Parameters:
+//   - doc: The parsed HTML document
+//   - structuredData: The JSON-LD objects of the document (see GetStructuredData)
+//
+// Returns:
+//   - true if the page declares or shows a paywall
+func isPaywalledDocument(doc *dom.VDocument, structuredData []map[string]interface{}) bool {
+	for _, object := range structuredData {
+		if isNotAccessibleForFree(object) {
+			return true
+		}
+	}
+	if !hasBody(doc) {
+		return false
+	}
+	for _, element := range GetElementsByTagName(doc.Body, "*") {
+		if paywallClassRegex.MatchString(element.ClassName() + " " + element.ID()) {
+			return true
+		}
+	}
+	return false
+}
+
+// isNotAccessibleForFree checks a JSON-LD object, and the parts listed in its hasPart,
+// for isAccessibleForFree set to false (as a boolean or a string).
+//
+// Parameters:
+//   - object: The JSON-LD object to check
+//
+// Returns:
+//   - true if the object or one of its parts is not accessible for free
+func isNotAccessibleForFree(object map[string]interface{}) bool {
+	switch free := object["isAccessibleForFree"].(type) {
+	case bool:
+		if !free {
+			return true
+		}
+	case string:
+		if strings.EqualFold(strings.TrimSpace(free), "false") {
+			return true
+		}
+	}
+
+	var parts []interface{}
+	switch hasPart := object["hasPart"].(type) {
+	case []interface{}:
+		parts = hasPart
+	case map[string]interface{}:
+		parts = []interface{}{hasPart}
+	}
+	for _, part := range parts {
+		if partObject, ok := part.(map[string]interface{}); ok && isNotAccessibleForFree(partObject) {
+			return true
+		}
+	}
+	return false
+}
+
+// endsWithContinueReading checks whether extracted content stops abruptly at a
+// "continue reading" or "subscribe to continue" link, the typical end of a teaser.
+//
+// Parameters:
+//   - root: The extracted content root
+//
+// Returns:
+//   - true if the last link in the content invites the reader to continue elsewhere
+func endsWithContinueReading(root *dom.VElement) bool {
+	if root == nil {
+		return false
+	}
+
+	// Only links in the last block of the content count
+	last, ok := dom.AsVElement(lastMeaningfulChild(root))
+	for ok && hasBlockChild(last) {
+		last, ok = dom.AsVElement(lastMeaningfulChild(last))
+	}
+	if !ok {
+		return false
+	}
+	candidates := append([]*dom.VElement{last}, GetElementsByTagName(last, "a")...)
+	for _, element := range candidates {
+		if element.TagName != "a" && element.TagName != "button" {
+			continue
+		}
+		if continueReadingRegex.MatchString(strings.TrimSpace(GetInnerText(element, true))) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package readability
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestExtractPaywalled(t *testing.T) {
+	source, err := os.ReadFile("testdata/paywall/source.html")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+
+	article, err := Extract(string(source), DefaultOptions())
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if !article.Paywalled {
+		t.Error("Expected the paywall fixture to be flagged as paywalled")
+	}
+	if article.Root == nil || !strings.Contains(ToHTML(article.Root), "The city council voted") {
+		t.Error("Expected the teaser to be extracted as usual")
+	}
+}
+
+func TestPaywallSignals(t *testing.T) {
+	paragraph := "<p>" + strings.Repeat("This is a long paragraph of article text that goes on and on. ", 10) + "</p>"
+
+	testCases := []struct {
+		name     string
+		html     string
+		expected bool
+	}{
+		{
+			name:     "free article",
+			html:     "<html><body><article>" + paragraph + paragraph + "</article></body></html>",
+			expected: false,
+		},
+		{
+			name:     "paywall class",
+			html:     "<html><body><article>" + paragraph + `<div class="article-paywall"><p>Subscribe now</p></div></article></body></html>`,
+			expected: true,
+		},
+		{
+			name:     "subscriber-only id",
+			html:     "<html><body><article>" + paragraph + `<section id="subscriber-only-content"></section></article></body></html>`,
+			expected: true,
+		},
+		{
+			name: "JSON-LD not accessible for free",
+			html: `<html><head><script type="application/ld+json">{"@type":"NewsArticle","isAccessibleForFree":false}</script></head>` +
+				"<body><article>" + paragraph + "</article></body></html>",
+			expected: true,
+		},
+		{
+			name: "JSON-LD accessible for free",
+			html: `<html><head><script type="application/ld+json">{"@type":"NewsArticle","isAccessibleForFree":true}</script></head>` +
+				"<body><article>" + paragraph + "</article></body></html>",
+			expected: false,
+		},
+		{
+			name:     "ends with continue reading link",
+			html:     "<html><body><article>" + paragraph + `<p><a href="/subscribe">Continue reading</a></p></article></body></html>`,
+			expected: true,
+		},
+		{
+			name:     "continue reading link before more content",
+			html:     "<html><body><article>" + paragraph + `<p><a href="/part2">Continue reading</a></p>` + paragraph + "</article></body></html>",
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			article, err := Extract(tc.html, DefaultOptions())
+			if err != nil {
+				t.Fatalf("Extract failed: %v", err)
+			}
+			if article.Paywalled != tc.expected {
+				t.Errorf("Expected Paywalled=%v, got %v", tc.expected, article.Paywalled)
+			}
+		})
+	}
+}
@@ -0,0 +1,891 @@
+// Package readability provides functionality to extract readable content from HTML documents.
+// It implements an algorithm similar to Mozilla's Readability.js to identify and extract
+// the main content from web pages, removing clutter, navigation, ads, and other non-content elements.
+package readability
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mackee/go-readability/internal/dom"
+	"github.com/mackee/go-readability/internal/util"
+)
+
+// ellipsis is appended to content that has been truncated.
+const ellipsis = "…"
+
+// collectTextNodes returns all text nodes under an element in document order.
+//
+// Parameters:
+//   - element: The element to collect text nodes from
+//
+// Returns:
+//   - A slice of text nodes in document order
+func collectTextNodes(element *dom.VElement) []*dom.VText {
+	var texts []*dom.VText
+	for _, child := range element.Children {
+		if text, ok := dom.AsVText(child); ok {
+			texts = append(texts, text)
+		} else if elem, ok := dom.AsVElement(child); ok {
+			texts = append(texts, collectTextNodes(elem)...)
+		}
+	}
+	return texts
+}
+
+// removeFollowingNodes removes every node that comes after node in document order,
+// up to and including the descendants of root.
+//
+// Parameters:
+//   - root: The element that bounds the removal
+//   - node: The last node to keep
+func removeFollowingNodes(root *dom.VElement, node dom.VNode) {
+	current := node
+	for current != nil && dom.VNode(root) != current {
+		parent := current.Parent()
+		if parent == nil {
+			return
+		}
+		for i, child := range parent.Children {
+			if child == current {
+				for _, removed := range parent.Children[i+1:] {
+					removed.SetParent(nil)
+				}
+				parent.Children = parent.Children[:i+1]
+				break
+			}
+		}
+		current = parent
+	}
+}
+
+// trimAtStopElement removes the first element below root matching a selector list, and
+// every node after it in document order (see ReadabilityOptions.StopSelector).
+//
+// Parameters:
+//   - root: The content element, modified in place
+//   - stop: The selectors of the end-of-content marker
+//
+// Returns:
+//   - true if a marker was found and the content was trimmed
+func trimAtStopElement(root *dom.VElement, stop selectorList) bool {
+	for _, element := range GetElementsByTagName(root, "*") {
+		if element == root || !stop.matches(element) {
+			continue
+		}
+		removeFollowingNodes(root, element)
+		element.Parent().RemoveChild(element)
+		return true
+	}
+	return false
+}
+
+// closestAncestorTag returns the closest ancestor of node with the given tag name,
+// searching no higher than root. Returns nil if none is found.
+//
+// Parameters:
+//   - root: The element that bounds the search
+//   - node: The node to start from
+//   - tagName: The lowercase tag name to look for
+//
+// Returns:
+//   - The matching ancestor element, or nil
+func closestAncestorTag(root *dom.VElement, node dom.VNode, tagName string) *dom.VElement {
+	for parent := node.Parent(); parent != nil && parent != root; parent = parent.Parent() {
+		if parent.TagName == tagName {
+			return parent
+		}
+	}
+	return nil
+}
+
+// cutAtBoundary returns the longest prefix of text that is at most maxRunes runes long
+// and ends at a word or sentence boundary. Text without any boundary (e.g. CJK) is cut
+// at a rune boundary instead.
+//
+// Parameters:
+//   - text: The whitespace-normalized text to cut
+//   - maxRunes: The maximum number of runes to keep
+//
+// Returns:
+//   - The cut text without trailing whitespace
+func cutAtBoundary(text string, maxRunes int) string {
+	if maxRunes <= 0 {
+		return ""
+	}
+	runes := []rune(text)
+	if len(runes) <= maxRunes {
+		return text
+	}
+
+	// A boundary right after the limit means the whole prefix is made of complete words
+	if unicode.IsSpace(runes[maxRunes]) {
+		return strings.TrimRightFunc(string(runes[:maxRunes]), unicode.IsSpace)
+	}
+
+	prefix := runes[:maxRunes]
+	for i := len(prefix) - 1; i > 0; i-- {
+		if unicode.IsSpace(prefix[i]) {
+			return strings.TrimRightFunc(string(prefix[:i]), unicode.IsSpace)
+		}
+		if strings.ContainsRune("。、．，！？", prefix[i]) {
+			return string(prefix[:i+1])
+		}
+	}
+	return string(prefix)
+}
+
+// truncateContent trims an element in place so that its text fits within maxChars
+// characters (runes), including a trailing ellipsis. The cut happens at a word or
+// sentence boundary inside a text node, so markup such as links is never split:
+// a link that would be cut is dropped as a whole. Everything after the cut is removed.
+//
+// Parameters:
+//   - root: The element to truncate
+//   - maxChars: The maximum number of characters to keep
+//
+// Returns:
+//   - true if the element was truncated, false if it already fit
+func truncateContent(root *dom.VElement, maxChars int) bool {
+	if root == nil || maxChars <= 0 {
+		return false
+	}
+
+	texts := collectTextNodes(root)
+	normalized := make([]string, len(texts))
+	total := 0
+	for i, text := range texts {
+		normalized[i] = strings.Join(strings.Fields(text.TextContent), " ")
+		if normalized[i] == "" {
+			continue
+		}
+		if total > 0 {
+			total++ // Space separating this text from the previous one
+		}
+		total += utf8.RuneCountInString(normalized[i])
+	}
+	if total <= maxChars {
+		return false
+	}
+
+	// Reserve room for the ellipsis and the space renderers may put before it
+	limit := maxChars - utf8.RuneCountInString(ellipsis) - 1
+	used := 0
+	for i, text := range texts {
+		if normalized[i] == "" {
+			continue
+		}
+		separator := 0
+		if used > 0 {
+			separator = 1
+		}
+		length := separator + utf8.RuneCountInString(normalized[i])
+		if used+length <= limit {
+			used += length
+			continue
+		}
+
+		var cutNode dom.VNode = text
+		if link := closestAncestorTag(root, text, "a"); link != nil {
+			// Never render a partial link: drop it and put the ellipsis in its place
+			marker := dom.NewVText(ellipsis)
+			parent := link.Parent()
+			for j, child := range parent.Children {
+				if child == dom.VNode(link) {
+					parent.Children[j] = marker
+					marker.SetParent(parent)
+					link.SetParent(nil)
+					break
+				}
+			}
+			cutNode = marker
+		} else {
+			kept := cutAtBoundary(normalized[i], limit-used-separator)
+			if kept != "" && separator > 0 && unicode.IsSpace([]rune(text.TextContent)[0]) {
+				kept = " " + kept
+			}
+			text.TextContent = kept + ellipsis
+		}
+
+		removeFollowingNodes(root, cutNode)
+		return true
+	}
+
+	return false
+}
+
+// fitRenderedContent truncates an element in place so that it fits within maxChars
+// characters (runes) once rendered in the given format. The text is cut first; as long as
+// the markup still makes the rendered output too long, the text is cut further by the
+// excess. When even that does not fit, such as a limit shorter than the "## " of a heading,
+// the content is replaced with the ellipsis alone, and dropped entirely if the markup of
+// root itself is longer than the limit.
+//
+// Parameters:
+//   - root: The element to truncate
+//   - maxChars: The maximum length of the rendered output
+//   - format: The format the output is rendered in
+//
+// Returns:
+//   - true if the element was truncated, false if it already fit
+func fitRenderedContent(root *dom.VElement, maxChars int, format OutputFormat) bool {
+	truncated := truncateContent(root, maxChars)
+	budget := maxChars
+	for budget > 0 {
+		excess := renderedContentLength(root, format) - maxChars
+		if excess <= 0 {
+			break
+		}
+		budget -= excess
+		truncated = truncateContent(root, budget) || truncated
+	}
+
+	if renderedContentLength(root, format) > maxChars {
+		replaceChildren(root, dom.NewVText(ellipsis))
+		truncated = true
+	}
+	if renderedContentLength(root, format) > maxChars {
+		replaceChildren(root)
+	}
+	return truncated
+}
+
+// replaceChildren replaces the children of an element with the given nodes.
+func replaceChildren(element *dom.VElement, children ...dom.VNode) {
+	for _, child := range element.Children {
+		child.SetParent(nil)
+	}
+	element.Children = children
+	for _, child := range children {
+		child.SetParent(element)
+	}
+}
+
+// renderedContentLength returns the length in runes of an element rendered in the given
+// format.
+func renderedContentLength(root *dom.VElement, format OutputFormat) int {
+	switch format {
+	case OutputFormatHTML:
+		return utf8.RuneCountInString(ToHTML(root))
+	case OutputFormatMarkdown:
+		return utf8.RuneCountInString(ToMarkdown(root))
+	}
+	return utf8.RuneCountInString(strings.Join(strings.Fields(ExtractTextContent(root)), " "))
+}
+
+// paragraphTags are the block elements counted as one paragraph by limitParagraphs.
+// A list, quote, or table counts as a single paragraph.
+var paragraphTags = map[string]bool{
+	"p":          true,
+	"pre":        true,
+	"blockquote": true,
+	"ul":         true,
+	"ol":         true,
+	"dl":         true,
+	"table":      true,
+}
+
+// findParagraph returns the n-th (1-based) paragraph under an element in document order,
+// not counting paragraphs nested in another paragraph or paragraphs without text.
+//
+// Parameters:
+//   - element: The element to search
+//   - n: The position of the paragraph to find; decremented for every paragraph found
+//
+// Returns:
+//   - The paragraph element, or nil if the element has fewer paragraphs
+func findParagraph(element *dom.VElement, n *int) *dom.VElement {
+	for _, child := range element.Children {
+		elem, ok := dom.AsVElement(child)
+		if !ok {
+			continue
+		}
+		if paragraphTags[elem.TagName] {
+			if GetInnerText(elem, false) == "" {
+				continue
+			}
+			*n--
+			if *n == 0 {
+				return elem
+			}
+			continue
+		}
+		if found := findParagraph(elem, n); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// limitParagraphs trims an element in place to its first maxParagraphs paragraphs
+// (see paragraphTags). Headings and images before the last kept paragraph are kept;
+// everything after it is removed.
+//
+// Parameters:
+//   - root: The element to trim
+//   - maxParagraphs: The number of paragraphs to keep
+//
+// Returns:
+//   - true if any text was removed
+func limitParagraphs(root *dom.VElement, maxParagraphs int) bool {
+	if root == nil || maxParagraphs <= 0 {
+		return false
+	}
+
+	n := maxParagraphs
+	last := findParagraph(root, &n)
+	if last == nil {
+		return false
+	}
+	before := GetInnerText(root, true)
+	removeFollowingNodes(root, last)
+	return GetInnerText(root, true) != before
+}
+
+// maxBylineLength is the maximum text length of an element considered a byline.
+const maxBylineLength = 100
+
+// isBylineElement checks whether an element looks like an author or date line.
+// It matches rel="author", itemprop="author", and byline-like class or ID names,
+// and rejects elements that are too long or contain navigation structures.
+//
+// Parameters:
+//   - element: The element to check
+//
+// Returns:
+//   - true if the element is likely a byline
+func isBylineElement(element *dom.VElement) bool {
+	matchString := element.ClassName() + " " + element.ID()
+	if element.GetAttribute("rel") != "author" &&
+		!strings.Contains(element.GetAttribute("itemprop"), "author") &&
+		!util.Regexps.Byline.MatchString(matchString) {
+		return false
+	}
+
+	text := strings.TrimSpace(GetInnerText(element, true))
+	if text == "" || utf8.RuneCountInString(text) > maxBylineLength {
+		return false
+	}
+
+	// Bylines never contain lists or navigation
+	return len(GetElementsByTagNames(element, []string{"nav", "ul", "ol", "table"})) == 0
+}
+
+// FindBylineElement looks for an author or date line just above the content root.
+// It checks the nearest preceding siblings of the root and of up to two of its
+// ancestors, stopping at the body, so unrelated parts of the page are not picked up.
+// A match inside a line, such as an author link, is expanded to the line holding it.
+//
+// Parameters:
+//   - root: The main content element
+//
+// Returns:
+//   - The byline element, or nil if none is found
+func FindBylineElement(root *dom.VElement) *dom.VElement {
+	if root == nil {
+		return nil
+	}
+
+	var node dom.VNode = root
+	for depth := 0; depth < 3; depth++ {
+		parent := node.Parent()
+		if parent == nil {
+			return nil
+		}
+
+		index := -1
+		for i, child := range parent.Children {
+			if child == node {
+				index = i
+				break
+			}
+		}
+
+		// Look at the three nearest preceding element siblings
+		checked := 0
+		for i := index - 1; i >= 0 && checked < 3; i-- {
+			sibling, ok := dom.AsVElement(parent.Children[i])
+			if !ok {
+				continue
+			}
+			checked++
+			if sibling.TagName == "nav" || sibling.TagName == "aside" || sibling.TagName == "footer" {
+				continue
+			}
+			for _, candidate := range GetElementsByTagName(sibling, "*") {
+				if isBylineElement(candidate) {
+					return bylineBlock(candidate, sibling)
+				}
+			}
+		}
+
+		if parent.TagName == "body" {
+			return nil
+		}
+		node = parent
+	}
+
+	return nil
+}
+
+// bylineBlock expands a byline match such as an author link to the element holding the
+// whole line, so "By <a rel="author">Jane</a> Doe" is kept as one: it climbs inline
+// ancestors up to the nearest block, stopping before an ancestor that also holds a
+// heading, navigation, or too much text.
+//
+// Parameters:
+//   - element: The element matched as a byline
+//   - limit: The outermost ancestor to consider
+//
+// Returns:
+//   - The element holding the byline text
+func bylineBlock(element, limit *dom.VElement) *dom.VElement {
+	block := element
+	for !blockElements[block.TagName] && block != limit {
+		parent := block.Parent()
+		if parent == nil || parent.TagName == "body" {
+			break
+		}
+		text := strings.TrimSpace(GetInnerText(parent, true))
+		if utf8.RuneCountInString(text) > maxBylineLength ||
+			len(GetElementsByTagNames(parent, []string{"h1", "h2", "h3", "h4", "h5", "h6", "nav", "ul", "ol", "table"})) > 0 {
+			break
+		}
+		block = parent
+	}
+	return block
+}
+
+// maxBoilerplateLength is the maximum text length of a trailing element considered boilerplate.
+const maxBoilerplateLength = 200
+
+// isBoilerplateElement checks whether an element looks like trailing legal boilerplate:
+// a short copyright or legal notice, or a short line made up only of links.
+//
+// Parameters:
+//   - element: The element to check
+//
+// Returns:
+//   - true if the element is likely boilerplate
+func isBoilerplateElement(element *dom.VElement) bool {
+	text := strings.TrimSpace(GetInnerText(element, true))
+	if text == "" || utf8.RuneCountInString(text) > maxBoilerplateLength {
+		return false
+	}
+	if util.Regexps.Boilerplate.MatchString(text) {
+		return true
+	}
+
+	// Short lines of nothing but links ("Home | About | Contact")
+	return utf8.RuneCountInString(text) <= maxBylineLength &&
+		len(GetElementsByTagName(element, "a")) > 0 && !hasTextOutsideLinks(element)
+}
+
+// hasTextOutsideLinks checks whether an element has letters or digits that are not
+// inside a link. Separators such as "|" and "·" between links do not count.
+//
+// Parameters:
+//   - element: The element to check
+//
+// Returns:
+//   - true if any text outside links contains a letter or digit
+func hasTextOutsideLinks(element *dom.VElement) bool {
+	for _, child := range element.Children {
+		if text, ok := dom.AsVText(child); ok {
+			if strings.IndexFunc(text.TextContent, func(r rune) bool {
+				return unicode.IsLetter(r) || unicode.IsDigit(r)
+			}) >= 0 {
+				return true
+			}
+		} else if elem, ok := dom.AsVElement(child); ok && elem.TagName != "a" && hasTextOutsideLinks(elem) {
+			return true
+		}
+	}
+	return false
+}
+
+// lastMeaningfulChild returns the last child of an element, skipping whitespace-only text.
+//
+// Parameters:
+//   - element: The element to look in
+//
+// Returns:
+//   - The last meaningful child node, or nil if there is none
+func lastMeaningfulChild(element *dom.VElement) dom.VNode {
+	for i := len(element.Children) - 1; i >= 0; i-- {
+		child := element.Children[i]
+		if text, ok := dom.AsVText(child); ok && strings.TrimSpace(text.TextContent) == "" {
+			continue
+		}
+		return child
+	}
+	return nil
+}
+
+// hasBlockChild checks whether an element directly contains a block-level element.
+//
+// Parameters:
+//   - element: The element to check
+//
+// Returns:
+//   - true if any child element is block-level
+func hasBlockChild(element *dom.VElement) bool {
+	for _, child := range element.Children {
+		if elem, ok := dom.AsVElement(child); ok && blockElements[elem.TagName] {
+			return true
+		}
+	}
+	return false
+}
+
+// trimBoilerplate removes copyright notices, legal lines, and short link-only lines
+// from the end of an element. It only works backwards from the last child, descending
+// into trailing containers, and stops at the first substantial node.
+//
+// Parameters:
+//   - root: The element to trim
+//
+// Returns:
+//   - The number of elements removed
+func trimBoilerplate(root *dom.VElement) int {
+	if root == nil {
+		return 0
+	}
+
+	removed := 0
+	for {
+		last, ok := dom.AsVElement(lastMeaningfulChild(root))
+		if !ok {
+			// Nothing left, or trailing text that belongs to the content
+			return removed
+		}
+
+		if isBoilerplateElement(last) {
+			root.RemoveChild(last)
+			removed++
+			continue
+		}
+		if !hasBlockChild(last) {
+			return removed
+		}
+
+		// Trim inside a trailing container, and drop the container once it is empty
+		removed += trimBoilerplate(last)
+		if lastMeaningfulChild(last) != nil {
+			return removed
+		}
+		root.RemoveChild(last)
+	}
+}
+
+// mergeableInlineTags are the inline elements that mergeInlineElements joins.
+var mergeableInlineTags = map[string]bool{
+	"b":      true,
+	"strong": true,
+	"em":     true,
+	"i":      true,
+	"code":   true,
+}
+
+// mergeInlineElements joins adjacent sibling elements with the same inline tag and
+// attributes into one, so that fragmented markup such as <b>Hel</b><b>lo</b> becomes
+// <b>Hello</b>. Elements separated by text, even whitespace, are left alone.
+//
+// Parameters:
+//   - element: The element to normalize, in place
+//
+// Returns:
+//   - The number of elements merged into their previous sibling
+func mergeInlineElements(element *dom.VElement) int {
+	merged := 0
+	children := make([]dom.VNode, 0, len(element.Children))
+	for _, child := range element.Children {
+		current, ok := dom.AsVElement(child)
+		if ok && mergeableInlineTags[current.TagName] && len(children) > 0 {
+			previous, ok := dom.AsVElement(children[len(children)-1])
+			if ok && previous.TagName == current.TagName && maps.Equal(previous.Attributes, current.Attributes) {
+				for _, grandchild := range current.Children {
+					// Join text split across the two elements back into a single text node
+					if text, ok := dom.AsVText(grandchild); ok && len(previous.Children) > 0 {
+						if last, ok := dom.AsVText(previous.Children[len(previous.Children)-1]); ok {
+							last.TextContent += text.TextContent
+							continue
+						}
+					}
+					previous.AppendChild(grandchild)
+				}
+				current.Children = nil
+				current.SetParent(nil)
+				merged++
+				continue
+			}
+		}
+		children = append(children, child)
+	}
+	element.Children = children
+
+	// Merging can make the children's own children adjacent, so recurse afterwards
+	for _, child := range element.Children {
+		if elem, ok := dom.AsVElement(child); ok {
+			merged += mergeInlineElements(elem)
+		}
+	}
+	return merged
+}
+
+// Thresholds for removeNavLike
+const (
+	// minNavLinks is the minimum number of links in a menu-like element
+	minNavLinks = 3
+	// maxNavTextPerLink is the maximum average text length per link, in characters
+	maxNavTextPerLink = 30
+	// minNavLinkDensity is the minimum share of the text inside links
+	minNavLinkDensity = 0.5
+)
+
+// isNavLike checks whether an element looks like a menu or tag cloud: many links with
+// little text each, and little prose around them.
+//
+// Parameters:
+//   - element: The element to check
+//
+// Returns:
+//   - true if the element is link-heavy and text-poor
+func isNavLike(element *dom.VElement) bool {
+	links := len(GetElementsByTagName(element, "a"))
+	if links < minNavLinks {
+		return false
+	}
+	textLength := utf8.RuneCountInString(GetInnerText(element, true))
+	return textLength/links <= maxNavTextPerLink && GetLinkDensity(element) >= minNavLinkDensity
+}
+
+// removeNavLike removes the descendants of an element that look like menus or tag clouds
+// (see isNavLike). The element itself is never removed, and the outermost matching
+// descendant is removed as a whole.
+//
+// Parameters:
+//   - root: The element to clean, in place
+//
+// Returns:
+//   - The number of elements removed
+func removeNavLike(root *dom.VElement) int {
+	if root == nil {
+		return 0
+	}
+
+	removed := 0
+	for _, child := range append([]dom.VNode(nil), root.Children...) {
+		element, ok := dom.AsVElement(child)
+		if !ok {
+			continue
+		}
+		if isNavLike(element) {
+			root.RemoveChild(element)
+			removed++
+			continue
+		}
+		removed += removeNavLike(element)
+	}
+	return removed
+}
+
+// linkMediaTags are the elements that give a link visible content without text.
+var linkMediaTags = []string{"img", "picture", "svg", "video"}
+
+// isEmptyLink checks whether a link is a placeholder: an href of "#" or "", or no text
+// and no image. Empty <a id> and <a name> elements without href are in-page link targets
+// and are not considered empty.
+//
+// Parameters:
+//   - link: The <a> element to check
+//
+// Returns:
+//   - true if the link should be unwrapped
+func isEmptyLink(link *dom.VElement) bool {
+	href, hasHref := link.Attributes["href"]
+	if hasHref {
+		if href = strings.TrimSpace(href); href == "" || href == "#" {
+			return true
+		}
+	}
+	if GetInnerText(link, true) != "" || len(dom.GetElementsByTagNames(link, linkMediaTags)) > 0 {
+		return false
+	}
+	return hasHref || (link.ID() == "" && link.GetAttribute("name") == "")
+}
+
+// removeEmptyLinks unwraps the placeholder links under an element (see isEmptyLink),
+// replacing each one with its children so that any text inside is kept.
+//
+// Parameters:
+//   - root: The element to clean, in place
+//
+// Returns:
+//   - The number of links unwrapped
+func removeEmptyLinks(root *dom.VElement) int {
+	if root == nil {
+		return 0
+	}
+
+	removed := 0
+	children := make([]dom.VNode, 0, len(root.Children))
+	for _, child := range root.Children {
+		element, ok := dom.AsVElement(child)
+		if !ok {
+			children = append(children, child)
+			continue
+		}
+		removed += removeEmptyLinks(element)
+		if element.TagName != "a" || !isEmptyLink(element) {
+			children = append(children, child)
+			continue
+		}
+		for _, grandchild := range element.Children {
+			grandchild.SetParent(root)
+			children = append(children, grandchild)
+		}
+		element.Children = nil
+		element.SetParent(nil)
+		removed++
+	}
+	root.Children = children
+	return removed
+}
+
+// headingTags are the heading elements cleaned by normalizeHeadings.
+var headingTags = []string{"h1", "h2", "h3", "h4", "h5", "h6"}
+
+// normalizeHeadings cleans the headings under an element: headings without text or
+// media (e.g. <h2></h2> or <h2><span> </span></h2>) are removed, and whitespace in the
+// text of the others is collapsed to single spaces and trimmed at both ends.
+//
+// Parameters:
+//   - root: The element to clean, in place
+//
+// Returns:
+//   - The number of headings removed
+func normalizeHeadings(root *dom.VElement) int {
+	if root == nil {
+		return 0
+	}
+
+	removed := 0
+	for _, heading := range dom.GetElementsByTagNames(root, headingTags) {
+		if heading == root {
+			continue
+		}
+		if GetInnerText(heading, true) == "" && len(dom.GetElementsByTagNames(heading, linkMediaTags)) == 0 {
+			if parent := heading.Parent(); parent != nil {
+				parent.RemoveChild(heading)
+				removed++
+			}
+			continue
+		}
+
+		textNodes := collectTextNodes(heading)
+		for i, textNode := range textNodes {
+			text := collapseWhitespace(textNode.TextContent)
+			if i == 0 {
+				text = strings.TrimLeft(text, " ")
+			}
+			if i == len(textNodes)-1 {
+				text = strings.TrimRight(text, " ")
+			}
+			textNode.TextContent = text
+		}
+	}
+	return removed
+}
+
+// collapseWhitespace replaces every run of whitespace in text, including line breaks,
+// with a single space. Whitespace at the ends is collapsed but kept, since it separates
+// the text from its neighbors.
+func collapseWhitespace(text string) string {
+	var b strings.Builder
+	inSpace := false
+	for _, r := range text {
+		if unicode.IsSpace(r) {
+			if !inSpace {
+				b.WriteByte(' ')
+			}
+			inSpace = true
+			continue
+		}
+		b.WriteRune(r)
+		inSpace = false
+	}
+	return b.String()
+}
+
+// bylinePrefixRegex matches the words introducing an author line, such as "By" or "Written by:".
+var bylinePrefixRegex = regexp.MustCompile(`(?i)^\s*(?:(?:written|posted|words)\s+)?by\s*:?\s*`)
+
+// bylineLineTags are the elements that can hold the byline line at the start of the content.
+var bylineLineTags = map[string]bool{
+	"p":       true,
+	"div":     true,
+	"address": true,
+	"header":  true,
+}
+
+// minBylineSimilarity is the minimum similarity, in both directions, between the extracted
+// byline and a line of the content for the line to count as a repeat of the byline.
+const minBylineSimilarity = 0.75
+
+// removeLeadingByline removes the author line from the start of the content when it repeats
+// the extracted byline, e.g. <p>By Jane Doe</p> for the byline "Jane Doe". Headings are
+// skipped, and only the first two lines of text are checked.
+//
+// Parameters:
+//   - root: The content element, modified in place
+//   - byline: The extracted byline
+//
+// Returns:
+//   - true if a line was removed
+func removeLeadingByline(root *dom.VElement, byline string) bool {
+	if root == nil || strings.TrimSpace(byline) == "" {
+		return false
+	}
+
+	checked := 0
+	var last *dom.VElement
+	for _, element := range GetElementsByTagName(root, "*") {
+		if checked >= 2 {
+			return false
+		}
+		if last != nil && isAncestorOrSelf(last, element) {
+			continue
+		}
+		if slices.Contains(headingTags, element.TagName) {
+			last = element
+			continue
+		}
+		if !bylineLineTags[element.TagName] || hasBlockChild(element) {
+			continue
+		}
+		text := GetInnerText(element, true)
+		if text == "" {
+			continue
+		}
+		last = element
+		checked++
+
+		if utf8.RuneCountInString(text) > maxBylineLength {
+			return false
+		}
+		text = bylinePrefixRegex.ReplaceAllString(text, "")
+		if TextSimilarity(byline, text) >= minBylineSimilarity && TextSimilarity(text, byline) >= minBylineSimilarity {
+			if parent := element.Parent(); parent != nil {
+				parent.RemoveChild(element)
+				removeEmptyContainers(root, parent)
+			}
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,851 @@
+package readability
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/mackee/go-readability/internal/dom"
+	"github.com/mackee/go-readability/internal/parser"
+)
+
+// renderedLength returns the whitespace-normalized rune count of an element's text.
+func renderedLength(element *dom.VElement) int {
+	return utf8.RuneCountInString(strings.Join(strings.Fields(ExtractTextContent(element)), " "))
+}
+
+func TestTruncateContent(t *testing.T) {
+	tests := []struct {
+		name      string
+		html      string
+		maxChars  int
+		truncated bool
+		expected  string
+	}{
+		{
+			name:      "content within the limit is untouched",
+			html:      `<div><p>Short text.</p></div>`,
+			maxChars:  100,
+			truncated: false,
+			expected:  "<div><p>Short text.</p></div>",
+		},
+		{
+			name:      "cut at a word boundary",
+			html:      `<div><p>The quick brown fox jumps over the lazy dog.</p></div>`,
+			maxChars:  21,
+			truncated: true,
+			expected:  "<div><p>The quick brown fox…</p></div>",
+		},
+		{
+			name:      "following blocks are removed",
+			html:      `<div><p>First paragraph.</p><p>Second paragraph is long.</p><p>Third.</p></div>`,
+			maxChars:  25,
+			truncated: true,
+			expected:  "<div><p>First paragraph.</p><p>Second…</p></div>",
+		},
+		{
+			name:      "a link that would be cut is dropped",
+			html:      `<div><p>Read <a href="https://example.com">the full story here</a> now.</p></div>`,
+			maxChars:  15,
+			truncated: true,
+			expected:  "<div><p>Read …</p></div>",
+		},
+		{
+			name:      "CJK text is cut at a sentence boundary",
+			html:      `<div><p>これは最初の文です。これは二番目の文です。</p></div>`,
+			maxChars:  15,
+			truncated: true,
+			expected:  "<div><p>これは最初の文です。…</p></div>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.ParseHTML(tt.html, "")
+			if err != nil {
+				t.Fatalf("Failed to parse HTML: %v", err)
+			}
+			root := dom.GetElementsByTagName(doc.Body, "div")[0]
+
+			if truncated := truncateContent(root, tt.maxChars); truncated != tt.truncated {
+				t.Errorf("truncateContent() = %v, want %v", truncated, tt.truncated)
+			}
+			if html := parser.SerializeToHTML(root); html != tt.expected {
+				t.Errorf("Expected HTML: %s, got: %s", tt.expected, html)
+			}
+			if length := renderedLength(root); length > tt.maxChars {
+				t.Errorf("Rendered length %d exceeds limit %d", length, tt.maxChars)
+			}
+		})
+	}
+}
+
+func TestExtractMaxOutputChars(t *testing.T) {
+	paragraph := `<p>Readability extracts the main content of a page and drops navigation,
+		advertisements and other clutter. See <a href="https://example.com/docs">the documentation</a>
+		for the details of the scoring algorithm used to pick the best candidate.</p>`
+	html := `<html><head><title>Truncation</title></head><body><article>` +
+		strings.Repeat(paragraph, 5) + `</article></body></html>`
+
+	options := DefaultOptions()
+	options.CharThreshold = 100
+
+	t.Run("no limit", func(t *testing.T) {
+		article, err := Extract(html, options)
+		if err != nil {
+			t.Fatalf("Extract failed: %v", err)
+		}
+		if article.Root == nil {
+			t.Fatal("Expected content to be extracted")
+		}
+		if article.Truncated {
+			t.Error("Expected article not to be truncated")
+		}
+	})
+
+	extract := func(t *testing.T, limit int, format OutputFormat) *dom.VElement {
+		t.Helper()
+		opts := options
+		opts.MaxOutputChars = limit
+		opts.OutputFormat = format
+		article, err := Extract(html, opts)
+		if err != nil {
+			t.Fatalf("Extract failed: %v", err)
+		}
+		if article.Root == nil {
+			t.Fatal("Expected content to be extracted")
+		}
+		if !article.Truncated {
+			t.Errorf("limit %d: expected article to be truncated", limit)
+		}
+		return article.Root
+	}
+
+	for _, limit := range []int{40, 150, 400} {
+		t.Run(fmt.Sprintf("html/%d", limit), func(t *testing.T) {
+			output := ToHTML(extract(t, limit, OutputFormatHTML))
+			if !utf8.ValidString(output) {
+				t.Errorf("limit %d: output is not valid UTF-8", limit)
+			}
+			if !strings.Contains(output, ellipsis) {
+				t.Errorf("limit %d: expected ellipsis in %q", limit, output)
+			}
+			if length := utf8.RuneCountInString(output); length > limit {
+				t.Errorf("limit %d: rendered length %d exceeds limit in %q", limit, length, output)
+			}
+		})
+
+		t.Run(fmt.Sprintf("markdown/%d", limit), func(t *testing.T) {
+			output := ToMarkdown(extract(t, limit, OutputFormatMarkdown))
+			if !strings.HasSuffix(strings.TrimSpace(output), ellipsis) {
+				t.Errorf("limit %d: expected trailing ellipsis in %q", limit, output)
+			}
+			if strings.Count(output, "[") != strings.Count(output, "](") {
+				t.Errorf("limit %d: found a partial link in %q", limit, output)
+			}
+			if length := utf8.RuneCountInString(output); length > limit {
+				t.Errorf("limit %d: rendered length %d exceeds limit in %q", limit, length, output)
+			}
+		})
+
+		t.Run(fmt.Sprintf("text/%d", limit), func(t *testing.T) {
+			root := extract(t, limit, OutputFormatText)
+			output := strings.TrimSpace(Stringify(root))
+			if !strings.HasSuffix(output, ellipsis) {
+				t.Errorf("limit %d: expected trailing ellipsis in %q", limit, output)
+			}
+			if length := renderedLength(root); length > limit {
+				t.Errorf("limit %d: text length %d exceeds limit", limit, length)
+			}
+		})
+	}
+
+	t.Run("limit below the markdown prefix", func(t *testing.T) {
+		html := `<html><head><title>Truncation</title></head><body><article><h2>Scoring</h2>` +
+			strings.Repeat(paragraph, 5) + `</article></body></html>`
+		for _, format := range []OutputFormat{OutputFormatMarkdown, OutputFormatText} {
+			opts := options
+			opts.MaxOutputChars = 3
+			opts.OutputFormat = format
+			article, err := Extract(html, opts)
+			if err != nil {
+				t.Fatalf("Extract failed: %v", err)
+			}
+			if article.Root == nil || !article.Truncated {
+				t.Fatalf("%q: expected truncated content", format)
+			}
+			output := ToMarkdown(article.Root)
+			if format == OutputFormatText {
+				output = strings.TrimSpace(Stringify(article.Root))
+			}
+			if length := utf8.RuneCountInString(output); length > 3 || !strings.Contains(output, ellipsis) {
+				t.Errorf("%q: expected at most 3 runes ending with an ellipsis, got %q", format, output)
+			}
+		}
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		opts := options
+		opts.OutputFormat = "pdf"
+		if _, err := Extract(html, opts); !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("Expected ErrInvalidOptions, got %v", err)
+		}
+	})
+}
+
+func TestFindBylineElement(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		expected string
+	}{
+		{
+			name:     "byline paragraph above the content",
+			html:     `<p class="byline">By Jane Doe</p><div id="content"><p>Body.</p></div>`,
+			expected: "By Jane Doe",
+		},
+		{
+			name:     "author link inside a header of an ancestor",
+			html:     `<header><h1>Title</h1><span>Posted by <a rel="author" href="/jane">Jane</a></span></header><div><div id="content"><p>Body.</p></div></div>`,
+			expected: "Posted by Jane",
+		},
+		{
+			name:     "author link inside a byline paragraph",
+			html:     `<p>By <a rel="author" href="/jane">Jane</a> Doe</p><div id="content"><p>Body.</p></div>`,
+			expected: "By Jane Doe",
+		},
+		{
+			name:     "navigation is not a byline",
+			html:     `<nav class="author-links"><a href="/a">Author A</a></nav><div id="content"><p>Body.</p></div>`,
+			expected: "",
+		},
+		{
+			name:     "long text is not a byline",
+			html:     `<div class="author-bio">` + strings.Repeat("Jane writes about many things. ", 10) + `</div><div id="content"><p>Body.</p></div>`,
+			expected: "",
+		},
+		{
+			name:     "elements after the content are ignored",
+			html:     `<div id="content"><p>Body.</p></div><p class="byline">By Jane Doe</p>`,
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.ParseHTML(tt.html, "")
+			if err != nil {
+				t.Fatalf("Failed to parse HTML: %v", err)
+			}
+			var root *dom.VElement
+			for _, div := range dom.GetElementsByTagName(doc.Body, "div") {
+				if div.ID() == "content" {
+					root = div
+				}
+			}
+
+			byline := FindBylineElement(root)
+			text := ""
+			if byline != nil {
+				text = strings.TrimSpace(GetInnerText(byline, true))
+			}
+			if text != tt.expected {
+				t.Errorf("FindBylineElement() = %q, want %q", text, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExtractIncludeByline(t *testing.T) {
+	html := `<html><head><title>Byline</title></head><body>
+		<nav><ul><li><a href="/">Home</a></li></ul></nav>
+		<p class="byline">By Jane Doe, March 3, 2024</p>
+		<article>` + strings.Repeat(`<p>Readability extracts the main content of a page and drops
+			navigation, advertisements and other clutter from it.</p>`, 5) + `</article>
+	</body></html>`
+
+	options := DefaultOptions()
+	options.CharThreshold = 100
+
+	article, err := Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if strings.Contains(ToMarkdown(article.Root), "Jane Doe") {
+		t.Error("Expected byline to be excluded by default")
+	}
+
+	options.IncludeByline = true
+	article, err = Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	markdown := ToMarkdown(article.Root)
+	if !strings.HasPrefix(markdown, "By Jane Doe, March 3, 2024") {
+		t.Errorf("Expected output to start with the byline, got %q", markdown)
+	}
+	if strings.Contains(markdown, "Home") {
+		t.Errorf("Expected navigation to be excluded, got %q", markdown)
+	}
+}
+
+func TestTrimBoilerplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		expected string
+		removed  int
+	}{
+		{
+			name:     "trailing copyright lines",
+			html:     `<p>First paragraph.</p><p>Last paragraph.</p><p>© 2024 Example Corp.</p><p><small>All rights reserved.</small></p>`,
+			expected: "First paragraph.Last paragraph.",
+			removed:  2,
+		},
+		{
+			name:     "link-only line inside a trailing container",
+			html:     `<div><p>Body text.</p><p><a href="/terms">Terms</a> | <a href="/about">About</a></p></div>`,
+			expected: "Body text.",
+			removed:  1,
+		},
+		{
+			name:     "stops at the first substantial paragraph",
+			html:     `<p>© 2024 Example Corp.</p><p>Body text.</p><p>All rights reserved.</p>`,
+			expected: "© 2024 Example Corp.Body text.",
+			removed:  1,
+		},
+		{
+			name:     "content without boilerplate is kept",
+			html:     `<p>Body text with a <a href="/more">link</a>.</p>`,
+			expected: "Body text with a link.",
+			removed:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.ParseHTML(`<html><body><div id="content">`+tt.html+`</div></body></html>`, "")
+			if err != nil {
+				t.Fatalf("Failed to parse HTML: %v", err)
+			}
+			root := dom.GetElementsByTagName(doc.Body, "div")[0]
+
+			removed := trimBoilerplate(root)
+			if removed != tt.removed {
+				t.Errorf("Expected %d elements removed, got %d", tt.removed, removed)
+			}
+			if text := ExtractTextContent(root); text != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, text)
+			}
+		})
+	}
+}
+
+func TestExtractTrimBoilerplate(t *testing.T) {
+	html := `<html><head><title>Boilerplate</title></head><body><article>` +
+		strings.Repeat(`<p>Readability extracts the main content of a page and drops
+			navigation, advertisements and other clutter from it.</p>`, 5) +
+		`<p>Copyright © 2024 Example Corp. All rights reserved.</p></article></body></html>`
+
+	options := DefaultOptions()
+	options.CharThreshold = 100
+
+	article, err := Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if !strings.Contains(ToMarkdown(article.Root), "All rights reserved") {
+		t.Error("Expected boilerplate to be kept by default")
+	}
+
+	options.TrimBoilerplate = true
+	article, err = Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	markdown := ToMarkdown(article.Root)
+	if strings.Contains(markdown, "All rights reserved") {
+		t.Errorf("Expected boilerplate to be trimmed, got %q", markdown)
+	}
+	if !strings.HasSuffix(strings.TrimSpace(markdown), "clutter from it.") {
+		t.Errorf("Expected content to end with the last paragraph, got %q", markdown)
+	}
+}
+
+func TestMergeInlineElements(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		expected string
+		merged   int
+	}{
+		{
+			name:     "split bold runs",
+			html:     `<p><b>Hel</b><b>lo</b> world</p>`,
+			expected: "**Hello** world",
+			merged:   1,
+		},
+		{
+			name:     "nested runs become adjacent after merging",
+			html:     `<p><strong><em>One</em></strong><strong><em> two</em></strong></p>`,
+			expected: "***One two***",
+			merged:   2,
+		},
+		{
+			name:     "elements separated by text are kept",
+			html:     `<p><b>One</b> and <b>two</b></p>`,
+			expected: "**One** and **two**",
+			merged:   0,
+		},
+		{
+			name:     "different attributes are kept",
+			html:     `<p><code class="a">x</code><code class="b">y</code></p>`,
+			expected: "`x` `y`",
+			merged:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.ParseHTML(tt.html, "")
+			if err != nil {
+				t.Fatalf("Failed to parse HTML: %v", err)
+			}
+
+			if merged := mergeInlineElements(doc.Body); merged != tt.merged {
+				t.Errorf("Expected %d elements merged, got %d", tt.merged, merged)
+			}
+			if markdown := ToMarkdown(doc.Body); markdown != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, markdown)
+			}
+		})
+	}
+}
+
+func TestRemoveNavLike(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		expected string
+		removed  int
+	}{
+		{
+			name: "menu list",
+			html: `<div><p>Article text that stays.</p><ul><li><a href="/">Home</a></li><li><a href="/news">News</a></li>` +
+				`<li><a href="/sports">Sports</a></li><li><a href="/about">About</a></li></ul></div>`,
+			expected: "Article text that stays.",
+			removed:  1,
+		},
+		{
+			name:     "tag cloud",
+			html:     `<div><p>Article text that stays.</p><div class="tags"><a href="/t/go">go</a> <a href="/t/html">html</a> <a href="/t/web">web</a></div></div>`,
+			expected: "Article text that stays.",
+			removed:  1,
+		},
+		{
+			name: "paragraph with many links",
+			html: `<div><p>See <a href="/a">the first study</a>, which is discussed at length below, and compare it with ` +
+				`<a href="/b">the second one</a> and <a href="/c">the third</a>, since all three reached different conclusions.</p></div>`,
+			expected: "See [the first study](/a), which is discussed at length below, and compare it with " +
+				"[the second one](/b) and [the third](/c), since all three reached different conclusions.",
+			removed: 0,
+		},
+		{
+			name:     "list with few links",
+			html:     `<div><ul><li><a href="/a">One</a></li><li><a href="/b">Two</a></li></ul></div>`,
+			expected: "- [One](/a)\n- [Two](/b)",
+			removed:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := ParseHTML("<html><body>"+tt.html+"</body></html>", "")
+			if err != nil {
+				t.Fatalf("Failed to parse HTML: %v", err)
+			}
+			root := GetElementsByTagName(doc.Body, "div")[0]
+			if removed := removeNavLike(root); removed != tt.removed {
+				t.Errorf("Expected %d removed elements, got %d", tt.removed, removed)
+			}
+			if markdown := strings.TrimSpace(ToMarkdown(root)); markdown != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, markdown)
+			}
+		})
+	}
+}
+
+func TestExtractRemoveNavLike(t *testing.T) {
+	html := `<html><head><title>Menus</title></head><body><article>` +
+		strings.Repeat(`<p>Readability extracts the main content of a page and drops
+			navigation, advertisements and other clutter from it.</p>`, 3) +
+		`<ul class="menu"><li><a href="/">Home</a></li><li><a href="/blog">Blog</a></li>` +
+		`<li><a href="/projects">Projects</a></li><li><a href="/contact">Contact</a></li></ul>` +
+		strings.Repeat(`<p>Readability extracts the main content of a page and drops
+			navigation, advertisements and other clutter from it.</p>`, 2) +
+		`</article></body></html>`
+
+	options := DefaultOptions()
+	options.CharThreshold = 100
+
+	article, err := Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if !strings.Contains(ToMarkdown(article.Root), "[Projects](/projects)") {
+		t.Error("Expected the menu to be kept by default")
+	}
+
+	options.RemoveNavLike = true
+	article, err = Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	markdown := ToMarkdown(article.Root)
+	if strings.Contains(markdown, "Projects") {
+		t.Errorf("Expected the menu to be removed, got %q", markdown)
+	}
+	if strings.Count(markdown, "clutter from it.") != 5 {
+		t.Errorf("Expected all paragraphs to be kept, got %q", markdown)
+	}
+}
+
+func TestLimitParagraphs(t *testing.T) {
+	tests := []struct {
+		name      string
+		html      string
+		limit     int
+		expected  string
+		truncated bool
+	}{
+		{
+			name:      "leading heading and image",
+			html:      `<div><h2>Intro</h2><p><img src="/a.png" alt="A"></p><p>First.</p><p>Second.</p><p>Third.</p></div>`,
+			limit:     2,
+			expected:  "## Intro\n\n![A](/a.png)\n\nFirst.\n\nSecond.",
+			truncated: true,
+		},
+		{
+			name:      "nested containers",
+			html:      `<div><section><p>First.</p></section><section><p>Second.</p><p>Third.</p></section></div>`,
+			limit:     2,
+			expected:  "First.\n\nSecond.",
+			truncated: true,
+		},
+		{
+			name:      "list counts once",
+			html:      `<div><ul><li>One</li><li>Two</li></ul><p>After.</p><p>Dropped.</p></div>`,
+			limit:     2,
+			expected:  "- One\n- Two\n\nAfter.",
+			truncated: true,
+		},
+		{
+			name:      "fewer paragraphs than the limit",
+			html:      `<div><p>First.</p><p>Second.</p></div>`,
+			limit:     3,
+			expected:  "First.\n\nSecond.",
+			truncated: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := ParseHTML("<html><body>"+tt.html+"</body></html>", "")
+			if err != nil {
+				t.Fatalf("Failed to parse HTML: %v", err)
+			}
+			root := GetElementsByTagName(doc.Body, "div")[0]
+			if truncated := limitParagraphs(root, tt.limit); truncated != tt.truncated {
+				t.Errorf("Expected truncated=%v, got %v", tt.truncated, truncated)
+			}
+			if markdown := strings.TrimSpace(ToMarkdown(root)); markdown != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, markdown)
+			}
+		})
+	}
+}
+
+func TestExtractMaxParagraphs(t *testing.T) {
+	html := `<html><head><title>Summary</title></head><body><article><h1>Summary</h1>` +
+		strings.Repeat(`<p>Readability extracts the main content of a page and drops
+			navigation, advertisements and other clutter from it.</p>`, 5) +
+		`</article></body></html>`
+
+	options := DefaultOptions()
+	options.CharThreshold = 100
+	options.MaxParagraphs = 2
+
+	article, err := Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if !article.Truncated {
+		t.Error("Expected article to be truncated")
+	}
+	if count := len(GetElementsByTagName(article.Root, "p")); count != 2 {
+		t.Errorf("Expected 2 paragraphs, got %d", count)
+	}
+}
+
+func TestExtractStopSelector(t *testing.T) {
+	html := `<html><head><title>Stop</title></head><body><article><h1>Stop</h1>` +
+		strings.Repeat(`<p>Readability extracts the main content of a page and drops
+			navigation, advertisements and other clutter from it.</p>`, 5) +
+		`<div><p>Last words of the article.</p><div class="article-end"></div>` +
+		`<p>More from the site: other stories.</p></div>` +
+		`<section class="comments"><p>First!</p></section>` +
+		`</article></body></html>`
+
+	options := DefaultOptions()
+	options.CharThreshold = 100
+	options.StopSelector = ".article-end"
+
+	article, err := Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	text := GetInnerText(article.Root, true)
+	if !strings.Contains(text, "Last words of the article.") {
+		t.Errorf("Expected the content before the marker to be kept, got %q", text)
+	}
+	for _, removed := range []string{"More from the site", "First!"} {
+		if strings.Contains(text, removed) {
+			t.Errorf("Expected %q after the marker to be removed, got %q", removed, text)
+		}
+	}
+
+	options.StopSelector = "div > p"
+	if _, err := Extract(html, options); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("Expected ErrInvalidOptions for an unsupported selector, got %v", err)
+	}
+}
+
+func TestRemoveEmptyLinks(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		expected string
+		removed  int
+	}{
+		{
+			name:     "empty anchor",
+			html:     `<div><p>Text<a href="/share"></a> continues.</p></div>`,
+			expected: "<div><p>Text continues.</p></div>",
+			removed:  1,
+		},
+		{
+			name:     "fragment placeholder with text",
+			html:     `<div><p><a href="#">Read more</a> about it.</p></div>`,
+			expected: "<div><p>Read more about it.</p></div>",
+			removed:  1,
+		},
+		{
+			name:     "empty href with markup",
+			html:     `<div><p><a href=""><b>Bold</b> text</a></p></div>`,
+			expected: "<div><p><b>Bold</b> text</p></div>",
+			removed:  1,
+		},
+		{
+			name:     "image link and link target are kept",
+			html:     `<div><p><a href="/photo"><img src="a.png"/></a><a name="fn1"></a><a href="/docs">Docs</a></p></div>`,
+			expected: `<div><p><a href="/photo"><img src="a.png"/></a><a name="fn1"></a><a href="/docs">Docs</a></p></div>`,
+			removed:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := ParseHTML("<html><body>"+tt.html+"</body></html>", "")
+			if err != nil {
+				t.Fatalf("Failed to parse HTML: %v", err)
+			}
+			root := GetElementsByTagName(doc.Body, "div")[0]
+			if removed := removeEmptyLinks(root); removed != tt.removed {
+				t.Errorf("Expected %d unwrapped links, got %d", tt.removed, removed)
+			}
+			if html := ToHTML(root); html != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, html)
+			}
+		})
+	}
+}
+
+func TestExtractRemoveEmptyLinks(t *testing.T) {
+	html := `<html><head><title>Links</title></head><body><article>` +
+		strings.Repeat(`<p>Readability extracts the main content of a page and drops
+			navigation, advertisements and other clutter from it.<a href="/share"></a></p>`, 3) +
+		`<p><a href="#">Back to top</a></p></article></body></html>`
+
+	options := DefaultOptions()
+	options.CharThreshold = 100
+
+	article, err := Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if markdown := ToMarkdown(article.Root); !strings.Contains(markdown, "[Back to top](#)") {
+		t.Errorf("Expected links to be kept by default, got %q", markdown)
+	}
+
+	options.RemoveEmptyLinks = true
+	article, err = Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	markdown := ToMarkdown(article.Root)
+	if strings.Contains(markdown, "](") {
+		t.Errorf("Expected no links, got %q", markdown)
+	}
+	if !strings.Contains(markdown, "Back to top") {
+		t.Errorf("Expected the link text to be kept, got %q", markdown)
+	}
+}
+
+func TestNormalizeHeadings(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		expected string
+		removed  int
+	}{
+		{
+			name:     "empty headings",
+			html:     "<div><h2></h2><p>Text</p><h3><span> </span>\n</h3></div>",
+			expected: "<div><p>Text</p></div>",
+			removed:  2,
+		},
+		{
+			name:     "stray whitespace",
+			html:     "<div><h2>\n  Getting <em>started</em>\n  quickly  </h2><p>Text</p></div>",
+			expected: "<div><h2>Getting <em>started</em> quickly</h2><p>Text</p></div>",
+			removed:  0,
+		},
+		{
+			name:     "image heading is kept",
+			html:     `<div><h1><img src="logo.png"/></h1></div>`,
+			expected: `<div><h1><img src="logo.png"/></h1></div>`,
+			removed:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := ParseHTML("<html><body>"+tt.html+"</body></html>", "")
+			if err != nil {
+				t.Fatalf("Failed to parse HTML: %v", err)
+			}
+			root := GetElementsByTagName(doc.Body, "div")[0]
+			if removed := normalizeHeadings(root); removed != tt.removed {
+				t.Errorf("Expected %d removed headings, got %d", tt.removed, removed)
+			}
+			if html := ToHTML(root); html != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, html)
+			}
+		})
+	}
+}
+
+func TestExtractNormalizesHeadings(t *testing.T) {
+	html := `<html><head><title>Headings</title></head><body><article><h2> </h2>` +
+		strings.Repeat(`<p>Readability extracts the main content of a page and drops navigation, advertisements and other clutter from it.</p>`, 3) +
+		"<h2>  Next\n  steps </h2><p>Read the documentation.</p></article></body></html>"
+
+	options := DefaultOptions()
+	options.CharThreshold = 100
+	article, err := Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if len(article.Outline) != 1 || article.Outline[0].Text != "Next steps" {
+		t.Errorf("Expected a single outline item \"Next steps\", got %+v", article.Outline)
+	}
+	if markdown := ToMarkdown(article.Root); strings.Contains(markdown, "## \n") || !strings.Contains(markdown, "## Next steps") {
+		t.Errorf("Expected no empty heading, got %q", markdown)
+	}
+}
+
+func TestRemoveLeadingByline(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		byline   string
+		expected string
+		removed  bool
+	}{
+		{
+			name:     "byline after the title",
+			html:     `<div><h1>Title</h1><p>By Jane Doe</p><p>Body text.</p></div>`,
+			byline:   "Jane Doe",
+			expected: "<div><h1>Title</h1><p>Body text.</p></div>",
+			removed:  true,
+		},
+		{
+			name:     "byline in a container",
+			html:     `<div><div><p>Written by: jane doe</p></div><p>Body text.</p></div>`,
+			byline:   "Jane Doe",
+			expected: "<div><p>Body text.</p></div>",
+			removed:  true,
+		},
+		{
+			name:     "different author",
+			html:     `<div><p>By John Smith</p><p>Body text.</p></div>`,
+			byline:   "Jane Doe",
+			expected: "<div><p>By John Smith</p><p>Body text.</p></div>",
+			removed:  false,
+		},
+		{
+			name:     "byline mentioned later",
+			html:     `<div><p>Intro.</p><p>Body text.</p><p>Jane Doe</p></div>`,
+			byline:   "Jane Doe",
+			expected: "<div><p>Intro.</p><p>Body text.</p><p>Jane Doe</p></div>",
+			removed:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := ParseHTML("<html><body>"+tt.html+"</body></html>", "")
+			if err != nil {
+				t.Fatalf("Failed to parse HTML: %v", err)
+			}
+			root := GetElementsByTagName(doc.Body, "div")[0]
+			if removed := removeLeadingByline(root, tt.byline); removed != tt.removed {
+				t.Errorf("Expected removed = %v, got %v", tt.removed, removed)
+			}
+			if html := ToHTML(root); html != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, html)
+			}
+		})
+	}
+}
+
+func TestExtractDedupeBylineInContent(t *testing.T) {
+	html := `<html><head><title>Byline</title><meta name="author" content="Jane Doe"></head><body><article>` +
+		`<p>By Jane Doe</p>` +
+		strings.Repeat(`<p>Readability extracts the main content of a page and drops navigation, advertisements and other clutter from it.</p>`, 3) +
+		`</article></body></html>`
+
+	options := DefaultOptions()
+	options.CharThreshold = 100
+	article, err := Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if article.Byline != "Jane Doe" {
+		t.Fatalf("Expected the byline from metadata, got %q", article.Byline)
+	}
+	if markdown := ToMarkdown(article.Root); !strings.HasPrefix(markdown, "By Jane Doe") {
+		t.Errorf("Expected the byline line to be kept by default, got %q", markdown)
+	}
+
+	options.DedupeBylineInContent = true
+	article, err = Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if markdown := ToMarkdown(article.Root); strings.Contains(markdown, "Jane Doe") {
+		t.Errorf("Expected the byline line to be removed, got %q", markdown)
+	}
+}
@@ -4,11 +4,19 @@
 package readability
 
 import (
+	"log/slog"
 	"regexp"
+	"strings"
+	"unicode"
 
 	"github.com/mackee/go-readability/internal/dom"
+	"github.com/mackee/go-readability/internal/parser"
+	"golang.org/x/text/unicode/norm"
 )
 
+// Class names of text meant for screen readers only, such as Bootstrap's sr-only
+var screenReaderOnlyRegex = regexp.MustCompile(`(?i)(^|\s)(sr-only|visually-?hidden|screen-reader-(text|only)|a11y-hidden|assistive-text)(\s|$)`)
+
 // List of semantic tags to remove (lowercase)
 var tagsToRemove = []string{
 	"aside",    // Supplementary information not directly related to the main content, like sidebars
@@ -64,22 +72,320 @@ var adPatterns = []*regexp.Regexp{
 // Returns:
 //   - The same document after preprocessing (for method chaining)
 func PreprocessDocument(doc *dom.VDocument) *dom.VDocument {
+	return preprocessDocument(doc, nil)
+}
+
+// preprocessDocument is PreprocessDocument with an optional logger that receives
+// debug events about the removed elements.
+//
+// Parameters:
+//   - doc: The parsed HTML document to preprocess
+//   - logger: The logger for debug events, or nil to disable logging
+//
+// Returns:
+//   - The same document after preprocessing (for method chaining)
+func preprocessDocument(doc *dom.VDocument, logger *slog.Logger) *dom.VDocument {
+	if !hasBody(doc) {
+		return doc
+	}
+
 	// 1. Remove semantic tags and unnecessary tags
-	removeUnwantedTags(doc)
+	removed := removeUnwantedTags(doc)
+	if logger != nil {
+		logger.Debug("removed unwanted tags", slog.Int("count", removed))
+	}
 
 	// 2. Remove ad elements
-	removeAds(doc)
+	removeAds(doc, logger)
 
 	return doc
 }
 
+// preprocessIncluded reduces the body of a document to the subtrees matching an allowlist
+// (see ReadabilityOptions.IncludeOnlySelectors) and preprocesses each subtree. A matching
+// element is kept even if preprocessing would remove it (e.g. a <header>); only noise
+// inside it is removed.
+//
+// Parameters:
+//   - doc: The parsed HTML document
+//   - list: The allowlist selectors
+//   - logger: The logger for debug events, or nil to disable logging
+//
+// Returns:
+//   - The same document after reduction and preprocessing (for method chaining)
+func preprocessIncluded(doc *dom.VDocument, list selectorList, logger *slog.Logger) *dom.VDocument {
+	if !hasBody(doc) {
+		return doc
+	}
+
+	included := selectOutermost(doc.Body, list)
+	if logger != nil {
+		logger.Debug("selected included elements", slog.Int("count", len(included)))
+	}
+	doc.Body.Children = nil
+	for _, element := range included {
+		// Detached from the document, the element itself cannot be removed
+		if parent := element.Parent(); parent != nil {
+			parent.RemoveChild(element)
+		}
+		preprocessDocument(dom.NewVDocument(element, element), logger)
+		doc.Body.AppendChild(element)
+	}
+	return doc
+}
+
+// Reasons reported by PreprocessReport for a removed element
+const (
+	// RemovalReasonTag means the element's tag is removed by preprocessing (e.g. nav, script)
+	RemovalReasonTag = "tag"
+	// RemovalReasonAd means the element looks like an advertisement
+	RemovalReasonAd = "ad"
+)
+
+// RemovedElement describes an element that preprocessing removes.
+type RemovedElement struct {
+	Element   *dom.VElement `json:"-"`         // The element itself (still attached to the document)
+	TagName   string        `json:"tagName"`   // Lowercase tag name
+	ClassName string        `json:"className"` // Value of the class attribute
+	ID        string        `json:"id"`        // Value of the id attribute
+	Reason    string        `json:"reason"`    // RemovalReasonTag or RemovalReasonAd
+}
+
+// RemovalReport lists the elements that preprocessing removes, in the order it removes them.
+// Elements inside another removed element are not listed separately.
+type RemovalReport struct {
+	Removed []RemovedElement `json:"removed"`
+}
+
+// PreprocessReport reports what PreprocessDocument would remove from the document,
+// without changing it. This helps to debug content lost to over-aggressive removal.
+//
+// Parameters:
+//   - doc: The parsed HTML document to inspect
+//
+// Returns:
+//   - A RemovalReport listing the elements that would be removed
+func PreprocessReport(doc *dom.VDocument) RemovalReport {
+	report := RemovalReport{}
+	if !hasBody(doc) {
+		return report
+	}
+
+	removed := make(map[*dom.VElement]bool)
+	add := func(element *dom.VElement, reason string) {
+		// Skip elements that go away with a removed ancestor
+		for ancestor := element.Parent(); ancestor != nil; ancestor = ancestor.Parent() {
+			if removed[ancestor] {
+				return
+			}
+		}
+		removed[element] = true
+		report.Removed = append(report.Removed, RemovedElement{
+			Element:   element,
+			TagName:   strings.ToLower(element.TagName),
+			ClassName: element.ClassName(),
+			ID:        element.ID(),
+			Reason:    reason,
+		})
+	}
+
+	// Same order as preprocessDocument: unwanted tags first, then ads
+	for _, tagName := range tagsToRemove {
+		for _, element := range dom.GetElementsByTagName(doc.DocumentElement, tagName) {
+			if !removed[element] {
+				add(element, RemovalReasonTag)
+			}
+		}
+	}
+	for _, element := range dom.GetElementsByTagName(doc.Body, "*") {
+		if !removed[element] && isLikelyAd(element) {
+			add(element, RemovalReasonAd)
+		}
+	}
+	return report
+}
+
+// unwrapTemplates replaces every <template> and <noscript> element with its content,
+// so that content provided only for progressive enhancement can be extracted.
+// The raw markup inside <noscript> (which the parser keeps as text) is parsed first.
+// This must run before preprocessing, which removes <noscript> entirely.
+//
+// Parameters:
+//   - doc: The document to process
+//
+// Returns:
+//   - The number of unwrapped elements
+func unwrapTemplates(doc *dom.VDocument) int {
+	if !hasBody(doc) {
+		return 0
+	}
+
+	unwrapped := 0
+	for _, element := range dom.GetElementsByTagNames(doc.DocumentElement, []string{"template", "noscript"}) {
+		parent := element.Parent()
+		if parent == nil {
+			continue
+		}
+
+		content := element.Children
+		if element.TagName == "noscript" {
+			var markup strings.Builder
+			for _, child := range element.Children {
+				if text, ok := dom.AsVText(child); ok {
+					markup.WriteString(text.TextContent)
+				}
+			}
+			if markup.Len() > 0 {
+				nodes, err := parser.ParseFragment(markup.String())
+				if err != nil {
+					continue
+				}
+				content = nodes
+			}
+		}
+
+		// Replace the element with its content, in place
+		for i, child := range parent.Children {
+			if child == element {
+				children := make([]dom.VNode, 0, len(parent.Children)-1+len(content))
+				children = append(children, parent.Children[:i]...)
+				children = append(children, content...)
+				children = append(children, parent.Children[i+1:]...)
+				parent.Children = children
+				for _, node := range content {
+					node.SetParent(parent)
+				}
+				element.Children = nil
+				element.SetParent(nil)
+				unwrapped++
+				break
+			}
+		}
+	}
+	return unwrapped
+}
+
+// stripInvisibleChars removes characters that are invisible but break downstream tools:
+// zero-width spaces, word joiners, soft hyphens, byte order marks, and control characters.
+// Tabs, newlines, non-breaking spaces, and the zero-width (non-)joiners needed by emoji
+// sequences and some scripts are kept.
+//
+// Parameters:
+//   - text: The text to clean
+//
+// Returns:
+//   - The text without invisible characters
+func stripInvisibleChars(text string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '\t', '\n', '\r':
+			return r
+		case '\u200b', '\u2060', '\ufeff', '\u00ad':
+			return -1
+		}
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, text)
+}
+
+// removeInvisibleChars strips invisible characters from every text node under an element.
+//
+// Parameters:
+//   - element: The element to clean
+//
+// Returns:
+//   - The number of text nodes that were changed
+func removeInvisibleChars(element *dom.VElement) int {
+	changed := 0
+	for _, text := range collectTextNodes(element) {
+		if cleaned := stripInvisibleChars(text.TextContent); cleaned != text.TextContent {
+			text.TextContent = cleaned
+			changed++
+		}
+	}
+	return changed
+}
+
+// normalizeUnicode converts every text node under an element to Unicode normalization
+// form C (NFC), composing characters written as a base and combining marks.
+//
+// Parameters:
+//   - element: The element to normalize
+//
+// Returns:
+//   - The number of text nodes that were changed
+func normalizeUnicode(element *dom.VElement) int {
+	changed := 0
+	for _, text := range collectTextNodes(element) {
+		if !norm.NFC.IsNormalString(text.TextContent) {
+			text.TextContent = norm.NFC.String(text.TextContent)
+			changed++
+		}
+	}
+	return changed
+}
+
+// removeScreenReaderOnly removes the elements meant for screen readers only (see
+// screenReaderOnlyRegex and dom.IsVisuallyHidden) whose text repeats visible text, so
+// that the content is not doubled. Elements with text found nowhere else are kept.
+//
+// Parameters:
+//   - element: The element to clean
+//
+// Returns:
+//   - The number of removed elements
+func removeScreenReaderOnly(element *dom.VElement) int {
+	var candidates []*dom.VElement
+	var visible strings.Builder
+	var walk func(node *dom.VElement)
+	walk = func(node *dom.VElement) {
+		for _, child := range node.Children {
+			if text, ok := dom.AsVText(child); ok {
+				visible.WriteString(text.TextContent)
+				visible.WriteString(" ")
+				continue
+			}
+			childElement, ok := dom.AsVElement(child)
+			if !ok {
+				continue
+			}
+			if screenReaderOnlyRegex.MatchString(childElement.ClassName()) || dom.IsVisuallyHidden(childElement) {
+				candidates = append(candidates, childElement)
+				continue
+			}
+			walk(childElement)
+		}
+	}
+	walk(element)
+
+	visibleText := strings.Join(strings.Fields(visible.String()), " ")
+	removed := 0
+	for _, candidate := range candidates {
+		text := strings.Join(strings.Fields(GetInnerText(candidate, false)), " ")
+		if text != "" && !strings.Contains(visibleText, text) {
+			continue
+		}
+		if parent := candidate.Parent(); parent != nil {
+			parent.RemoveChild(candidate)
+			removed++
+		}
+	}
+	return removed
+}
+
 // removeUnwantedTags removes unwanted tags from the document.
 // This removes elements that are unlikely to contain main content, such as
 // navigation, scripts, styles, and other non-content elements.
 //
 // Parameters:
 //   - doc: The document to process
-func removeUnwantedTags(doc *dom.VDocument) {
+//
+// Returns:
+//   - The number of removed elements
+func removeUnwantedTags(doc *dom.VDocument) int {
+	removed := 0
 	for _, tagName := range tagsToRemove {
 		elements := dom.GetElementsByTagName(doc.DocumentElement, tagName)
 
@@ -89,12 +395,14 @@ func removeUnwantedTags(doc *dom.VDocument) {
 				for i, child := range parent.Children {
 					if child == element {
 						parent.Children = append(parent.Children[:i], parent.Children[i+1:]...)
+						removed++
 						break
 					}
 				}
 			}
 		}
 	}
+	return removed
 }
 
 // removeAds removes ad elements from the document.
@@ -103,22 +411,33 @@ func removeUnwantedTags(doc *dom.VDocument) {
 //
 // Parameters:
 //   - doc: The document to process
-func removeAds(doc *dom.VDocument) {
+//   - logger: The logger for debug events, or nil to disable logging
+//
+// Returns:
+//   - The number of removed elements
+func removeAds(doc *dom.VDocument, logger *slog.Logger) int {
 	// Get all elements under body
 	allElements := dom.GetElementsByTagName(doc.Body, "*")
 
 	// Remove elements that seem to be ads
+	removed := 0
 	for _, element := range allElements {
 		if isLikelyAd(element) && element.Parent() != nil {
 			parent := element.Parent()
 			for i, child := range parent.Children {
 				if child == element {
 					parent.Children = append(parent.Children[:i], parent.Children[i+1:]...)
+					removed++
 					break
 				}
 			}
 		}
 	}
+
+	if logger != nil {
+		logger.Debug("removed ad elements", slog.Int("count", removed))
+	}
+	return removed
 }
 
 // isLikelyAd determines if an element is likely an ad.
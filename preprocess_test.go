@@ -1,6 +1,7 @@
 package readability
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/mackee/go-readability/internal/dom"
@@ -201,3 +202,255 @@ func TestPreprocessDocument(t *testing.T) {
 		}
 	})
 }
+
+func TestUnwrapTemplates(t *testing.T) {
+	html := `
+		<html>
+			<body>
+				<div id="app">
+					<noscript><article><h1>Title</h1><p>Rendered without JavaScript.</p></article></noscript>
+					<template><p>From a template.</p></template>
+				</div>
+			</body>
+		</html>
+	`
+	doc, err := parser.ParseHTML(html, "")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	if unwrapped := unwrapTemplates(doc); unwrapped != 2 {
+		t.Errorf("Expected 2 unwrapped elements, got %d", unwrapped)
+	}
+	if remaining := dom.GetElementsByTagNames(doc.Body, []string{"noscript", "template"}); len(remaining) != 0 {
+		t.Errorf("Expected no noscript/template elements, got %d", len(remaining))
+	}
+
+	articles := dom.GetElementsByTagName(doc.Body, "article")
+	if len(articles) != 1 || articles[0].Parent().ID() != "app" {
+		t.Fatalf("Expected the noscript article to be promoted into #app")
+	}
+	if paragraphs := dom.GetElementsByTagName(doc.Body, "p"); len(paragraphs) != 2 {
+		t.Errorf("Expected 2 paragraphs, got %d", len(paragraphs))
+	}
+}
+
+func TestExtractUnwrapTemplates(t *testing.T) {
+	html := `<html><head><title>App</title></head><body>
+		<div id="app">Loading…</div>
+		<noscript><article><h1>Title</h1>` +
+		strings.Repeat("<p>This paragraph has enough text, and commas, to be scored as content.</p>", 10) +
+		`</article></noscript>
+	</body></html>`
+
+	options := DefaultOptions()
+	article, err := Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if article.Root != nil {
+		t.Errorf("Expected no content without UnwrapTemplates, got <%s>", article.Root.TagName)
+	}
+
+	options.UnwrapTemplates = true
+	article, err = Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if article.Root == nil || article.Root.TagName != "article" {
+		t.Fatalf("Expected the noscript article to be extracted, got %v", article.Root)
+	}
+	if !strings.Contains(ToMarkdown(article.Root), "# Title") {
+		t.Errorf("Expected the heading in the output, got %q", ToMarkdown(article.Root))
+	}
+}
+
+func TestStripInvisibleChars(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "zero-width spaces", input: "zero\u200bwidth\u200b spaces", expected: "zerowidth spaces"},
+		{name: "soft hyphens and word joiners", input: "read\u00adabil\u2060ity", expected: "readability"},
+		{name: "byte order mark", input: "\ufeffStart of text", expected: "Start of text"},
+		{name: "control characters", input: "bell\a and\x00 null", expected: "bell and null"},
+		{name: "whitespace is kept", input: "tab\tnewline\nnbsp\u00a0end", expected: "tab\tnewline\nnbsp\u00a0end"},
+		{name: "emoji joiners are kept", input: "👩\u200d💻 and नम\u200cस्ते", expected: "👩\u200d💻 and नम\u200cस्ते"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := stripInvisibleChars(tt.input); result != tt.expected {
+				t.Errorf("stripInvisibleChars(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExtractStripInvisibleChars(t *testing.T) {
+	html := "<html><head><title>\ufeffInvisible\u200b</title></head><body><article>" +
+		strings.Repeat("<p>Readabil\u00adity extracts\u200b the main content of a page and drops navigation, ads and clutter.</p>", 8) +
+		"</article></body></html>"
+
+	options := DefaultOptions()
+	options.StripInvisibleChars = true
+
+	article, err := Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if article.Root == nil {
+		t.Fatal("Expected content to be extracted")
+	}
+	if article.Title != "Invisible" {
+		t.Errorf("Expected clean title, got %q", article.Title)
+	}
+	markdown := ToMarkdown(article.Root)
+	if strings.ContainsAny(markdown, "\u200b\u00ad\ufeff") {
+		t.Errorf("Expected no invisible characters, got %q", markdown)
+	}
+	if !strings.Contains(markdown, "Readability extracts the main content") {
+		t.Errorf("Expected cleaned text, got %q", markdown)
+	}
+}
+
+func TestExtractNormalizeUnicode(t *testing.T) {
+	// "Café déjà vu" with each accent written as a combining mark
+	decomposed := "Cafe\u0301 de\u0301ja\u0300 vu"
+	html := "<html><head><title>" + decomposed + " at the harbor</title>" +
+		`<meta name="author" content="Rene` + "\u0301" + ` Dupont"></head><body><article>` +
+		strings.Repeat("<p>"+decomposed+": readability extracts the main content of a page and drops navigation, ads and clutter.</p>", 8) +
+		"</article></body></html>"
+
+	options := DefaultOptions()
+	article, err := Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if !strings.Contains(Stringify(article.Root), decomposed) {
+		t.Error("Expected the text to be left as is by default")
+	}
+
+	options.NormalizeUnicode = true
+	article, err = Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if article.Root == nil {
+		t.Fatal("Expected content to be extracted")
+	}
+	if article.Title != "Café déjà vu at the harbor" {
+		t.Errorf("Expected composed title, got %q", article.Title)
+	}
+	if article.Byline != "René Dupont" {
+		t.Errorf("Expected composed byline, got %q", article.Byline)
+	}
+	for name, output := range map[string]string{
+		"Stringify": Stringify(article.Root),
+		"Markdown":  ToMarkdown(article.Root),
+		"HTML":      ToHTML(article.Root),
+	} {
+		if !strings.Contains(output, "Café déjà vu: readability") || strings.ContainsAny(output, "\u0300\u0301") {
+			t.Errorf("Expected composed characters in %s output, got %q", name, output)
+		}
+	}
+
+	// Markdown conversion of other elements can normalize its output too
+	markdownOptions := DefaultMarkdownOptions()
+	markdownOptions.NormalizeUnicode = true
+	element := dom.NewVElement("p")
+	element.AppendChild(dom.NewVText(decomposed))
+	if markdown := ToMarkdownWithOptions(element, markdownOptions); markdown != "Café déjà vu" {
+		t.Errorf("Expected composed Markdown, got %q", markdown)
+	}
+}
+
+func TestPreprocessReport(t *testing.T) {
+	html := `<html><head><title>Report</title><script>var x = 1;</script></head><body>
+		<nav class="site-nav"><ul><li><a href="/">Home</a></li></ul></nav>
+		<article>
+			<p>Article text.</p>
+			<div class="ad-container" id="top-ad"><span class="ad-label">Ad</span></div>
+		</article>
+		<aside><nav>Nested navigation</nav></aside>
+	</body></html>`
+
+	doc, err := parser.ParseHTML(html, "")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+	before := parser.SerializeToHTML(doc.DocumentElement)
+
+	report := PreprocessReport(doc)
+
+	expected := []RemovedElement{
+		{TagName: "aside", Reason: RemovalReasonTag},
+		{TagName: "nav", ClassName: "site-nav", Reason: RemovalReasonTag},
+		{TagName: "script", Reason: RemovalReasonTag},
+		{TagName: "div", ClassName: "ad-container", ID: "top-ad", Reason: RemovalReasonAd},
+	}
+	if len(report.Removed) != len(expected) {
+		t.Fatalf("Expected %d removed elements, got %d: %+v", len(expected), len(report.Removed), report.Removed)
+	}
+	for i, want := range expected {
+		got := report.Removed[i]
+		got.Element = nil
+		if got != want {
+			t.Errorf("Removed[%d] = %+v, want %+v", i, got, want)
+		}
+	}
+
+	if after := parser.SerializeToHTML(doc.DocumentElement); after != before {
+		t.Error("Expected PreprocessReport to leave the document unchanged")
+	}
+
+	// The report matches what preprocessing actually removes
+	PreprocessDocument(doc)
+	remaining := make(map[*dom.VElement]bool)
+	for _, element := range dom.GetElementsByTagName(doc.DocumentElement, "*") {
+		remaining[element] = true
+	}
+	for _, removed := range report.Removed {
+		if remaining[removed.Element] {
+			t.Errorf("Expected <%s class=%q> to be removed by PreprocessDocument", removed.TagName, removed.ClassName)
+		}
+	}
+}
+
+func TestExtractDropScreenReaderOnly(t *testing.T) {
+	html := `<html><head><title>Screen readers</title></head><body><article>` +
+		`<h2>Weekly summary</h2><p>Prices rose again this week.</p>` +
+		`<p style="position: absolute; left: -10000px">Weekly summary</p>` +
+		`<p class="sr-only">Prices rose again this week.</p>` +
+		`<p>Read the <a href="/guide">full guide<span class="visually-hidden"> (opens in a new tab)</span></a> first.</p>` +
+		strings.Repeat(`<p>Readability extracts the main content of a page and drops navigation, ads and clutter.</p>`, 6) +
+		`</article></body></html>`
+
+	options := DefaultOptions()
+	article, err := Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if count := strings.Count(GetInnerText(article.Root, true), "Prices rose again this week."); count != 2 {
+		t.Errorf("Expected screen-reader-only duplicates to be kept by default, got the text %d times", count)
+	}
+
+	options.DropScreenReaderOnly = true
+	article, err = Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if article.Root == nil {
+		t.Fatal("Expected content to be extracted")
+	}
+	text := GetInnerText(article.Root, true)
+	for _, duplicate := range []string{"Weekly summary", "Prices rose again this week."} {
+		if count := strings.Count(text, duplicate); count != 1 {
+			t.Errorf("Expected %q once, got %d times in %q", duplicate, count, text)
+		}
+	}
+	if !strings.Contains(text, "(opens in a new tab)") {
+		t.Errorf("Expected unique screen-reader-only text to be kept, got %q", text)
+	}
+}
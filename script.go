@@ -0,0 +1,87 @@
+// Package readability provides functionality to extract readable content from HTML documents.
+// It implements an algorithm similar to Mozilla's Readability.js to identify and extract
+// the main content from web pages, removing clutter, navigation, ads, and other non-content elements.
+package readability
+
+import (
+	"unicode"
+)
+
+// Script represents the dominant writing system of a text.
+type Script string
+
+const (
+	// ScriptUnknown is used when a text has no letters
+	ScriptUnknown Script = ""
+	// ScriptLatin represents Latin-based writing systems (English, French, ...)
+	ScriptLatin Script = "latin"
+	// ScriptCJK represents Chinese, Japanese, and Korean writing systems
+	ScriptCJK Script = "cjk"
+	// ScriptCyrillic represents Cyrillic writing systems (Russian, Ukrainian, ...)
+	ScriptCyrillic Script = "cyrillic"
+	// ScriptArabic represents the Arabic writing system
+	ScriptArabic Script = "arabic"
+)
+
+// cjkThresholdFactor scales the character threshold for CJK text.
+// A CJK character carries roughly as much content as a short Latin word,
+// so far fewer characters make up a legitimate article.
+const cjkThresholdFactor = 0.5
+
+// DetectScript detects the dominant script of a text by counting its letters.
+//
+// Parameters:
+//   - text: The text to analyze
+//
+// Returns:
+//   - The script with the most letters, or ScriptUnknown if the text has no letters
+func DetectScript(text string) Script {
+	counts := map[Script]int{}
+	for _, r := range text {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			counts[ScriptCJK]++
+		case unicode.Is(unicode.Latin, r):
+			counts[ScriptLatin]++
+		case unicode.Is(unicode.Cyrillic, r):
+			counts[ScriptCyrillic]++
+		case unicode.Is(unicode.Arabic, r):
+			counts[ScriptArabic]++
+		}
+	}
+
+	dominant := ScriptUnknown
+	maxCount := 0
+	// Iterate in a fixed order so that ties are resolved deterministically
+	for _, script := range []Script{ScriptLatin, ScriptCJK, ScriptCyrillic, ScriptArabic} {
+		if counts[script] > maxCount {
+			dominant = script
+			maxCount = counts[script]
+		}
+	}
+	return dominant
+}
+
+// isCJKLanguage checks whether a language tag denotes Chinese, Japanese, or Korean.
+func isCJKLanguage(lang string) bool {
+	switch sentenceLanguage(lang) {
+	case "zh", "ja", "ko":
+		return true
+	}
+	return false
+}
+
+// effectiveCharThreshold adjusts a character threshold to the script of the content.
+//
+// Parameters:
+//   - charThreshold: The configured character threshold
+//   - script: The dominant script of the content
+//
+// Returns:
+//   - The character threshold to use for the content
+func effectiveCharThreshold(charThreshold int, script Script) int {
+	if script == ScriptCJK {
+		return int(float64(charThreshold) * cjkThresholdFactor)
+	}
+	return charThreshold
+}
@@ -0,0 +1,122 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetectScript(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected Script
+	}{
+		{name: "english", text: "The quick brown fox jumps over the lazy dog.", expected: ScriptLatin},
+		{name: "japanese", text: "これは日本語の文章です。カタカナも含みます。", expected: ScriptCJK},
+		{name: "japanese with latin words", text: "Go言語でHTMLを解析して本文を抽出します。", expected: ScriptCJK},
+		{name: "korean", text: "한국어 문장입니다.", expected: ScriptCJK},
+		{name: "russian", text: "Быстрая коричневая лиса.", expected: ScriptCyrillic},
+		{name: "arabic", text: "الثعلب البني السريع", expected: ScriptArabic},
+		{name: "no letters", text: "123 456 !?", expected: ScriptUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if script := DetectScript(tt.text); script != tt.expected {
+				t.Errorf("DetectScript(%q) = %q, want %q", tt.text, script, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEffectiveCharThreshold(t *testing.T) {
+	english := effectiveCharThreshold(500, DetectScript("An English article about readability."))
+	japanese := effectiveCharThreshold(500, DetectScript("可読性についての日本語の記事です。"))
+
+	if english != 500 {
+		t.Errorf("Expected English threshold to be 500, got %d", english)
+	}
+	if japanese >= english {
+		t.Errorf("Expected Japanese threshold (%d) to be lower than English threshold (%d)", japanese, english)
+	}
+}
+
+func TestExtractAutoThreshold(t *testing.T) {
+	// About 400 bytes of text: below the default threshold of 500, above the CJK threshold of 250
+	japanese := `<html><head><title>記事</title></head><body><article>` +
+		strings.Repeat("<p>本文抽出のアルゴリズムは段落を評価して記事を選びます。</p>", 5) +
+		`</article></body></html>`
+	english := `<html><head><title>Article</title></head><body><article>` +
+		strings.Repeat("<p>The extraction algorithm scores paragraphs to pick the article.</p>", 5) +
+		`</article></body></html>`
+
+	tests := []struct {
+		name          string
+		html          string
+		autoThreshold bool
+		expectRoot    bool
+		expectScript  Script
+	}{
+		{name: "japanese without auto threshold", html: japanese, autoThreshold: false, expectRoot: false, expectScript: ScriptCJK},
+		{name: "japanese with auto threshold", html: japanese, autoThreshold: true, expectRoot: true, expectScript: ScriptCJK},
+		{name: "english with auto threshold", html: english, autoThreshold: true, expectRoot: false, expectScript: ScriptLatin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultOptions()
+			options.AutoThreshold = tt.autoThreshold
+
+			article, err := Extract(tt.html, options)
+			if err != nil {
+				t.Fatalf("Extract failed: %v", err)
+			}
+			if (article.Root != nil) != tt.expectRoot {
+				t.Errorf("Expected content extracted = %v, got Root = %v", tt.expectRoot, article.Root)
+			}
+			if article.Script != tt.expectScript {
+				t.Errorf("Expected script %q, got %q", tt.expectScript, article.Script)
+			}
+		})
+	}
+}
+
+func TestExtractLang(t *testing.T) {
+	// The same borderline page as TestExtractAutoThreshold, with and without a declared language
+	body := `<head><title>記事</title></head><body><article>` +
+		strings.Repeat("<p>本文抽出のアルゴリズムは段落を評価して記事を選びます。</p>", 5) +
+		`</article></body></html>`
+
+	tests := []struct {
+		name       string
+		html       string
+		lang       string
+		expectRoot bool
+		expectLang string
+	}{
+		{name: "no language", html: "<html>" + body, lang: "", expectRoot: false, expectLang: ""},
+		{name: "configured language", html: "<html>" + body, lang: "ja", expectRoot: true, expectLang: "ja"},
+		{name: "configured region subtag", html: "<html>" + body, lang: "zh-TW", expectRoot: true, expectLang: "zh-TW"},
+		{name: "declared language wins", html: `<html lang="en">` + body, lang: "ja", expectRoot: false, expectLang: "en"},
+		{name: "declared language", html: `<html lang="ja-JP">` + body, lang: "", expectRoot: true, expectLang: "ja-JP"},
+		{name: "declared language wins over a configured one", html: `<html lang="ja-JP">` + body, lang: "en", expectRoot: true, expectLang: "ja-JP"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultOptions()
+			options.Lang = tt.lang
+
+			article, err := Extract(tt.html, options)
+			if err != nil {
+				t.Fatalf("Extract failed: %v", err)
+			}
+			if (article.Root != nil) != tt.expectRoot {
+				t.Errorf("Expected content extracted = %v, got Root = %v", tt.expectRoot, article.Root)
+			}
+			if article.Lang != tt.expectLang {
+				t.Errorf("Expected lang %q, got %q", tt.expectLang, article.Lang)
+			}
+		})
+	}
+}
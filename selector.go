@@ -0,0 +1,208 @@
+// Package readability provides functionality to extract readable content from HTML documents.
+// It implements an algorithm similar to Mozilla's Readability.js to identify and extract
+// the main content from web pages, removing clutter, navigation, ads, and other non-content elements.
+package readability
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mackee/go-readability/internal/dom"
+)
+
+// attributeSelector is an attribute condition of a selector: [name] or [name=value].
+type attributeSelector struct {
+	name     string
+	value    string
+	hasValue bool
+}
+
+// compoundSelector is a selector for a single element, such as div.article-body#main[data-id].
+type compoundSelector struct {
+	tag        string // Lowercase tag name, or "" for any tag
+	id         string
+	classes    []string
+	attributes []attributeSelector
+}
+
+// selectorList is a list of compound selectors; an element matches if it matches any of them.
+type selectorList []compoundSelector
+
+// parseSelectors parses CSS selectors into a single selector list. Each selector may be a
+// comma-separated list of compound selectors made of a tag name (or *), #id, .class,
+// [attr], and [attr=value] parts. Combinators and pseudo-classes are not supported.
+//
+// Parameters:
+//   - selectors: The selectors to parse
+//
+// Returns:
+//   - The parsed selector list
+//   - An error describing the first invalid selector
+func parseSelectors(selectors []string) (selectorList, error) {
+	var list selectorList
+	for _, selector := range selectors {
+		for _, part := range strings.Split(selector, ",") {
+			compound, err := parseCompoundSelector(strings.TrimSpace(part))
+			if err != nil {
+				return nil, fmt.Errorf("invalid selector %q: %w", selector, err)
+			}
+			list = append(list, compound)
+		}
+	}
+	return list, nil
+}
+
+// parseCompoundSelector parses a selector for a single element.
+//
+// Parameters:
+//   - selector: The selector without surrounding whitespace
+//
+// Returns:
+//   - The parsed selector
+//   - An error if the selector is empty or uses unsupported syntax
+func parseCompoundSelector(selector string) (compoundSelector, error) {
+	var compound compoundSelector
+	if selector == "" {
+		return compound, fmt.Errorf("empty selector")
+	}
+
+	rest := selector
+	if strings.HasPrefix(rest, "*") {
+		rest = rest[1:]
+	} else if name := leadingIdentifier(rest); name != "" {
+		compound.tag = strings.ToLower(name)
+		rest = rest[len(name):]
+	}
+
+	for rest != "" {
+		switch rest[0] {
+		case '#', '.':
+			name := leadingIdentifier(rest[1:])
+			if name == "" {
+				return compound, fmt.Errorf("missing name after %q", rest[0])
+			}
+			if rest[0] == '#' {
+				compound.id = name
+			} else {
+				compound.classes = append(compound.classes, name)
+			}
+			rest = rest[1+len(name):]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return compound, fmt.Errorf("unclosed attribute selector")
+			}
+			attribute, err := parseAttributeSelector(rest[1:end])
+			if err != nil {
+				return compound, err
+			}
+			compound.attributes = append(compound.attributes, attribute)
+			rest = rest[end+1:]
+		default:
+			return compound, fmt.Errorf("unsupported syntax at %q", rest)
+		}
+	}
+	return compound, nil
+}
+
+// parseAttributeSelector parses the inside of an attribute selector: name or name=value,
+// where the value may be quoted.
+func parseAttributeSelector(inner string) (attributeSelector, error) {
+	name, value, hasValue := strings.Cut(inner, "=")
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" || leadingIdentifier(name) != name {
+		return attributeSelector{}, fmt.Errorf("invalid attribute name %q", name)
+	}
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+	}
+	return attributeSelector{name: name, value: value, hasValue: hasValue}, nil
+}
+
+// leadingIdentifier returns the longest prefix of s made of identifier characters
+// (letters, digits, hyphens, and underscores).
+func leadingIdentifier(s string) string {
+	end := 0
+	for end < len(s) {
+		c := s[end]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c >= 0x80) {
+			break
+		}
+		end++
+	}
+	return s[:end]
+}
+
+// matches checks whether an element matches the compound selector.
+func (c compoundSelector) matches(element *dom.VElement) bool {
+	if c.tag != "" && c.tag != element.TagName {
+		return false
+	}
+	if c.id != "" && c.id != element.ID() {
+		return false
+	}
+	if len(c.classes) > 0 {
+		classNames := strings.Fields(element.ClassName())
+		for _, class := range c.classes {
+			found := false
+			for _, className := range classNames {
+				if className == class {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+	}
+	for _, attribute := range c.attributes {
+		value, ok := element.Attributes[attribute.name]
+		if !ok || (attribute.hasValue && value != attribute.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// matches checks whether an element matches any selector of the list.
+func (l selectorList) matches(element *dom.VElement) bool {
+	for _, compound := range l {
+		if compound.matches(element) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectOutermost finds the elements under root that match the selector list, in document
+// order. Matches inside another match are part of its subtree and are not returned separately.
+//
+// Parameters:
+//   - root: The element to search (not matched itself)
+//   - list: The selectors to match
+//
+// Returns:
+//   - The outermost matching elements
+func selectOutermost(root *dom.VElement, list selectorList) []*dom.VElement {
+	var matched []*dom.VElement
+	var walk func(element *dom.VElement)
+	walk = func(element *dom.VElement) {
+		for _, child := range element.Children {
+			childElement, ok := dom.AsVElement(child)
+			if !ok {
+				continue
+			}
+			if list.matches(childElement) {
+				matched = append(matched, childElement)
+				continue
+			}
+			walk(childElement)
+		}
+	}
+	if root != nil {
+		walk(root)
+	}
+	return matched
+}
@@ -0,0 +1,94 @@
+package readability
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseSelectors(t *testing.T) {
+	valid := []string{"p", "*", ".article-body", "#main", "div.a.b", "[data-content]", `[data-role="main"]`, "h1, .lead"}
+	for _, selector := range valid {
+		if _, err := parseSelectors([]string{selector}); err != nil {
+			t.Errorf("Expected %q to parse, got %v", selector, err)
+		}
+	}
+
+	invalid := []string{"", "main .body", "div > p", "a:hover", "[unclosed", "#", "p,"}
+	for _, selector := range invalid {
+		if _, err := parseSelectors([]string{selector}); err == nil {
+			t.Errorf("Expected %q to be rejected", selector)
+		}
+	}
+}
+
+func TestSelectOutermost(t *testing.T) {
+	doc, err := ParseHTML(`<html><body>`+
+		`<div id="main" class="post"><p class="lead">Lead</p><p>Body</p></div>`+
+		`<p class="lead">Other</p><span data-role="main">Span</span><span data-role="aside">Aside</span>`+
+		`</body></html>`, "")
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	tests := []struct {
+		selector string
+		expected []string
+	}{
+		{".lead", []string{"Lead", "Other"}},
+		{"div.post, .lead", []string{"Lead Body", "Other"}},
+		{"#main", []string{"Lead Body"}},
+		{"span[data-role=main]", []string{"Span"}},
+		{"[data-role]", []string{"Span", "Aside"}},
+		{"P.LEAD", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			list, err := parseSelectors([]string{tt.selector})
+			if err != nil {
+				t.Fatalf("Failed to parse selector: %v", err)
+			}
+			var texts []string
+			for _, element := range selectOutermost(doc.Body, list) {
+				texts = append(texts, GetInnerText(element, false))
+			}
+			if strings.Join(texts, "|") != strings.Join(tt.expected, "|") {
+				t.Errorf("Expected %v, got %v", tt.expected, texts)
+			}
+		})
+	}
+}
+
+func TestExtractIncludeOnlySelectors(t *testing.T) {
+	html := `<html><head><title>Allowlist</title></head><body>` +
+		`<nav><a href="/">Home</a></nav>` +
+		`<header class="article-header"><h1>Allowlisted title</h1></header>` +
+		`<div class="sidebar">` + strings.Repeat(`<p>Sidebar text that scoring would pick because it is long enough.</p>`, 5) + `</div>` +
+		`<div class="article-body"><p>Short body.</p><script>track()</script></div>` +
+		`</body></html>`
+
+	options := DefaultOptions()
+	options.IncludeOnlySelectors = []string{".article-body", ".article-header"}
+
+	article, err := Extract(html, options)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if article.Root == nil {
+		t.Fatal("Expected content to be extracted")
+	}
+	if text := GetInnerText(article.Root, true); text != "Allowlisted title Short body." {
+		t.Errorf("Expected only the allowlisted subtrees in document order, got %q", text)
+	}
+	if len(GetElementsByTagName(article.Root, "header")) != 1 {
+		t.Error("Expected the allowlisted <header> to be kept")
+	}
+	if len(GetElementsByTagName(article.Root, "script")) != 0 {
+		t.Error("Expected scripts inside the allowlisted subtrees to be removed")
+	}
+
+	options.IncludeOnlySelectors = []string{"main .body"}
+	if _, err := Extract(html, options); !errors.Is(err, ErrInvalidOptions) {
+		t.Errorf("Expected ErrInvalidOptions, got %v", err)
+	}
+}
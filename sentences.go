@@ -0,0 +1,214 @@
+// Package readability provides functionality to extract readable content from HTML documents.
+// It implements an algorithm similar to Mozilla's Readability.js to identify and extract
+// the main content from web pages, removing clutter, navigation, ads, and other non-content elements.
+package readability
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/mackee/go-readability/internal/dom"
+)
+
+// sentenceAbbreviations lists, per language, the lowercase abbreviations (without their
+// final period) after which a period does not end a sentence.
+var sentenceAbbreviations = map[string]map[string]bool{
+	"en": toSet(
+		"mr", "mrs", "ms", "dr", "prof", "sr", "jr", "st", "mt", "rev", "gen", "gov", "sen", "rep",
+		"capt", "col", "lt", "sgt", "inc", "ltd", "co", "corp", "vs", "etc", "e.g", "i.e", "cf",
+		"al", "approx", "dept", "est", "fig", "no", "vol", "pp", "ed", "u.s", "u.k", "a.m", "p.m",
+		"jan", "feb", "mar", "apr", "jun", "jul", "aug", "sep", "sept", "oct", "nov", "dec",
+	),
+	"de": toSet("z.b", "d.h", "u.a", "usw", "bzw", "ca", "dr", "prof", "nr", "s", "vgl", "evtl", "ggf"),
+	"fr": toSet("m", "mme", "mlle", "dr", "p.ex", "etc", "cf", "av", "bd", "env"),
+}
+
+// sentenceClosers are the characters that stay with the sentence after its final
+// punctuation, such as closing quotes and brackets.
+const sentenceClosers = "\"')]}’”»」』）】〕"
+
+// toSet builds a set from a list of strings.
+func toSet(values ...string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
+
+// ToSentences extracts the text of an element and splits it into sentences,
+// one string per sentence with whitespace collapsed. Block elements and <br> always end
+// a sentence. Splitting is rule-based: a sentence ends at 。！？ (always) or at . ! ?
+// followed by whitespace, unless the period belongs to a known abbreviation or an
+// initial, or the next word starts in lowercase.
+//
+// Parameters:
+//   - root: The element to extract sentences from
+//   - lang: The language of the text (e.g. "en", "ja", "de-AT"), used to select the
+//     abbreviation list. An empty string is treated as English.
+//
+// Returns:
+//   - The sentences in document order, or nil if the element is nil or has no text
+func ToSentences(root *dom.VElement, lang string) []string {
+	if root == nil {
+		return nil
+	}
+
+	abbreviations := sentenceAbbreviations[sentenceLanguage(lang)]
+	var sentences []string
+	for _, block := range collectTextBlocks(root) {
+		sentences = append(sentences, splitSentences(block, abbreviations)...)
+	}
+	return sentences
+}
+
+// sentenceLanguage reduces a language tag to its lowercase primary subtag, defaulting to English.
+func sentenceLanguage(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	if lang == "" {
+		return "en"
+	}
+	return lang
+}
+
+// collectTextBlocks collects the text of an element as separate blocks, starting a new
+// block at every block element and <br>, so that sentences never span paragraphs.
+//
+// Parameters:
+//   - root: The element to collect text from
+//
+// Returns:
+//   - The non-empty text blocks, with whitespace collapsed
+func collectTextBlocks(root *dom.VElement) []string {
+	var blocks []string
+	var current strings.Builder
+	flush := func() {
+		if text := strings.Join(strings.Fields(current.String()), " "); text != "" {
+			blocks = append(blocks, text)
+		}
+		current.Reset()
+	}
+
+	var walk func(node dom.VNode)
+	walk = func(node dom.VNode) {
+		if text, ok := dom.AsVText(node); ok {
+			current.WriteString(text.TextContent)
+			return
+		}
+		element, ok := dom.AsVElement(node)
+		if !ok {
+			return
+		}
+		if element.TagName == "br" {
+			flush()
+			return
+		}
+		isBlock := blockElements[element.TagName] || element.TagName == "td" || element.TagName == "th"
+		if isBlock {
+			flush()
+		}
+		for _, child := range element.Children {
+			walk(child)
+		}
+		if isBlock {
+			flush()
+		}
+	}
+	walk(root)
+	flush()
+	return blocks
+}
+
+// splitSentences splits a block of text with collapsed whitespace into sentences.
+//
+// Parameters:
+//   - text: The text to split
+//   - abbreviations: The abbreviations that do not end a sentence (may be nil)
+//
+// Returns:
+//   - The sentences, trimmed
+func splitSentences(text string, abbreviations map[string]bool) []string {
+	runes := []rune(text)
+	var sentences []string
+	start := 0
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		var isEnd bool
+		switch r {
+		case '。', '！', '？', '｡':
+			isEnd = true
+		case '.', '!', '?':
+			// Keep runs like "?!" or "..." together
+			if i+1 < len(runes) && strings.ContainsRune(".!?", runes[i+1]) {
+				continue
+			}
+			isEnd = isSentenceEnd(runes, i, abbreviations)
+		}
+		if !isEnd {
+			continue
+		}
+
+		end := i + 1
+		for end < len(runes) && strings.ContainsRune(sentenceClosers, runes[end]) {
+			end++
+		}
+		if sentence := strings.TrimSpace(string(runes[start:end])); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		start = end
+		i = end - 1
+	}
+	if sentence := strings.TrimSpace(string(runes[start:])); sentence != "" {
+		sentences = append(sentences, sentence)
+	}
+	return sentences
+}
+
+// isSentenceEnd decides whether the Latin punctuation at runes[i] ends a sentence.
+//
+// Parameters:
+//   - runes: The text being split
+//   - i: The index of the '.', '!' or '?'
+//   - abbreviations: The abbreviations that do not end a sentence
+//
+// Returns:
+//   - true if a sentence ends at runes[i]
+func isSentenceEnd(runes []rune, i int, abbreviations map[string]bool) bool {
+	// The punctuation must be followed by whitespace (after any closing quotes) or the end
+	next := i + 1
+	for next < len(runes) && strings.ContainsRune(sentenceClosers, runes[next]) {
+		next++
+	}
+	if next == len(runes) {
+		return true
+	}
+	if !unicode.IsSpace(runes[next]) {
+		return false
+	}
+
+	// A lowercase word after the punctuation continues the sentence
+	for next < len(runes) && unicode.IsSpace(runes[next]) {
+		next++
+	}
+	if next < len(runes) && unicode.IsLower(runes[next]) {
+		return false
+	}
+
+	if runes[i] != '.' {
+		return true
+	}
+
+	// Check the word before the period for abbreviations and initials
+	wordStart := i
+	for wordStart > 0 && !unicode.IsSpace(runes[wordStart-1]) {
+		wordStart--
+	}
+	word := strings.TrimLeft(string(runes[wordStart:i]), "\"'([{“‘")
+	if wordRunes := []rune(word); len(wordRunes) == 1 && unicode.IsUpper(wordRunes[0]) {
+		return false
+	}
+	return !abbreviations[strings.ToLower(word)]
+}
@@ -0,0 +1,95 @@
+package readability
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestToSentences(t *testing.T) {
+	testCases := []struct {
+		name     string
+		html     string
+		lang     string
+		expected []string
+	}{
+		{
+			name: "simple English",
+			html: "<div><p>The sky is blue. The grass is green! Is it raining?</p></div>",
+			lang: "en",
+			expected: []string{
+				"The sky is blue.",
+				"The grass is green!",
+				"Is it raining?",
+			},
+		},
+		{
+			name: "English abbreviations",
+			html: "<div><p>Dr. Smith met Mr. Jones at 5 p.m. on Monday. They discussed fruits, e.g. apples and pears, etc. Then they left.</p></div>",
+			lang: "en",
+			expected: []string{
+				"Dr. Smith met Mr. Jones at 5 p.m. on Monday.",
+				"They discussed fruits, e.g. apples and pears, etc. Then they left.",
+			},
+		},
+		{
+			name: "initials, decimals, and quotes",
+			html: `<div><p>J. R. R. Tolkien wrote it in 1937. The price rose by 3.5 percent. She said "Stop." He stopped.</p></div>`,
+			lang: "",
+			expected: []string{
+				"J. R. R. Tolkien wrote it in 1937.",
+				"The price rose by 3.5 percent.",
+				`She said "Stop."`,
+				"He stopped.",
+			},
+		},
+		{
+			name: "Japanese full stops",
+			html: "<div><p>今日は晴れです。明日は雨が降るでしょう！「本当ですか？」と彼は聞いた。</p></div>",
+			lang: "ja",
+			expected: []string{
+				"今日は晴れです。",
+				"明日は雨が降るでしょう！",
+				"「本当ですか？」",
+				"と彼は聞いた。",
+			},
+		},
+		{
+			name: "blocks end sentences",
+			html: "<div><h2>Heading without period</h2><p>First paragraph<br>Second line</p><ul><li>Item one</li><li>Item two</li></ul></div>",
+			lang: "en",
+			expected: []string{
+				"Heading without period",
+				"First paragraph",
+				"Second line",
+				"Item one",
+				"Item two",
+			},
+		},
+		{
+			name: "inline elements do not split",
+			html: "<div><p>This is <strong>very</strong> important. <a href=\"#\">Read</a> it now.</p></div>",
+			lang: "en-US",
+			expected: []string{
+				"This is very important.",
+				"Read it now.",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := ParseHTML("<html><body>"+tc.html+"</body></html>", "")
+			if err != nil {
+				t.Fatalf("Failed to parse HTML: %v", err)
+			}
+			actual := ToSentences(doc.Body, tc.lang)
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("Expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+
+	if sentences := ToSentences(nil, "en"); sentences != nil {
+		t.Errorf("Expected nil for a nil element, got %q", sentences)
+	}
+}
@@ -136,8 +136,8 @@ func TestSiteExtraction(t *testing.T) {
 			}
 
 			// bylineを比較（nullの場合は空文字列として扱う）
-			// 注意: Go実装では、HTMLの本文中にある著者情報（<span itemprop="name">など）は
-			// 抽出されないため、テストケースによっては期待値と異なる場合があります
+			// 注意: Go実装では、本文中の著者情報はmicrodata（itemprop="author"）のみから
+			// 抽出されるため、テストケースによっては期待値と異なる場合があります
 			expectedByline := ""
 			if byline, ok := testPage.ExpectedMetadata.Byline.(string); ok {
 				expectedByline = byline